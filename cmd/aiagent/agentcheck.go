@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+	"github.com/egobogo/aiagents/internal/context/embedding/openai"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+	"github.com/egobogo/aiagents/internal/context/similarity/hnsw"
+	"github.com/egobogo/aiagents/internal/docs/notion"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/promptbuilder/chatgptpromptbuilder"
+)
+
+// runAgentCheck wires an engineering manager agent from the environment, reporting missing settings
+// along the way. It is a quick check of a new .env file: the agent's context is not built, so Notion,
+// OpenAI and Trello are not contacted. Only git is: the repository is cloned when GIT_REPO_PATH does
+// not exist yet.
+func runAgentCheck(args []string) error {
+	fs := flag.NewFlagSet("agent-check", flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigPath, "path to the YAML configuration")
+	fs.Parse(args)
+
+	loadEnv()
+	if err := loadConfig(*configPath); err != nil {
+		return err
+	}
+
+	for _, name := range []string{"OPENAI_API_KEY", "NOTION_TOKEN", "NOTION_PARENT_PAGE", "TRELLO_API_KEY", "TRELLO_TOKEN", "TRELLO_BOARD_ID"} {
+		if os.Getenv(name) == "" {
+			log.Printf("%s not set", name)
+		}
+	}
+	openaiAPIKey := os.Getenv("OPENAI_API_KEY")
+
+	modelClient := chatgpt.NewChatGPTClient(openaiAPIKey, "gpt-4o-mini", nil)
+	docsClient := notion.NewNotionClient(os.Getenv("NOTION_TOKEN"), os.Getenv("NOTION_PARENT_PAGE"))
+	boardClient := trelloClient.NewTrelloClient(os.Getenv("TRELLO_API_KEY"), os.Getenv("TRELLO_TOKEN"), os.Getenv("TRELLO_BOARD_ID"))
+
+	repoPath := strings.TrimSpace(os.Getenv("GIT_REPO_PATH"))
+	repoURL := os.Getenv("GIT_REPO_URL")
+	if repoPath == "" || repoURL == "" {
+		log.Println("GIT_REPO_PATH or GIT_REPO_URL not set")
+	}
+	gitClient, err := gitrepo.NewGitClient(repoURL, repoPath)
+	if err != nil {
+		log.Printf("Failed to create GitClient: %v", err)
+	} else {
+		log.Println("GitClient created successfully")
+	}
+
+	embeddingProvider := openai.NewOpenAIEmbeddingProvider(openaiAPIKey, "text-embedding-ada-002")
+	hnswSearcher, err := hnsw.New(embeddingProvider.Dimensions())
+	if err != nil {
+		return fmt.Errorf("failed to create HNSW SimilaritySearcher: %w", err)
+	}
+	ctxStorage, err := inmemory.NewInMemoryContextStorage(embeddingProvider, hnswSearcher)
+	if err != nil {
+		return fmt.Errorf("failed to create context storage: %w", err)
+	}
+
+	// NewEngineeringManagerAgent would build the context; construct the agent directly instead.
+	engAgent := &agent.EngineeringManagerAgent{BaseAgent: &agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   modelClient,
+		BoardClient:   boardClient,
+		DocsClient:    docsClient,
+		GitClient:     gitClient,
+		Context:       ctxStorage,
+		PromptBuilder: chatgptpromptbuilder.New(),
+	}}
+	log.Printf("Created agent %s", engAgent.Name)
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/egobogo/aiagents/internal/context/embedding/openai"
+)
+
+// cosineSimilarity computes the cosine similarity between two vectors.
+func cosineSimilarity(a, b []float64) (float64, error) {
+	if len(a) != len(b) {
+		return 0, fmt.Errorf("vectors must be the same length: got %d and %d", len(a), len(b))
+	}
+	var dot, normA, normB float64
+	for i := 0; i < len(a); i++ {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0, nil
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB)), nil
+}
+
+// runCosine embeds two sentences and prints their cosine similarity and distance.
+// The sentences default to a pair of near-paraphrases; pass two arguments to compare others.
+func runCosine(args []string) error {
+	fs := flag.NewFlagSet("cosine", flag.ExitOnError)
+	embeddingModel := fs.String("model", "text-embedding-ada-002", "embedding model")
+	fs.Parse(args)
+
+	sentence1 := "The architecture is modular and event-driven, ensuring each module can develop independently with clear interfaces. Integrates seamlessly with external tools like Notion and Trello, enhancing adaptability and scalability."
+	sentence2 := "Architecture Modular and event-driven architecture to ensure independent development and seamless integration with tools like Notion and Trello"
+	if fs.NArg() == 2 {
+		sentence1, sentence2 = fs.Arg(0), fs.Arg(1)
+	} else if fs.NArg() != 0 {
+		return fmt.Errorf("cosine takes zero or two sentences, got %d arguments", fs.NArg())
+	}
+
+	loadEnv()
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return fmt.Errorf("OPENAI_API_KEY is not set")
+	}
+	embProvider := openai.NewOpenAIEmbeddingProvider(apiKey, *embeddingModel)
+
+	emb1, err := embProvider.ComputeEmbedding(sentence1)
+	if err != nil {
+		return fmt.Errorf("failed to compute embedding for the first sentence: %w", err)
+	}
+	emb2, err := embProvider.ComputeEmbedding(sentence2)
+	if err != nil {
+		return fmt.Errorf("failed to compute embedding for the second sentence: %w", err)
+	}
+
+	similarity, err := cosineSimilarity(emb1, emb2)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Cosine similarity: %f\n", similarity)
+	fmt.Printf("Cosine distance: %f\n", 1-similarity)
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"github.com/egobogo/aiagents/internal/cli"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/config/filesys"
+)
+
+// defaultConfigPath is the configuration loaded when a command is not given -config.
+const defaultConfigPath = "cfg/main.cfg.yaml"
+
+// commands lists the subcommands of the binary; "run" is used when none is named.
+var commands = []cli.Command{
+	{Name: "run", Summary: "poll the board and answer tickets (default)", Run: runAgent},
+	{Name: "workflow", Summary: "step through the configured workflow interactively", Run: runWorkflow},
+	{Name: "agent-check", Summary: "wire the engineering manager agent from the environment without building its context, and exit", Run: runAgentCheck},
+	{Name: "cosine", Summary: "print the embedding similarity of two sentences", Run: runCosine},
+}
+
+func main() {
+	if err := cli.Dispatch(commands, "run", os.Args[1:], os.Stderr); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadEnv loads variables from a .env file when one is present.
+func loadEnv() {
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found; using system environment variables")
+	}
+}
+
+// loadConfig registers the YAML file at path as the config provider and loads it.
+func loadConfig(path string) error {
+	prov, err := filesys.NewFilesysConfigProvider(path)
+	if err != nil {
+		return fmt.Errorf("could not create config provider: %w", err)
+	}
+	config.SetProvider(prov)
+	if err := config.Load(path); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return nil
+}
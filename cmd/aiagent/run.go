@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+	"github.com/egobogo/aiagents/internal/budget"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/context/embedding/openai"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+	"github.com/egobogo/aiagents/internal/context/similarity/hnsw"
+	"github.com/egobogo/aiagents/internal/context/summarizing"
+	"github.com/egobogo/aiagents/internal/docs/notion"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/httputil"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+	"github.com/egobogo/aiagents/internal/notify"
+	"github.com/egobogo/aiagents/internal/notify/slack"
+	"github.com/egobogo/aiagents/internal/poller"
+	"github.com/egobogo/aiagents/internal/promptbuilder/chatgptpromptbuilder"
+	"github.com/egobogo/aiagents/internal/ratelimit"
+	"github.com/egobogo/aiagents/internal/respcache"
+)
+
+// runAgent polls the board and lets the engineering manager answer the tickets until interrupted.
+func runAgent(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigPath, "path to the YAML configuration")
+	interval := fs.Duration("interval", 30*time.Second, "wait between board polls")
+	onlyChanged := fs.Bool("only-changed", false, "after the first poll, only process tickets changed since the previous one")
+	fs.Parse(args)
+
+	loadEnv()
+	if err := loadConfig(*configPath); err != nil {
+		return err
+	}
+
+	openaiAPIKey := os.Getenv("OPENAI_API_KEY")
+	if openaiAPIKey == "" {
+		return fmt.Errorf("OPENAI_API_KEY is not set")
+	}
+
+	// One limiter caps the combined rate of chat, embedding and file requests to OpenAI.
+	rl := config.GetLoadedConfig().RateLimit
+	limiter := ratelimit.NewLimiter(rl.RequestsPerMinute, rl.Burst)
+
+	// All outbound requests share one client so a configured proxy and CA file apply everywhere.
+	httpCfg := config.GetLoadedConfig().HTTP
+	httpClient, err := httputil.NewClient(httpCfg.Proxy, httpCfg.CAFile)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	vsClient := vectorstorage.NewClient(openaiAPIKey)
+	vsClient.Limiter = limiter
+	vsClient.HTTPClient = httpClient
+	modelClient := chatgpt.NewChatGPTClient(openaiAPIKey, "gpt-4o-mini", vsClient)
+	modelClient.Limiter = limiter
+	modelClient.HTTPClient = httpClient
+	if rc := config.GetLoadedConfig().ResponseCache; rc.Dir != "" {
+		diskCache, err := respcache.NewDisk(rc.Dir)
+		if err != nil {
+			return fmt.Errorf("failed to create response cache: %w", err)
+		}
+		modelClient.Cache = diskCache
+	} else if rc.Entries > 0 {
+		modelClient.Cache = respcache.NewLRU(rc.Entries)
+	}
+	docsClient := notion.NewNotionClient(os.Getenv("NOTION_TOKEN"), os.Getenv("NOTION_PARENT_PAGE"))
+	docsClient.HTTPClient = httpClient
+	boardClient := trelloClient.NewTrelloClient(os.Getenv("TRELLO_API_KEY"), os.Getenv("TRELLO_TOKEN"), os.Getenv("TRELLO_BOARD_ID"))
+	boardClient.Client.Client = httpClient
+
+	// Verify every service up front so a bad token or board ID fails here instead of mid-ticket.
+	preflight := []struct {
+		name string
+		ping func(context.Context) error
+	}{
+		{"OpenAI", modelClient.Ping},
+		{"OpenAI vector storage", vsClient.Ping},
+		{"Notion", docsClient.Ping},
+		{"Trello", boardClient.Ping},
+	}
+	pingCtx, cancelPing := context.WithTimeout(context.Background(), 30*time.Second)
+	for _, check := range preflight {
+		if err := check.ping(pingCtx); err != nil {
+			cancelPing()
+			return fmt.Errorf("startup check for %s failed: %w", check.name, err)
+		}
+	}
+	cancelPing()
+	if err := modelClient.ValidateModel(); err != nil {
+		return fmt.Errorf("startup check for the OpenAI model failed: %w", err)
+	}
+
+	gitClient, err := gitrepo.NewGitClient(os.Getenv("GIT_REPO_URL"), strings.TrimSpace(os.Getenv("GIT_REPO_PATH")))
+	if err != nil {
+		return fmt.Errorf("failed to create GitClient: %w", err)
+	}
+
+	// Embeddings go through the chat client so both share its key, HTTP client and rate limiter.
+	embeddingProvider := openai.NewOpenAIEmbeddingProvider(openaiAPIKey, modelClient.EmbeddingModel)
+	embeddingProvider.SetEmbedder(modelClient)
+
+	// One guard meters chat and embedding spend against the configured ceiling.
+	if guard := newBudgetGuard(); guard != nil {
+		modelClient.Budget = guard
+		embeddingProvider.SetBudgetGuard(guard)
+	}
+	hnswSearcher, err := hnsw.New(embeddingProvider.Dimensions())
+	if err != nil {
+		return fmt.Errorf("failed to create HNSW SimilaritySearcher: %w", err)
+	}
+	ctxStorage, err := inmemory.NewInMemoryContextStorage(embeddingProvider, hnswSearcher)
+	if err != nil {
+		return fmt.Errorf("failed to create context storage: %w", err)
+	}
+
+	var notifier notify.Notifier
+	if webhook := os.Getenv("SLACK_WEBHOOK_URL"); webhook != "" {
+		slackNotifier := slack.NewSlackNotifier(webhook)
+		slackNotifier.HTTPClient = httpClient
+		notifier = slackNotifier
+	}
+
+	engAgent := agent.NewEngineeringManagerAgent(&agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   modelClient,
+		BoardClient:   boardClient,
+		DocsClient:    docsClient,
+		GitClient:     gitClient,
+		Context:       summarizing.New(ctxStorage, modelClient, config.GetLoadedConfig().Context.MaxTokens),
+		PromptBuilder: chatgptpromptbuilder.New(),
+		VectorStorage: vsClient,
+		Embeddings:    embeddingProvider,
+		Notifier:      notifier,
+		NotifyChannel: os.Getenv("SLACK_CHANNEL"),
+	})
+
+	// Cancel the context on Ctrl-C / SIGTERM so the in-flight ticket finishes before exiting.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	p := poller.New(engAgent, func(ctx context.Context, ticket board.Card) error {
+		handled, err := engAgent.WithTicket(ticket, func() error {
+			if err := engAgent.RefreshContext(); err != nil {
+				log.Printf("Warning: failed to refresh context: %v", err)
+			}
+			if _, err := engAgent.IngestTicketSpec(ticket); err != nil {
+				log.Printf("Warning: failed to ingest attached spec of %q: %v", ticket.GetName(), err)
+			}
+			answer, err := engAgent.Answer("Ticket on the board: "+ticket.GetURL(), ticket.GetName(), nil)
+			if err != nil {
+				return err
+			}
+			return ticket.WriteComment(answer.Text())
+		})
+		if !handled {
+			log.Printf("Skipping %q: already being processed", ticket.GetName())
+		}
+		return err
+	})
+
+	p.OnlyChanged = *onlyChanged
+
+	log.Printf("Polling the board every %s", *interval)
+	if err := p.Run(ctx, *interval); err != nil {
+		return fmt.Errorf("agent loop failed: %w", err)
+	}
+	log.Println("Shutting down")
+	return nil
+}
+
+// newBudgetGuard builds the spend guard from the budget section of the configuration, pricing the
+// built-in models and any rates the section adds. It returns nil when no ceiling is configured.
+func newBudgetGuard() *budget.BudgetGuard {
+	cfg := config.GetLoadedConfig().Budget
+	if cfg.Ceiling <= 0 {
+		return nil
+	}
+	rates := chatgpt.BudgetRates(chatgpt.Cheap, chatgpt.ExpensiveCoding)
+	for model, rate := range cfg.Rates {
+		rates[model] = rate
+	}
+	return budget.NewBudgetGuard(cfg.Ceiling, rates)
+}
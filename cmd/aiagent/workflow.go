@@ -1,8 +1,9 @@
-// File: cmd/manual_workflow.go
 package main
 
 import (
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -10,19 +11,17 @@ import (
 	"strings"
 
 	"github.com/egobogo/aiagents/internal/config"
-	"github.com/egobogo/aiagents/internal/config/filesys"
 	"github.com/egobogo/aiagents/internal/workflow"
 )
 
-func main() {
-	// Load configuration from the absolute or relative path (adjust as needed)
-	prov, err := filesys.NewFilesysConfigProvider("cfg/main.cfg.yaml")
-	if err != nil {
-		log.Fatalf("Could not create config provider: %v", err)
-	}
-	config.SetProvider(prov)
-	if err := config.Load("cfg/main.cfg.yaml"); err != nil {
-		log.Fatalf("Failed to load configuration: %v", err)
+// runWorkflow walks through the configured workflow, asking on stdin which branch to take at each choice.
+func runWorkflow(args []string) error {
+	fs := flag.NewFlagSet("workflow", flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigPath, "path to the YAML configuration")
+	fs.Parse(args)
+
+	if err := loadConfig(*configPath); err != nil {
+		return err
 	}
 
 	// Create a new workflow manager using the loaded configuration.
@@ -32,20 +31,34 @@ func main() {
 	for {
 		current, err := wm.CurrentStep()
 		if err != nil {
-			log.Fatalf("Error getting current step: %v", err)
+			return fmt.Errorf("error getting current step: %w", err)
 		}
 		fmt.Printf("\nCurrent Step: %s\nDescription: %s\n", current.Name, current.Description)
 
 		// If the current action indicates completion, exit.
-		if strings.ToLower(current.Action) == "close_ticket" {
+		if strings.EqualFold(current.Action, config.CloseTicketAction) {
 			fmt.Println("Workflow complete. Ticket closed.")
-			break
+			return nil
+		}
+
+		// Run the step's action if a handler is registered for it.
+		if err := wm.Execute(); err != nil && !errors.Is(err, workflow.ErrUnknownAction) {
+			log.Printf("Warning: %v", err)
+		}
+
+		// Steps with a single next step need no input; move on and show the new step.
+		advanced, err := wm.AutoAdvance()
+		if err != nil {
+			return fmt.Errorf("error advancing workflow: %w", err)
+		}
+		if advanced {
+			continue
 		}
 
 		// Display next choices.
 		choices, err := wm.NextChoices()
 		if err != nil {
-			log.Fatalf("Error getting next choices: %v", err)
+			return fmt.Errorf("error getting next choices: %w", err)
 		}
 		fmt.Println("Next choices:")
 		for i, choice := range choices {
@@ -55,7 +68,7 @@ func main() {
 		fmt.Print("Enter your choice: ")
 		input, err := reader.ReadString('\n')
 		if err != nil {
-			log.Fatalf("Error reading input: %v", err)
+			return fmt.Errorf("error reading input: %w", err)
 		}
 		input = strings.TrimSpace(input)
 		choice, err := strconv.Atoi(input)
@@ -66,7 +79,7 @@ func main() {
 
 		// Advance to the chosen step.
 		if err := wm.NextStep(choices[choice-1].NextStep); err != nil {
-			log.Fatalf("Error advancing to step: %v", err)
+			return fmt.Errorf("error advancing to step: %w", err)
 		}
 	}
 }
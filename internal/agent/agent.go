@@ -3,30 +3,50 @@ package agent
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
 	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/context/embedding"
 	"github.com/egobogo/aiagents/internal/docs"
 	"github.com/egobogo/aiagents/internal/gitrepo"
 	"github.com/egobogo/aiagents/internal/model"
 	mclient "github.com/egobogo/aiagents/internal/model"
 	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+	"github.com/egobogo/aiagents/internal/notify"
 	pb "github.com/egobogo/aiagents/internal/promptbuilder"
+	"github.com/invopop/jsonschema"
 )
 
 // Agent defines the basic operations available to any agent.
 type Agent interface {
 	Act() error
 	FindMyTickets() ([]board.Card, error)
-	Think(senderContext, userInput, mode string, desiredOutput interface{}) (mclient.Message, error)
+	Think(senderContext, userInput, mode, modelName string, desiredOutput interface{}) (mclient.Message, error)
 	Answer(senderContext, userInput string, desiredOutput interface{}) (mclient.Message, error)
-	CreateThoughts(userInput string, attachments []model.FileAttachment, webSearch *model.WebSearch) ([]context.EasyMemory, error)
+	CreateThoughts(userInput string, attachments []model.FileAttachment, webSearch *model.WebSearch, modelName string) ([]context.EasyMemory, error)
 	createContext() error
 }
 
+// The specialized agents all satisfy Agent.
+var (
+	_ Agent = (*BaseAgent)(nil)
+	_ Agent = (*EngineeringManagerAgent)(nil)
+	_ Agent = (*ProductManagerAgent)(nil)
+	_ Agent = (*QAAgent)(nil)
+	_ Agent = (*DesignerAgent)(nil)
+	_ Agent = (*DeveloperAgent)(nil)
+)
+
 // BaseAgent provides the common functionality for all agents.
 type BaseAgent struct {
-	Name            string
+	Name string
+	// CurrentTicketID is the URL of the ticket most recently acquired with AcquireTicket,
+	// cleared again when that ticket is released.
 	CurrentTicketID string
 	Role            string
 
@@ -37,6 +57,65 @@ type BaseAgent struct {
 	Context       context.ContextStorage
 	PromptBuilder pb.PromptBuilder
 	VectorStorage *vectorstorage.Client
+
+	// Embeddings, when set, lets CreateThoughts collapse near-duplicate thoughts. It is optional.
+	Embeddings embedding.EmbeddingProvider
+
+	// Notifier receives messages about key ticket events. It is optional.
+	Notifier notify.Notifier
+	// NotifyChannel is the channel passed to Notifier; empty means the notifier's default.
+	NotifyChannel string
+
+	// StateDir is the directory where the agent persists its state between runs.
+	// An empty value means the current working directory.
+	StateDir string
+
+	// ticketsMu guards inFlight and CurrentTicketID.
+	ticketsMu sync.Mutex
+	// inFlight holds the URLs of the tickets currently being processed.
+	inFlight map[string]struct{}
+}
+
+// statePath returns the location of a persisted state file inside StateDir.
+func (a *BaseAgent) statePath(name string) string {
+	return filepath.Join(a.StateDir, name)
+}
+
+// notify sends a message through the agent's Notifier, if one is configured.
+// Delivery failures are logged rather than returned so they never block ticket work.
+func (a *BaseAgent) notify(format string, args ...interface{}) {
+	if a.Notifier == nil {
+		return
+	}
+	if err := a.Notifier.Notify(a.NotifyChannel, fmt.Sprintf(format, args...)); err != nil {
+		fmt.Printf("Warning: failed to send notification: %v\n", err)
+	}
+}
+
+// Act performs one round of the agent's own work. The base agent has none;
+// specialized agents override it.
+func (a *BaseAgent) Act() error {
+	return nil
+}
+
+// createContext builds the agent's initial context. The base agent starts empty.
+func (a *BaseAgent) createContext() error {
+	return nil
+}
+
+// temperature returns the sampling temperature for a mode: the configured per-mode value,
+// or the model client's temperature when the mode has none.
+func (a *BaseAgent) temperature(mode string) float64 {
+	return config.GetModeTemperature(mode, a.ModelClient.GetTemperature())
+}
+
+// model returns the model for a mode: modelName when set, otherwise the configured per-mode model,
+// or the model client's model when the mode has none.
+func (a *BaseAgent) model(mode, modelName string) string {
+	if modelName != "" {
+		return modelName
+	}
+	return config.GetModeModel(mode, a.ModelClient.GetModel())
 }
 
 // FindMyTickets retrieves board cards assigned to this agent.
@@ -44,10 +123,32 @@ func (a *BaseAgent) FindMyTickets() ([]board.Card, error) {
 	return a.BoardClient.GetCardsAssignedTo(a.Name)
 }
 
+// FindMyTicketsUpdatedSince retrieves the board cards assigned to this agent that changed after since.
+func (a *BaseAgent) FindMyTicketsUpdatedSince(since time.Time) ([]board.Card, error) {
+	cards, err := a.BoardClient.GetCardsUpdatedSince(since)
+	if err != nil {
+		return nil, err
+	}
+	var mine []board.Card
+	for _, card := range cards {
+		members, err := card.GetAssignedMembers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get members of %q: %w", card.GetName(), err)
+		}
+		for _, m := range members {
+			if strings.EqualFold(m.Name, a.Name) {
+				mine = append(mine, card)
+				break
+			}
+		}
+	}
+	return mine, nil
+}
+
 // Think builds a request, obtains a response, and updates context.
-func (a *BaseAgent) Think(senderContext, userInput, mode string, desiredOutput interface{}) (mclient.Message, error) {
+func (a *BaseAgent) Think(senderContext, userInput, mode, modelName string, desiredOutput interface{}) (mclient.Message, error) {
 	combinedInput := fmt.Sprintf("Context of the sender:\n%s\n\nThe query of the sender:\n%s", senderContext, userInput)
-	newMemories, err := a.CreateThoughts(combinedInput, nil, nil)
+	newMemories, err := a.CreateThoughts(combinedInput, nil, nil, "")
 	if err != nil {
 		return mclient.Message{}, fmt.Errorf("failed to summarize new input: %w", err)
 	}
@@ -72,8 +173,8 @@ func (a *BaseAgent) Think(senderContext, userInput, mode string, desiredOutput i
 		updatedContext,
 		userInput,
 		desiredOutput,
-		a.ModelClient.GetTemperature(),
-		a.ModelClient.GetModel(),
+		a.temperature(mode),
+		a.model(mode, modelName),
 	)
 	if err != nil {
 		return mclient.Message{}, fmt.Errorf("failed to build task request: %w", err)
@@ -84,11 +185,13 @@ func (a *BaseAgent) Think(senderContext, userInput, mode string, desiredOutput i
 		return mclient.Message{}, fmt.Errorf("failed to get task response: %w", err)
 	}
 
-	additionalMemories, err := a.CreateThoughts(taskResponse, nil, nil)
+	additionalMemories, err := a.CreateThoughts(taskResponse, nil, nil, "")
 	if err != nil {
 		fmt.Printf("Warning: failed to summarize task response for additional memories: %v\n", err)
 		additionalMemories = []context.EasyMemory{}
 	}
+	// The response usually restates what the input already taught; keep only what is new.
+	additionalMemories = a.dedupeThoughts(newMemories, additionalMemories)
 
 	relevantAdditional := a.Context.FilterRelatedMemories(additionalMemories)
 	if err := a.RefreshMemories(relevantAdditional, additionalMemories); err != nil {
@@ -103,11 +206,19 @@ func (a *BaseAgent) Think(senderContext, userInput, mode string, desiredOutput i
 
 // Answer is a wrapper around Think using mode "Answer".
 func (a *BaseAgent) Answer(senderContext, userInput string, desiredOutput interface{}) (mclient.Message, error) {
-	return a.Think(senderContext, userInput, "Answer", desiredOutput)
+	return a.Think(senderContext, userInput, "Answer", "", desiredOutput)
 }
 
 // CreateThoughts requests a structured output of memories and unmarshals it into []EasyMemory.
-func (a *BaseAgent) CreateThoughts(userInput string, attachments []model.FileAttachment, webSearch *model.WebSearch) ([]context.EasyMemory, error) {
+// Repeated thoughts are removed and the rest ordered by importance.
+func (a *BaseAgent) CreateThoughts(userInput string, attachments []model.FileAttachment, webSearch *model.WebSearch, modelName string) ([]context.EasyMemory, error) {
+	thoughts, _, err := a.CreateThoughtsWithSources(userInput, attachments, webSearch, modelName)
+	return thoughts, err
+}
+
+// CreateThoughtsWithSources works like CreateThoughts and also returns the IDs of the attached files the
+// model retrieved or cited. The IDs are nil when the model client is not a model.SourceReportingClient.
+func (a *BaseAgent) CreateThoughtsWithSources(userInput string, attachments []model.FileAttachment, webSearch *model.WebSearch, modelName string) ([]context.EasyMemory, []string, error) {
 	var userPrompt string
 	// If attachments are provided, extract the unique vector store IDs.
 	var vectorStoreIDs []string
@@ -136,24 +247,24 @@ func (a *BaseAgent) CreateThoughts(userInput string, attachments []model.FileAtt
 		a.Context.GetContext(),
 		userPrompt,
 		desiredOutput,
-		a.ModelClient.GetTemperature(),
-		a.ModelClient.GetModel(),
+		a.temperature("Summarize"),
+		a.model("Summarize", modelName),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build chat request: %w", err)
+		return nil, nil, fmt.Errorf("failed to build chat request: %w", err)
 	}
 
 	if len(vectorStoreIDs) > 0 {
 		// Attach the file search tool block to the ChatRequest.
 		if err := a.PromptBuilder.AddFile(&chatReq, vectorStoreIDs); err != nil {
-			return nil, fmt.Errorf("failed to add file tool: %w", err)
+			return nil, nil, fmt.Errorf("failed to add file tool: %w", err)
 		}
 	}
 
 	// If a web search configuration is provided, attach it.
 	if webSearch != nil {
 		if err := a.PromptBuilder.AddWeb(&chatReq, *webSearch); err != nil {
-			return nil, fmt.Errorf("failed to add web search tool: %w", err)
+			return nil, nil, fmt.Errorf("failed to add web search tool: %w", err)
 		}
 	}
 
@@ -161,15 +272,25 @@ func (a *BaseAgent) CreateThoughts(userInput string, attachments []model.FileAtt
 	var wrapper struct {
 		Result []context.EasyMemory `json:"result"`
 	}
-	if err := a.ModelClient.ChatAdvancedParsed(chatReq, &wrapper); err != nil {
-		return nil, fmt.Errorf("failed to parse CreateThoughts response: %w", err)
+	var sources []string
+	if reporter, ok := a.ModelClient.(model.SourceReportingClient); ok {
+		sources, err = reporter.ChatAdvancedParsedWithSources(chatReq, &wrapper)
+	} else {
+		err = a.ModelClient.ChatAdvancedParsed(chatReq, &wrapper)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse CreateThoughts response: %w", err)
 	}
 
-	return wrapper.Result, nil
+	return a.dedupeThoughts(nil, wrapper.Result), sources, nil
 }
 
 // BuildContext merges new and old memories into an updated context.
+// The old memories are narrowed with context.SelectContextMemoriesCapped first, so the most important
+// ones are merged and low-importance ones cannot crowd them out (see the context section of the config).
 func (a *BaseAgent) BuildContext(newMemories []context.EasyMemory, oldMemories []context.MemoryEntry) (string, error) {
+	budget, maxLow := contextSelectionLimits()
+	oldMemories = context.SelectContextMemoriesCapped(oldMemories, budget, maxLow)
 	priorHot := a.Context.GetContext()
 	if priorHot == "" && len(oldMemories) == 0 {
 		return fmt.Sprintf("Context:\n%v", newMemories), nil
@@ -182,8 +303,8 @@ func (a *BaseAgent) BuildContext(newMemories []context.EasyMemory, oldMemories [
 		priorHot,
 		prompt,
 		nil,
-		a.ModelClient.GetTemperature(),
-		a.ModelClient.GetModel(),
+		a.temperature("ActualizeContext"),
+		a.model("ActualizeContext", ""),
 	)
 	if err != nil {
 		return "", fmt.Errorf("failed to build hot context merge request: %w", err)
@@ -197,27 +318,97 @@ func (a *BaseAgent) BuildContext(newMemories []context.EasyMemory, oldMemories [
 	return mergedHot, nil
 }
 
+// contextSelectionLimits returns the memory budget and low-importance cap configured for BuildContext.
+func contextSelectionLimits() (budget, maxLow int) {
+	budget, maxLow = context.DefaultContextBudget, context.DefaultMaxLowImportance
+	if cfg := config.GetLoadedConfig(); cfg != nil {
+		if cfg.Context.MaxMemories > 0 {
+			budget = cfg.Context.MaxMemories
+		}
+		if cfg.Context.MaxLowImportance != 0 {
+			maxLow = cfg.Context.MaxLowImportance
+		}
+	}
+	return budget, maxLow
+}
+
+// MemoryRefresh reports the outcome of RefreshMemoriesReport.
+type MemoryRefresh struct {
+	// Forgotten lists the IDs of the old memories that were deleted.
+	Forgotten []string
+	// InvalidIDs lists the IDs the model asked to delete that were not among the old memories
+	// or are no longer stored; they are skipped.
+	InvalidIDs []string
+}
+
+// memoryDeletion is the response RefreshMemoriesReport asks the model for.
+type memoryDeletion struct {
+	DeleteIDs []string `json:"delete_ids"`
+
+	allowedIDs []string // the IDs the model may choose from; not part of the response
+}
+
+// JSONSchemaExtend restricts the items of delete_ids to the allowed IDs.
+func (d memoryDeletion) JSONSchemaExtend(s *jsonschema.Schema) {
+	if len(d.allowedIDs) == 0 {
+		return
+	}
+	prop, ok := s.Properties.Get("delete_ids")
+	if !ok || prop.Items == nil {
+		return
+	}
+	prop.Items.Enum = make([]any, 0, len(d.allowedIDs))
+	for _, id := range d.allowedIDs {
+		prop.Items.Enum = append(prop.Items.Enum, id)
+	}
+}
+
 // RefreshMemories asks the model which memories to delete and updates context accordingly.
+// Deletion IDs the model invents are skipped with a warning; see RefreshMemoriesReport.
 func (a *BaseAgent) RefreshMemories(oldMems []context.MemoryEntry, newMems []context.EasyMemory) error {
+	report, err := a.RefreshMemoriesReport(oldMems, newMems)
+	if err != nil {
+		return err
+	}
+	if len(report.InvalidIDs) > 0 {
+		fmt.Printf("Warning: skipped %d unknown memory ID(s) proposed for deletion: %s\n", len(report.InvalidIDs), strings.Join(report.InvalidIDs, ", "))
+	}
+	return nil
+}
+
+// RefreshMemoriesReport asks the model which of oldMems the new memories make obsolete, forgets them
+// and remembers newMems. The prompt lists the IDs the model may choose from; any other ID, or one that
+// is no longer stored, is not forgotten and is reported in InvalidIDs instead.
+// With no old memories there is nothing to make obsolete, so newMems are remembered without asking
+// the model.
+func (a *BaseAgent) RefreshMemoriesReport(oldMems []context.MemoryEntry, newMems []context.EasyMemory) (MemoryRefresh, error) {
+	var report MemoryRefresh
+	if len(oldMems) == 0 {
+		return report, a.rememberAll(newMems)
+	}
 	oldJSON, err := json.MarshalIndent(oldMems, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal old memories: %w", err)
+		return report, fmt.Errorf("failed to marshal old memories: %w", err)
 	}
 	newJSON, err := json.MarshalIndent(newMems, "", "  ")
 	if err != nil {
-		return fmt.Errorf("failed to marshal new memories: %w", err)
-	}
-	if len(oldMems) == 0 {
-		return nil
+		return report, fmt.Errorf("failed to marshal new memories: %w", err)
 	}
 
-	prompt := fmt.Sprintf("Old Memories:\n%s\nNew Memories:\n%s", string(oldJSON), string(newJSON))
-
-	// Define the expected deletion response.
-	type DeleteResponse struct {
-		DeleteIDs []string `json:"delete_ids"`
+	allowed := make(map[string]bool, len(oldMems))
+	ids := make([]string, 0, len(oldMems))
+	for _, m := range oldMems {
+		if !allowed[m.ID] {
+			allowed[m.ID] = true
+			ids = append(ids, m.ID)
+		}
 	}
-	desiredOutput := DeleteResponse{}
+	prompt := fmt.Sprintf("Old Memories:\n%s\nNew Memories:\n%s\n"+
+		"delete_ids may only contain IDs of old memories, chosen from this list: %s. "+
+		"Leave it empty to keep every old memory.",
+		string(oldJSON), string(newJSON), strings.Join(ids, ", "))
+
+	desiredOutput := memoryDeletion{allowedIDs: ids}
 
 	chatReq, err := a.PromptBuilder.Build(
 		a.Role,
@@ -225,24 +416,41 @@ func (a *BaseAgent) RefreshMemories(oldMems []context.MemoryEntry, newMems []con
 		a.Context.GetContext(),
 		prompt,
 		desiredOutput,
-		a.ModelClient.GetTemperature(),
-		a.ModelClient.GetModel(),
+		a.temperature("RefreshMemories"),
+		a.model("RefreshMemories", ""),
 	)
 	if err != nil {
-		return fmt.Errorf("failed to build refreshMemories chat request: %w", err)
+		return report, fmt.Errorf("failed to build refreshMemories chat request: %w", err)
 	}
 
-	var delResp DeleteResponse
+	var delResp memoryDeletion
 	if err := a.ModelClient.ChatAdvancedParsed(chatReq, &delResp); err != nil {
-		return fmt.Errorf("failed to parse refreshMemories response: %w", err)
+		return report, fmt.Errorf("failed to parse refreshMemories response: %w", err)
 	}
 
+	seen := make(map[string]bool, len(delResp.DeleteIDs))
 	for _, id := range delResp.DeleteIDs {
+		id = strings.TrimSpace(id)
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if !allowed[id] || !a.Context.MemoryExists(id) {
+			report.InvalidIDs = append(report.InvalidIDs, id)
+			continue
+		}
 		if err := a.Context.Forget(id); err != nil {
 			fmt.Printf("Warning: failed to forget memory with ID %s: %v\n", id, err)
+			continue
 		}
+		report.Forgotten = append(report.Forgotten, id)
 	}
 
+	return report, a.rememberAll(newMems)
+}
+
+// rememberAll stores each new memory, logging the ones that fail.
+func (a *BaseAgent) rememberAll(newMems []context.EasyMemory) error {
 	for _, emem := range newMems {
 		if err := a.Context.Remember(emem); err != nil {
 			fmt.Printf("Warning: failed to add new memory: %v\n", err)
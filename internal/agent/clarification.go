@@ -0,0 +1,113 @@
+package agent
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// managerMention is the tag developers put in a card comment to ask the manager a question.
+const managerMention = "@manager"
+
+// clarificationMarkerPrefix starts every answer comment so handled questions can be recognized on later polls.
+const clarificationMarkerPrefix = "[clarification:"
+
+// ClarificationAnswer is the structured answer the model produces for a clarification question.
+type ClarificationAnswer struct {
+	Answer string `json:"answer"`
+}
+
+// clarificationMarker returns the marker identifying the answer to a question.
+func clarificationMarker(question string) string {
+	sum := sha1.Sum([]byte(strings.TrimSpace(question)))
+	return clarificationMarkerPrefix + hex.EncodeToString(sum[:])[:10] + "]"
+}
+
+// Act answers the open clarification questions on the manager's tickets.
+func (em *EngineeringManagerAgent) Act() error {
+	return em.HandleOpenClarifications()
+}
+
+// HandleOpenClarifications scans the cards assigned to the manager for comments mentioning @manager
+// that have not been answered yet, asks the model for an answer and posts it as a comment.
+// Each answer carries a marker derived from the question, so a question is answered only once.
+func (em *EngineeringManagerAgent) HandleOpenClarifications() error {
+	cards, err := em.FindMyTickets()
+	if err != nil {
+		return fmt.Errorf("failed to find assigned tickets: %w", err)
+	}
+	for _, card := range cards {
+		if err := em.answerCardClarifications(card); err != nil {
+			fmt.Printf("Warning: failed to handle clarifications on %q: %v\n", card.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// answerCardClarifications answers every open @manager question on a single card.
+func (em *EngineeringManagerAgent) answerCardClarifications(card board.Card) error {
+	comments, err := card.ReadComments()
+	if err != nil {
+		return fmt.Errorf("failed to read comments: %w", err)
+	}
+	for _, question := range unansweredQuestions(comments) {
+		answer, err := em.answerClarification(card, question)
+		if err != nil {
+			return err
+		}
+		if err := card.WriteComment(fmt.Sprintf("%s %s", clarificationMarker(question), answer.Answer)); err != nil {
+			return fmt.Errorf("failed to post clarification answer: %w", err)
+		}
+		em.notify("%s answered a clarification on %s (%s)", em.Name, card.GetName(), card.GetURL())
+	}
+	return nil
+}
+
+// unansweredQuestions returns the @manager comments that have no answer yet, in comment order.
+// A question asked twice with the same text is returned once.
+func unansweredQuestions(comments []board.Comment) []string {
+	answered := make(map[string]bool)
+	for _, c := range comments {
+		if strings.HasPrefix(c.Text, clarificationMarkerPrefix) {
+			answered[c.Text[:strings.Index(c.Text, "]")+1]] = true
+		}
+	}
+	var open []string
+	for _, c := range comments {
+		if strings.HasPrefix(c.Text, clarificationMarkerPrefix) || !strings.Contains(strings.ToLower(c.Text), managerMention) {
+			continue
+		}
+		marker := clarificationMarker(c.Text)
+		if answered[marker] {
+			continue
+		}
+		answered[marker] = true
+		open = append(open, c.Text)
+	}
+	return open
+}
+
+// answerClarification asks the model for a structured answer to a question raised on a card.
+func (em *EngineeringManagerAgent) answerClarification(card board.Card, question string) (ClarificationAnswer, error) {
+	userInput := fmt.Sprintf("Ticket: %s (%s)\nA developer asks:\n%s", card.GetName(), card.GetURL(), question)
+	chatReq, err := em.PromptBuilder.Build(
+		em.Role,
+		"Answer",
+		em.Context.GetContext(),
+		userInput,
+		ClarificationAnswer{},
+		em.temperature("Answer"),
+		em.model("Answer", ""),
+	)
+	if err != nil {
+		return ClarificationAnswer{}, fmt.Errorf("failed to build clarification request: %w", err)
+	}
+	var answer ClarificationAnswer
+	if err := em.ModelClient.ChatAdvancedParsed(chatReq, &answer); err != nil {
+		return ClarificationAnswer{}, fmt.Errorf("failed to parse clarification answer: %w", err)
+	}
+	return answer, nil
+}
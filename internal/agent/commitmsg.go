@@ -0,0 +1,90 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// Commit message formats a DeveloperAgent can be configured with.
+const (
+	// CommitFormatPlain asks for a short imperative subject line, optionally followed by a body.
+	CommitFormatPlain = "plain"
+	// CommitFormatConventional asks for a Conventional Commits message such as "feat(api): add login".
+	CommitFormatConventional = "conventional"
+)
+
+// maxCommitFileContent caps how much of each changed file is shown to the model when writing a commit message.
+const maxCommitFileContent = 4000
+
+// CommitMessageOutput is the structured answer the model produces when writing a commit message.
+type CommitMessageOutput struct {
+	Message string `json:"message"`
+}
+
+// CommitMessage asks the model for a commit message describing files, the changes about to be committed.
+// The prompt lists every changed path and its new content, and asks for CommitFormat.
+func (d *DeveloperAgent) CommitMessage(files []codegen.GeneratedFile) (string, error) {
+	if len(files) == 0 {
+		return "", codegen.ErrNoFiles
+	}
+	var b strings.Builder
+	b.WriteString("Write the commit message for the following changes.\n")
+	switch d.CommitFormat {
+	case CommitFormatConventional:
+		b.WriteString("Follow the Conventional Commits format: type(optional scope): description, with type one of feat, fix, docs, test, refactor or chore.\n")
+	default:
+		b.WriteString("Start with a concise subject line in the imperative mood of at most 72 characters; add a body only if the change needs explaining.\n")
+	}
+	fmt.Fprintf(&b, "\nChanged files: %s\n", filePaths(files))
+	for _, f := range files {
+		content := f.Content
+		if len(content) > maxCommitFileContent {
+			content = content[:maxCommitFileContent] + "\n..."
+		}
+		fmt.Fprintf(&b, "\n%s %s\n%s", codegen.PathMarker, f.Path, content)
+	}
+
+	chatReq, err := d.PromptBuilder.Build(
+		d.Role,
+		"CommitMessage",
+		d.Context.GetContext(),
+		b.String(),
+		CommitMessageOutput{},
+		d.temperature("CommitMessage"),
+		d.model("CommitMessage", ""),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to build commit message request: %w", err)
+	}
+	var out CommitMessageOutput
+	if err := d.ModelClient.ChatAdvancedParsed(chatReq, &out); err != nil {
+		return "", fmt.Errorf("failed to parse commit message: %w", err)
+	}
+	message := strings.TrimSpace(out.Message)
+	if message == "" {
+		return "", fmt.Errorf("model returned an empty commit message")
+	}
+	return message, nil
+}
+
+// CommitAndPush commits files with a message from CommitMessage and pushes the commit using Push.
+// When another agent pushed first, the commit is replayed on top of the remote and pushed again.
+func (d *DeveloperAgent) CommitAndPush(files []codegen.GeneratedFile) error {
+	if d.Push == nil {
+		return fmt.Errorf("push credentials not configured")
+	}
+	message, err := d.CommitMessage(files)
+	if err != nil {
+		return err
+	}
+	if err := d.GitClient.CommitChanges(message, d.Name, d.Push.Email); err != nil {
+		return err
+	}
+	if err := d.GitClient.PushWithRetry(d.Push.Username, d.Push.Token, gitrepo.DefaultPushAttempts); err != nil {
+		return fmt.Errorf("failed to push %q: %w", message, err)
+	}
+	return nil
+}
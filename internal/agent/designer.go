@@ -0,0 +1,168 @@
+package agent
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/docs"
+)
+
+// designPageKeywords select the documentation pages that describe the brand and design system.
+var designPageKeywords = []string{"brand", "design", "style guide", "ui kit"}
+
+// mockupExtensions are the attachment file types passed to the model as reference images.
+var mockupExtensions = map[string]bool{".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true}
+
+// DesignSpec is the structured design specification the Designer produces for a ticket.
+type DesignSpec struct {
+	Summary     string          `json:"summary"`
+	Colors      []DesignColor   `json:"colors"`
+	Components  []DesignElement `json:"components"`
+	LayoutNotes []string        `json:"layout_notes"`
+}
+
+// DesignColor is a palette entry of a DesignSpec.
+type DesignColor struct {
+	Name  string `json:"name"`
+	Hex   string `json:"hex"`
+	Usage string `json:"usage"`
+}
+
+// DesignElement is a UI component of a DesignSpec.
+type DesignElement struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// DesignerAgent turns tickets into design specs grounded in the project's brandbook.
+type DesignerAgent struct {
+	*BaseAgent
+}
+
+// NewDesignerAgent creates a new DesignerAgent using the provided BaseAgent.
+func NewDesignerAgent(base *BaseAgent) *DesignerAgent {
+	return &DesignerAgent{BaseAgent: base}
+}
+
+// ProduceDesignSpec asks the model for a design spec for the ticket, grounded in the brand and
+// design pages of the documentation, with image attachments of the card passed as reference mockups.
+// A summary of the spec is posted as a comment on the card.
+func (d *DesignerAgent) ProduceDesignSpec(ticket board.Card) (DesignSpec, error) {
+	pages, err := d.designPages()
+	if err != nil {
+		return DesignSpec{}, err
+	}
+	mockups, err := mockupURLs(ticket)
+	if err != nil {
+		return DesignSpec{}, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Ticket: %s (%s)\n", ticket.GetName(), ticket.GetURL())
+	if len(pages) == 0 {
+		b.WriteString("\nNo brandbook was found in the documentation.\n")
+	}
+	for _, p := range pages {
+		fmt.Fprintf(&b, "\nBrandbook page %q:\n%s\n", p.Title, p.Content)
+	}
+	if len(mockups) > 0 {
+		b.WriteString("\nThe attached images are reference mockups for this ticket.\n")
+	}
+
+	chatReq, err := d.PromptBuilder.Build(
+		d.Role,
+		"Design",
+		d.Context.GetContext(),
+		b.String(),
+		DesignSpec{},
+		d.temperature("Design"),
+		d.model("Design", ""),
+	)
+	if err != nil {
+		return DesignSpec{}, fmt.Errorf("failed to build design request: %w", err)
+	}
+	for _, url := range mockups {
+		if err := d.PromptBuilder.AddImage(&chatReq, url); err != nil {
+			return DesignSpec{}, fmt.Errorf("failed to attach mockup %s: %w", url, err)
+		}
+	}
+
+	var spec DesignSpec
+	if err := d.ModelClient.ChatAdvancedParsed(chatReq, &spec); err != nil {
+		return DesignSpec{}, fmt.Errorf("failed to parse design spec: %w", err)
+	}
+	if err := ticket.WriteComment(designSummary(spec)); err != nil {
+		return spec, fmt.Errorf("failed to post design spec: %w", err)
+	}
+	return spec, nil
+}
+
+// designPages returns the documentation pages whose title mentions the brand or design system, with content.
+func (d *DesignerAgent) designPages() ([]docs.Page, error) {
+	all, err := d.DocsClient.ListPages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documentation pages: %w", err)
+	}
+	var pages []docs.Page
+	for _, p := range all {
+		title := strings.ToLower(p.Title)
+		for _, kw := range designPageKeywords {
+			if !strings.Contains(title, kw) {
+				continue
+			}
+			full, err := d.DocsClient.ReadPage(p.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read page %q: %w", p.Title, err)
+			}
+			pages = append(pages, full)
+			break
+		}
+	}
+	return pages, nil
+}
+
+// mockupURLs returns the URLs of the card's image attachments.
+func mockupURLs(ticket board.Card) ([]string, error) {
+	attachments, err := ticket.GetAttachments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	var urls []string
+	for _, att := range attachments {
+		name := att.Name
+		if name == "" {
+			name = att.URL
+		}
+		if att.URL != "" && mockupExtensions[strings.ToLower(path.Ext(name))] {
+			urls = append(urls, att.URL)
+		}
+	}
+	return urls, nil
+}
+
+// designSummary formats a design spec as a card comment.
+func designSummary(spec DesignSpec) string {
+	var b strings.Builder
+	b.WriteString("Design spec: " + spec.Summary + "\n")
+	if len(spec.Colors) > 0 {
+		b.WriteString("\nColors:\n")
+		for _, c := range spec.Colors {
+			fmt.Fprintf(&b, "- %s %s: %s\n", c.Name, c.Hex, c.Usage)
+		}
+	}
+	if len(spec.Components) > 0 {
+		b.WriteString("\nComponents:\n")
+		for _, c := range spec.Components {
+			fmt.Fprintf(&b, "- %s: %s\n", c.Name, c.Description)
+		}
+	}
+	if len(spec.LayoutNotes) > 0 {
+		b.WriteString("\nLayout:\n")
+		for _, n := range spec.LayoutNotes {
+			b.WriteString("- " + n + "\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,260 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/codegen"
+)
+
+// CodeChanges is the structured output the model produces when generating code.
+type CodeChanges struct {
+	Files []codegen.GeneratedFile `json:"files"`
+}
+
+// ClarificationQuestions is the structured output the model produces when checking a ticket for open questions.
+type ClarificationQuestions struct {
+	Questions []string `json:"questions"`
+}
+
+// ErrNeedsClarification is returned by ImplementTicket when the ticket was parked because questions are open.
+var ErrNeedsClarification = errors.New("ticket needs clarification")
+
+// Default lists used by the DeveloperAgent for parking tickets with open questions.
+const (
+	DefaultWaitingList = "Waiting"
+	DefaultResumeList  = "In Progress"
+)
+
+// DefaultManagerName is the member a parked ticket is assigned to so the manager answers its questions.
+const DefaultManagerName = "EngineeringManager"
+
+// DeveloperAgent implements tickets by generating code and writing it to the repository.
+type DeveloperAgent struct {
+	*BaseAgent
+	// WaitingList is the list a ticket is moved to while its clarification questions are unanswered.
+	WaitingList string
+	// ResumeList is the list a parked ticket is moved back to once its questions are answered.
+	ResumeList string
+	// ManagerName is the member a parked ticket is assigned to, so the manager finds its questions.
+	ManagerName string
+	// CommitFormat is the format CommitMessage asks for: CommitFormatPlain or CommitFormatConventional.
+	CommitFormat string
+	// Push, when set, makes ImplementTicket commit the written files and push them (see CommitAndPush).
+	Push *GitAuth
+}
+
+// GitAuth holds the credentials and author email a DeveloperAgent commits and pushes with.
+type GitAuth struct {
+	Username string
+	Token    string
+	Email    string
+}
+
+// NewDeveloperAgent creates a new DeveloperAgent using the provided BaseAgent.
+func NewDeveloperAgent(base *BaseAgent) *DeveloperAgent {
+	return &DeveloperAgent{
+		BaseAgent:    base,
+		WaitingList:  DefaultWaitingList,
+		ResumeList:   DefaultResumeList,
+		ManagerName:  DefaultManagerName,
+		CommitFormat: CommitFormatPlain,
+	}
+}
+
+// Act implements every ticket assigned to the developer.
+func (d *DeveloperAgent) Act() error {
+	tickets, err := d.FindMyTickets()
+	if err != nil {
+		return fmt.Errorf("failed to find assigned tickets: %w", err)
+	}
+	for _, ticket := range tickets {
+		_, err := d.ImplementTicket(ticket)
+		if errors.Is(err, ErrNeedsClarification) {
+			fmt.Printf("Parked %q until its questions are answered\n", ticket.GetName())
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Warning: failed to implement %q: %v\n", ticket.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// GenerateCode asks the model for the files implementing task. The response follows a strict schema
+// in which every file states whether it is a test, so implementation and tests need no parsing to separate.
+func (d *DeveloperAgent) GenerateCode(task string) ([]codegen.GeneratedFile, error) {
+	chatReq, err := d.PromptBuilder.Build(
+		d.Role,
+		"GenerateCode",
+		d.Context.GetContext(),
+		task,
+		CodeChanges{},
+		d.temperature("GenerateCode"),
+		d.model("GenerateCode", ""),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build code generation request: %w", err)
+	}
+	var changes CodeChanges
+	if err := d.ModelClient.ChatAdvancedParsed(chatReq, &changes); err != nil {
+		return nil, fmt.Errorf("failed to parse generated code: %w", err)
+	}
+	if len(changes.Files) == 0 {
+		return nil, codegen.ErrNoFiles
+	}
+	return changes.Files, nil
+}
+
+// NeedsClarification reports whether the ticket has questions for the manager that must be answered
+// before it is implemented. Questions already asked with @manager and not yet answered count first;
+// otherwise the model is asked whether the ticket is ambiguous, and its questions are posted as
+// @manager comments so the manager answers them on a later poll.
+func (d *DeveloperAgent) NeedsClarification(ticket board.Card) (bool, []string, error) {
+	comments, err := ticket.ReadComments()
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to read comments: %w", err)
+	}
+	if open := unansweredQuestions(comments); len(open) > 0 {
+		return true, open, nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Ticket: %s (%s)\nList the questions that must be answered before this ticket can be implemented, or none if it is clear.\n", ticket.GetName(), ticket.GetURL())
+	if len(comments) > 0 {
+		b.WriteString("\nComments:\n")
+		for _, c := range comments {
+			b.WriteString("- " + c.Text + "\n")
+		}
+	}
+	chatReq, err := d.PromptBuilder.Build(
+		d.Role,
+		"Clarify",
+		d.Context.GetContext(),
+		b.String(),
+		ClarificationQuestions{},
+		d.temperature("Clarify"),
+		d.model("Clarify", ""),
+	)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to build clarification request: %w", err)
+	}
+	var result ClarificationQuestions
+	if err := d.ModelClient.ChatAdvancedParsed(chatReq, &result); err != nil {
+		return false, nil, fmt.Errorf("failed to parse clarification questions: %w", err)
+	}
+
+	var questions []string
+	for _, q := range result.Questions {
+		if q = strings.TrimSpace(q); q == "" {
+			continue
+		}
+		question := managerMention + " " + q
+		if err := ticket.WriteComment(question); err != nil {
+			return false, nil, fmt.Errorf("failed to post clarification question: %w", err)
+		}
+		questions = append(questions, question)
+	}
+	return len(questions) > 0, questions, nil
+}
+
+// ImplementTicket generates the code for a ticket, writes it to the repository (committing and
+// pushing it when Push is set) and comments on the ticket with the implementation and test files
+// that were written.
+// A ticket with open questions (see NeedsClarification) is moved to WaitingList and assigned to
+// ManagerName instead, and ErrNeedsClarification is returned; once answered, it is moved back to ResumeList and implemented.
+func (d *DeveloperAgent) ImplementTicket(ticket board.Card) ([]codegen.GeneratedFile, error) {
+	if d.GitClient == nil {
+		return nil, fmt.Errorf("git client not configured")
+	}
+	needsInfo, questions, err := d.NeedsClarification(ticket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for open questions: %w", err)
+	}
+	if err := d.updateParking(ticket, needsInfo); err != nil {
+		return nil, err
+	}
+	if needsInfo {
+		if err := d.assignManager(ticket); err != nil {
+			return nil, err
+		}
+		d.notify("%s parked %s (%s) with %d open question(s)", d.Name, ticket.GetName(), ticket.GetURL(), len(questions))
+		return nil, ErrNeedsClarification
+	}
+	files, err := d.GenerateCode(fmt.Sprintf("Implement the ticket %s (%s).", ticket.GetName(), ticket.GetURL()))
+	if err != nil {
+		return nil, err
+	}
+	if err := codegen.WriteFiles(d.GitClient, files); err != nil {
+		return files, err
+	}
+	if d.Push != nil {
+		if err := d.CommitAndPush(files); err != nil {
+			return files, err
+		}
+	}
+	impl, tests := codegen.SplitTests(files)
+	if err := ticket.WriteComment(fmt.Sprintf("Implementation: %s\nTests: %s", filePaths(impl), filePaths(tests))); err != nil {
+		return files, fmt.Errorf("failed to post implementation summary: %w", err)
+	}
+	return files, nil
+}
+
+// updateParking moves the ticket to WaitingList when parked is true, and back to ResumeList when it is
+// false and the ticket is still waiting. Tickets already in the right list are not moved.
+func (d *DeveloperAgent) updateParking(ticket board.Card, parked bool) error {
+	if d.WaitingList == "" {
+		return nil
+	}
+	list, err := ticket.GetList()
+	if err != nil {
+		return fmt.Errorf("failed to get ticket list: %w", err)
+	}
+	waiting := list != nil && strings.EqualFold(list.GetName(), d.WaitingList)
+	switch {
+	case parked && !waiting:
+		if err := ticket.Move(d.WaitingList); err != nil {
+			return fmt.Errorf("failed to move ticket to %s: %w", d.WaitingList, err)
+		}
+	case !parked && waiting && d.ResumeList != "":
+		if err := ticket.Move(d.ResumeList); err != nil {
+			return fmt.Errorf("failed to move ticket to %s: %w", d.ResumeList, err)
+		}
+	}
+	return nil
+}
+
+// assignManager assigns the ticket to ManagerName unless the manager is already assigned, since the
+// manager only answers questions on its own tickets.
+func (d *DeveloperAgent) assignManager(ticket board.Card) error {
+	if d.ManagerName == "" {
+		return nil
+	}
+	members, err := ticket.GetAssignedMembers()
+	if err != nil {
+		return fmt.Errorf("failed to get ticket members: %w", err)
+	}
+	for _, m := range members {
+		if strings.EqualFold(m.Name, d.ManagerName) || strings.EqualFold(m.ID, d.ManagerName) {
+			return nil
+		}
+	}
+	if err := ticket.AssignTo(d.ManagerName); err != nil {
+		return fmt.Errorf("failed to assign ticket to %s: %w", d.ManagerName, err)
+	}
+	return nil
+}
+
+// filePaths lists the paths of files for a comment, or "none".
+func filePaths(files []codegen.GeneratedFile) string {
+	if len(files) == 0 {
+		return "none"
+	}
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.Path
+	}
+	return strings.Join(paths, ", ")
+}
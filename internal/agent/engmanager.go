@@ -1,18 +1,40 @@
 package agent
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/gitrepo"
 	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
 )
 
+// docPrompt introduces documentation content when asking the model to form memories.
+const docPrompt = "Below you can find information about the documentation of the project you are working on. Your task is to form human-like specific memories that help you execute your role. Try not to remember obvious statements but focus on specifics that aid your day-to-day tasks. Below you will find the tree of the documentation structure, followed by the actual documentation articles."
+
+// repoPrompt introduces attached repository files; the placeholder takes the repository tree.
+const repoPrompt = "In the attachments you can find the code of the repository. Study it carefully and extract memories about each struct, function, and purpose for your further development. GitStructure:\n%s"
+
+// repoChunkPrompt introduces one inline chunk of repository files; the placeholders take the chunk number,
+// the chunk count, the repository tree and the chunk's JSON.
+const repoChunkPrompt = "Below you can find part %d of %d of the code of the repository as JSON. Study it carefully and extract memories about each struct, function, and purpose for your further development. GitStructure:\n%s\nFiles:\n%s"
+
+// maxRepoChunkBytes bounds the JSON of each repository chunk sent inline, keeping it well inside the context window.
+const maxRepoChunkBytes = 60000
+
+// uploadIndexFile persists the path -> content hash and file ID map used to skip unchanged uploads.
+const uploadIndexFile = "vectorstore_uploads.json"
+
 // EngineeringManagerAgent implements the Agent interface.
 type EngineeringManagerAgent struct {
 	*BaseAgent
+	// HTTPClient downloads ticket attachments; http.DefaultClient is used when nil.
+	HTTPClient *http.Client
 }
 
 // NewEngineeringManagerAgent creates a new EngineeringManagerAgent.
@@ -70,11 +92,10 @@ func (em *EngineeringManagerAgent) createContext() error {
 		content, _ := em.DocsClient.ReadPage(p.ID)
 		pagesInfo += fmt.Sprintf("Title: %s\nContent: %s\n", p.Title, content)
 	}
-	docPrompt := "Below you can find information about the documentation of the project you are working on. Your task is to form human-like specific memories that help you execute your role. Try not to remember obvious statements but focus on specifics that aid your day-to-day tasks. Below you will find the tree of the documentation structure, followed by the actual documentation articles."
 	combinedDocContent := docPrompt + "\n" + docTree + "\n" + pagesInfo
 
 	// Generate documentation memories using CreateThoughts.
-	docMemories, err := em.CreateThoughts(combinedDocContent, nil, nil)
+	docMemories, err := em.CreateThoughts(combinedDocContent, nil, nil, "")
 	if err != nil {
 		return fmt.Errorf("failed to create thoughts from documentation: %w", err)
 	}
@@ -93,66 +114,41 @@ func (em *EngineeringManagerAgent) createContext() error {
 	// ------------------------------
 	// Step 2: Process Repository (Code) Files.
 	// ------------------------------
-	// Retrieve code files via GitClient.
-	codeFiles, err := em.GitClient.ListCodeFiles()
+	// Get repository structure (code tree) from GitClient.
+	gitTree, err := em.GitClient.PrintTree()
 	if err != nil {
-		return fmt.Errorf("failed to list code files: %w", err)
-	}
-
-	// Ensure the vector storage client is configured.
-	vsClient := em.VectorStorage
-	if vsClient == nil {
-		return fmt.Errorf("vector storage client not configured")
+		return fmt.Errorf("failed to gather repository info: %w", err)
 	}
 
-	// Check for a vector store named "aiagents" and create if missing.
-	vectorStoreID := ""
-	storages, err := vsClient.ListStorages()
-	if err != nil {
-		return fmt.Errorf("failed to list vector stores: %w", err)
-	}
-	for _, vs := range storages {
-		if vs.Name == "aiagents" {
-			vectorStoreID = vs.ID
-			break
+	var repoMemories []context.EasyMemory
+	if em.VectorStorage != nil {
+		// Retrieve code files via GitClient.
+		codeFiles, err := em.GitClient.ListCodeFiles()
+		if err != nil {
+			return fmt.Errorf("failed to list code files: %w", err)
 		}
-	}
-	if vectorStoreID == "" {
-		newVS, err := vsClient.CreateStorage("aiagents")
+
+		// Upload and attach only the files whose content changed since the last run.
+		fileTuple, err := em.syncCodeFiles(codeFiles)
 		if err != nil {
-			return fmt.Errorf("failed to create vector store: %w", err)
+			return err
 		}
-		vectorStoreID = newVS.ID
-	}
 
-	// Prepare an array of file attachments (each with file ID and vector store ID).
-	var fileTuple []model.FileAttachment
-	for _, filePath := range codeFiles {
-		uploaded, err := em.ModelClient.UploadFile(filePath, string(model.FilePurposeAssistants))
+		// Generate repository memories using CreateThoughts with the file attachments.
+		repoMemories, err = em.CreateThoughts(fmt.Sprintf(repoPrompt, gitTree), fileTuple, nil, "")
 		if err != nil {
-			return fmt.Errorf("failed to upload file %s: %w", filePath, err)
+			return fmt.Errorf("failed to create thoughts from repository info: %w", err)
 		}
-		// Attach the file and wait until it's processed.
-		_, err = vsClient.AttachFile(vectorStoreID, uploaded.ID)
+	} else {
+		// Without a vector store the code is sent inline, one context-sized chunk at a time.
+		chunks, err := em.GitClient.GatherRepoInfoChunks(maxRepoChunkBytes)
 		if err != nil {
-			return fmt.Errorf("failed to attach file %s to vector store: %w", filePath, err)
+			return fmt.Errorf("failed to gather repository info: %w", err)
+		}
+		repoMemories, err = em.repoChunkThoughts(gitTree, chunks)
+		if err != nil {
+			return err
 		}
-		// Append the tuple with correct field names.
-		fileTuple = append(fileTuple, model.FileAttachment{FileID: uploaded.ID, VectorStoreID: vectorStoreID})
-	}
-
-	// Get repository structure (code tree) from GitClient.
-	gitTree, err := em.GitClient.PrintTree()
-	if err != nil {
-		return fmt.Errorf("failed to gather repository info: %w", err)
-	}
-	// Construct a prompt for repository info.
-	repoInput := fmt.Sprintf("In the attachments you can find the code of the repository. Study it carefully and extract memories about each struct, function, and purpose for your further development. GitStructure:\n%s", gitTree)
-
-	// Generate repository memories using CreateThoughts with the file attachments.
-	repoMemories, err := em.CreateThoughts(repoInput, fileTuple, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create thoughts from repository info: %w", err)
 	}
 
 	// ------------------------------
@@ -175,5 +171,71 @@ func (em *EngineeringManagerAgent) createContext() error {
 		return fmt.Errorf("failed to refresh memories: %w", err)
 	}
 
+	// Remember what was ingested so RefreshContext only processes later changes.
+	headSHA, err := em.GitClient.HeadCommit()
+	if err != nil {
+		return fmt.Errorf("failed to get repository HEAD: %w", err)
+	}
+	state := refreshState{LastCommit: headSHA, DocEdits: make(map[string]time.Time)}
+	for _, p := range pages {
+		state.DocEdits[p.ID] = p.LastEdited
+	}
+	if err := state.save(em.statePath(refreshStateFile)); err != nil {
+		return fmt.Errorf("failed to save refresh state: %w", err)
+	}
+
 	return nil
 }
+
+// repoChunkThoughts creates memories from repository code chunk by chunk, for agents without a vector store.
+func (em *EngineeringManagerAgent) repoChunkThoughts(gitTree string, chunks []gitrepo.RepoSnapshot) ([]context.EasyMemory, error) {
+	var memories []context.EasyMemory
+	for i, chunk := range chunks {
+		chunkJSON, err := json.Marshal(chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal repository chunk: %w", err)
+		}
+		thoughts, err := em.CreateThoughts(fmt.Sprintf(repoChunkPrompt, i+1, len(chunks), gitTree, chunkJSON), nil, nil, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create thoughts from repository chunk %d: %w", i+1, err)
+		}
+		memories = append(memories, thoughts...)
+	}
+	return memories, nil
+}
+
+// syncCodeFiles uploads the given files to the "aiagents" vector store, skipping unchanged ones,
+// and returns the attachments to pass to CreateThoughts. Files that fail to sync are logged and
+// left out; it fails only when none of the files could be attached.
+func (em *EngineeringManagerAgent) syncCodeFiles(paths []string) ([]model.FileAttachment, error) {
+	vectorStoreID, err := em.ensureVectorStore()
+	if err != nil {
+		return nil, err
+	}
+	uploadIndex, err := vectorstorage.LoadUploadIndex(em.statePath(uploadIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load upload index: %w", err)
+	}
+	uploadIndex.Root = em.GitClient.RepoPath
+	attachments, err := em.VectorStorage.SyncFiles(vectorStoreID, paths, em.ModelClient, uploadIndex)
+	if err != nil {
+		if len(attachments) == 0 {
+			return nil, fmt.Errorf("failed to sync code files to vector store: %w", err)
+		}
+		fmt.Printf("Warning: only %d of %d code files were synced to the vector store: %v\n", len(attachments), len(paths), err)
+	}
+	return attachments, nil
+}
+
+// ensureVectorStore returns the ID of the "aiagents" vector store, creating it if missing.
+func (em *EngineeringManagerAgent) ensureVectorStore() (string, error) {
+	vsClient := em.VectorStorage
+	if vsClient == nil {
+		return "", fmt.Errorf("vector storage client not configured")
+	}
+	vs, err := vsClient.FindOrCreateStorage("aiagents")
+	if err != nil {
+		return "", err
+	}
+	return vs.ID, nil
+}
@@ -0,0 +1,41 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+
+	mclient "github.com/egobogo/aiagents/internal/model"
+)
+
+// AnswerMessages answers a room conversation. The latest user message is the question and
+// the earlier messages are passed to Think as the sender's context. It has the signature of
+// room.Participant's Answer, so an agent joins a room with room.ParticipantFunc(a.AnswerMessages).
+func (a *BaseAgent) AnswerMessages(question []mclient.Message) ([]mclient.Message, error) {
+	last := -1
+	for i := len(question) - 1; i >= 0; i-- {
+		if question[i].Role == "user" {
+			last = i
+			break
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("no user message to answer")
+	}
+	userInput := question[last].Text()
+	if strings.TrimSpace(userInput) == "" {
+		return nil, fmt.Errorf("user message has no text content")
+	}
+
+	var senderContext strings.Builder
+	for _, m := range question[:last] {
+		if text := m.Text(); text != "" {
+			senderContext.WriteString(fmt.Sprintf("%s: %s\n", m.Role, text))
+		}
+	}
+
+	reply, err := a.Think(senderContext.String(), userInput, "Answer", "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return []mclient.Message{reply}, nil
+}
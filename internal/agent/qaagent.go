@@ -0,0 +1,131 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// defaultTestCommand runs the Go test suite of the repository.
+var defaultTestCommand = []string{"go", "test", "./..."}
+
+// maxReportedOutput caps how much raw test output is posted to a card comment.
+const maxReportedOutput = 4000
+
+// QAAgent runs the test suite of the checked-out branch and reports the result on the ticket.
+type QAAgent struct {
+	*BaseAgent
+	// TestCommand is the command (program and arguments) executed in the repository root.
+	// Defaults to "go test ./...".
+	TestCommand []string
+	// DeveloperName is the board member the card is handed back to when tests fail.
+	DeveloperName string
+}
+
+// NewQAAgent creates a new QAAgent using the provided BaseAgent.
+func NewQAAgent(base *BaseAgent, developerName string) *QAAgent {
+	return &QAAgent{
+		BaseAgent:     base,
+		TestCommand:   defaultTestCommand,
+		DeveloperName: developerName,
+	}
+}
+
+// Act runs the tests for every ticket assigned to the QA agent.
+func (qa *QAAgent) Act() error {
+	tickets, err := qa.FindMyTickets()
+	if err != nil {
+		return fmt.Errorf("failed to find assigned tickets: %w", err)
+	}
+	var errs []error
+	for _, ticket := range tickets {
+		if _, _, err := qa.RunTests(ticket); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ticket.GetName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunTests executes the test command in the repository, posts the outcome as a comment on the ticket
+// and, on failure, reassigns the ticket to the developer. A failing test run is reported through
+// passed=false; err is only set when the tests could not be run or reported.
+func (qa *QAAgent) RunTests(ticket board.Card) (bool, string, error) {
+	if qa.GitClient == nil {
+		return false, "", fmt.Errorf("git client not configured")
+	}
+	command := qa.TestCommand
+	if len(command) == 0 {
+		command = defaultTestCommand
+	}
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Dir = qa.GitClient.RepoPath
+	out, runErr := cmd.CombinedOutput()
+	output := string(out)
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return false, output, fmt.Errorf("failed to run %q: %w", strings.Join(command, " "), runErr)
+	}
+	passed := runErr == nil
+
+	if err := ticket.WriteComment(testReport(command, passed, output)); err != nil {
+		return passed, output, fmt.Errorf("failed to post test report: %w", err)
+	}
+
+	if passed {
+		qa.notify("%s: tests passed on %s (%s)", qa.Name, ticket.GetName(), ticket.GetURL())
+	} else {
+		qa.notify("%s: tests failed on %s (%s)", qa.Name, ticket.GetName(), ticket.GetURL())
+	}
+
+	if !passed && qa.DeveloperName != "" {
+		if err := ticket.UnassignFrom(qa.Name); err != nil {
+			fmt.Printf("Warning: failed to unassign %s from ticket: %v\n", qa.Name, err)
+		}
+		if err := ticket.AssignTo(qa.DeveloperName); err != nil {
+			return passed, output, fmt.Errorf("failed to reassign ticket to %s: %w", qa.DeveloperName, err)
+		}
+	}
+	return passed, output, nil
+}
+
+// testReport formats the card comment for a test run.
+func testReport(command []string, passed bool, output string) string {
+	var b strings.Builder
+	if passed {
+		b.WriteString(fmt.Sprintf("QA: `%s` passed.", strings.Join(command, " ")))
+		return b.String()
+	}
+	b.WriteString(fmt.Sprintf("QA: `%s` failed.\n", strings.Join(command, " ")))
+	if failing := failingTests(output); len(failing) > 0 {
+		b.WriteString("Failing tests:\n")
+		for _, name := range failing {
+			b.WriteString("- " + name + "\n")
+		}
+	}
+	if len(output) > maxReportedOutput {
+		output = "...\n" + output[len(output)-maxReportedOutput:]
+	}
+	b.WriteString("\nOutput:\n" + output)
+	return b.String()
+}
+
+// failingTests extracts the names of failed tests from `go test` output.
+func failingTests(output string) []string {
+	var names []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "--- FAIL: ") {
+			continue
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "--- FAIL: "))
+		if len(fields) > 0 {
+			names = append(names, fields[0])
+		}
+	}
+	return names
+}
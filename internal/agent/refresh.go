@@ -0,0 +1,160 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/docs"
+)
+
+// refreshStateFile persists what RefreshContext has already ingested.
+const refreshStateFile = "context_refresh_state.json"
+
+// refreshState records the last-seen documentation edit times and repository commit.
+type refreshState struct {
+	LastCommit string               `json:"last_commit"`
+	DocEdits   map[string]time.Time `json:"doc_edits"`
+}
+
+// loadRefreshState reads the state file; a missing file yields an empty state.
+func loadRefreshState(path string) (refreshState, error) {
+	state := refreshState{DocEdits: make(map[string]time.Time)}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read refresh state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to unmarshal refresh state: %w", err)
+	}
+	if state.DocEdits == nil {
+		state.DocEdits = make(map[string]time.Time)
+	}
+	return state, nil
+}
+
+// save writes the state to path.
+func (s refreshState) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal refresh state: %w", err)
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// RefreshContext re-ingests only what changed since the last run: documentation pages edited after
+// their recorded timestamp and code files changed since the recorded commit. The resulting memories
+// are merged into the existing context the same way createContext does it.
+func (em *EngineeringManagerAgent) RefreshContext() error {
+	statePath := em.statePath(refreshStateFile)
+	state, err := loadRefreshState(statePath)
+	if err != nil {
+		return err
+	}
+
+	// ------------------------------
+	// Step 1: Changed documentation.
+	// ------------------------------
+	pages, err := em.DocsClient.ListPages()
+	if err != nil {
+		return fmt.Errorf("failed to list documentation pages: %w", err)
+	}
+	var changedPages []docs.Page
+	for _, p := range pages {
+		if seen, ok := state.DocEdits[p.ID]; ok && !p.LastEdited.After(seen) {
+			continue
+		}
+		changedPages = append(changedPages, p)
+	}
+
+	var newMemories []context.EasyMemory
+	if len(changedPages) > 0 {
+		var pagesInfo string
+		for _, p := range changedPages {
+			page, err := em.DocsClient.ReadPage(p.ID)
+			if err != nil {
+				return fmt.Errorf("failed to read documentation page %s: %w", p.ID, err)
+			}
+			pagesInfo += fmt.Sprintf("Title: %s\nContent: %s\n", page.Title, page.Content)
+		}
+		docMemories, err := em.CreateThoughts(docPrompt+"\n"+pagesInfo, nil, nil, "")
+		if err != nil {
+			return fmt.Errorf("failed to create thoughts from changed documentation: %w", err)
+		}
+		newMemories = append(newMemories, docMemories...)
+	}
+
+	// ------------------------------
+	// Step 2: Changed repository files.
+	// ------------------------------
+	headSHA, err := em.GitClient.HeadCommit()
+	if err != nil {
+		return fmt.Errorf("failed to get repository HEAD: %w", err)
+	}
+	if headSHA != state.LastCommit {
+		changedFiles, err := em.GitClient.ChangedFiles(state.LastCommit, headSHA)
+		if err != nil {
+			return fmt.Errorf("failed to list changed files: %w", err)
+		}
+		if len(changedFiles) > 0 {
+			gitTree, err := em.GitClient.PrintTree()
+			if err != nil {
+				return fmt.Errorf("failed to gather repository info: %w", err)
+			}
+			var repoMemories []context.EasyMemory
+			if em.VectorStorage != nil {
+				fileTuple, err := em.syncCodeFiles(changedFiles)
+				if err != nil {
+					return err
+				}
+				repoMemories, err = em.CreateThoughts(fmt.Sprintf(repoPrompt, gitTree), fileTuple, nil, "")
+				if err != nil {
+					return fmt.Errorf("failed to create thoughts from changed files: %w", err)
+				}
+			} else {
+				// Without a vector store the changed files are sent inline, chunk by chunk.
+				chunks, err := em.GitClient.GatherFileChunks(changedFiles, maxRepoChunkBytes)
+				if err != nil {
+					return fmt.Errorf("failed to gather changed files: %w", err)
+				}
+				repoMemories, err = em.repoChunkThoughts(gitTree, chunks)
+				if err != nil {
+					return err
+				}
+			}
+			newMemories = append(newMemories, repoMemories...)
+		}
+	}
+
+	// ------------------------------
+	// Step 3: Merge only the new memories.
+	// ------------------------------
+	if len(newMemories) > 0 {
+		collectedOldMemories := em.Context.FilterRelatedMemories(newMemories)
+		updatedContext, err := em.BuildContext(newMemories, collectedOldMemories)
+		if err != nil {
+			return fmt.Errorf("failed to build updated context: %w", err)
+		}
+		if err := em.Context.SetContext(updatedContext); err != nil {
+			return fmt.Errorf("failed to set hot context: %w", err)
+		}
+		if err := em.RefreshMemories(collectedOldMemories, newMemories); err != nil {
+			return fmt.Errorf("failed to refresh memories: %w", err)
+		}
+	}
+
+	state.LastCommit = headSHA
+	for _, p := range pages {
+		state.DocEdits[p.ID] = p.LastEdited
+	}
+	if err := state.save(statePath); err != nil {
+		return fmt.Errorf("failed to save refresh state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/context/similarity/exact"
+)
+
+// NearDuplicateThreshold is the cosine similarity at or above which two thoughts are treated as the same memory.
+const NearDuplicateThreshold = 0.95
+
+// normalizeThought reduces a memory's content to a key that ignores case, spacing and punctuation.
+func normalizeThought(content string) string {
+	fields := strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// dedupeThoughts returns the thoughts of batch that do not repeat each other or any of known.
+// Exact repeats are found by normalized content; when the agent has an embedding provider,
+// near-duplicates are collapsed as well by searching the batch for similar embeddings.
+// A collapsed thought keeps the higher importance of the pair. The result is ordered by
+// importance, most important first, with ties kept in the order the model produced them.
+func (a *BaseAgent) dedupeThoughts(known, batch []context.EasyMemory) []context.EasyMemory {
+	seen := make(map[string]bool, len(known))
+	for _, k := range known {
+		seen[normalizeThought(k.Content)] = true
+	}
+
+	var distinct []context.EasyMemory
+	index := make(map[string]int)
+	for _, mem := range batch {
+		key := normalizeThought(mem.Content)
+		if key == "" || seen[key] {
+			continue
+		}
+		if i, ok := index[key]; ok {
+			distinct[i].Importance = max(distinct[i].Importance, mem.Importance)
+			continue
+		}
+		index[key] = len(distinct)
+		distinct = append(distinct, mem)
+	}
+
+	if a.Embeddings != nil && len(distinct) > 0 {
+		collapsed, err := a.collapseNearDuplicates(known, distinct)
+		if err != nil {
+			fmt.Printf("Warning: skipping near-duplicate check: %v\n", err)
+		} else {
+			distinct = collapsed
+		}
+	}
+
+	sort.SliceStable(distinct, func(i, j int) bool { return distinct[i].Importance > distinct[j].Importance })
+	return distinct
+}
+
+// collapseNearDuplicates drops thoughts whose embedding is close to one of known or to an earlier kept thought.
+func (a *BaseAgent) collapseNearDuplicates(known, batch []context.EasyMemory) ([]context.EasyMemory, error) {
+	searcher := exact.New()
+	for i, mem := range known {
+		emb, err := a.Embeddings.ComputeEmbedding(mem.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed known thought: %w", err)
+		}
+		if err := searcher.IndexMemory(context.MemoryEntry{ID: "known_" + strconv.Itoa(i), Embedding: emb}); err != nil {
+			return nil, fmt.Errorf("failed to index known thought: %w", err)
+		}
+	}
+
+	var kept []context.EasyMemory
+	for _, mem := range batch {
+		emb, err := a.Embeddings.ComputeEmbedding(mem.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed thought: %w", err)
+		}
+		matches, err := searcher.Search(emb, 1, NearDuplicateThreshold)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search thoughts: %w", err)
+		}
+		if len(matches) > 0 {
+			if i, err := strconv.Atoi(matches[0].ID); err == nil {
+				kept[i].Importance = max(kept[i].Importance, mem.Importance)
+			}
+			continue
+		}
+		if err := searcher.IndexMemory(context.MemoryEntry{ID: strconv.Itoa(len(kept)), Embedding: emb}); err != nil {
+			return nil, fmt.Errorf("failed to index thought: %w", err)
+		}
+		kept = append(kept, mem)
+	}
+	return kept, nil
+}
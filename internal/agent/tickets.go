@@ -0,0 +1,163 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// ErrNoTerminalList is returned by CloseTicket when the board has none of the lists for finished tickets.
+var ErrNoTerminalList = errors.New("no list for finished tickets")
+
+// GetAssignedTickets returns the cards assigned to this agent.
+func (a *BaseAgent) GetAssignedTickets() ([]board.Card, error) {
+	return a.FindMyTickets()
+}
+
+// AssignTicketToAgent assigns a ticket to the agent with the given name.
+func (a *BaseAgent) AssignTicketToAgent(ticket board.Card, agentName string) error {
+	if err := ticket.AssignTo(agentName); err != nil {
+		return fmt.Errorf("failed to assign %q to %s: %w", ticket.GetName(), agentName, err)
+	}
+	return nil
+}
+
+// ChangeTicketColumn moves a ticket to the named list.
+func (a *BaseAgent) ChangeTicketColumn(ticket board.Card, column string) error {
+	if err := ticket.Move(column); err != nil {
+		return fmt.Errorf("failed to move %q to %s: %w", ticket.GetName(), column, err)
+	}
+	return nil
+}
+
+// ChangeTicketAssignee hands a ticket over from one member to another.
+// An empty from only adds the new assignee.
+func (a *BaseAgent) ChangeTicketAssignee(ticket board.Card, from, to string) error {
+	if from != "" {
+		if err := ticket.UnassignFrom(from); err != nil {
+			return fmt.Errorf("failed to unassign %s from %q: %w", from, ticket.GetName(), err)
+		}
+	}
+	return a.AssignTicketToAgent(ticket, to)
+}
+
+// CloseTicket moves a ticket to the board's list for finished tickets: the first of
+// config.GetTerminalLists that exists on the board, matched case-insensitively.
+// A ticket already in a terminal list is left where it is.
+func (a *BaseAgent) CloseTicket(ticket board.Card) error {
+	done, err := a.IsTicketFinished(ticket)
+	if err != nil || done {
+		return err
+	}
+	column, err := a.terminalList()
+	if err != nil {
+		return err
+	}
+	if err := a.ChangeTicketColumn(ticket, column); err != nil {
+		return err
+	}
+	a.notify("%s closed %s (%s)", a.Name, ticket.GetName(), ticket.GetURL())
+	return nil
+}
+
+// IsTicketFinished reports whether the ticket sits in one of config.GetTerminalLists.
+func (a *BaseAgent) IsTicketFinished(ticket board.Card) (bool, error) {
+	list, err := ticket.GetList()
+	if err != nil {
+		return false, fmt.Errorf("failed to get list of %q: %w", ticket.GetName(), err)
+	}
+	if list == nil {
+		return false, nil
+	}
+	for _, name := range config.GetTerminalLists() {
+		if strings.EqualFold(list.GetName(), name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// terminalList returns the board's name for the first terminal list that exists on it.
+func (a *BaseAgent) terminalList() (string, error) {
+	if a.BoardClient == nil {
+		return "", fmt.Errorf("board client not configured")
+	}
+	lists, err := a.BoardClient.GetLists()
+	if err != nil {
+		return "", fmt.Errorf("failed to get lists: %w", err)
+	}
+	candidates := config.GetTerminalLists()
+	for _, name := range candidates {
+		for _, l := range lists {
+			if strings.EqualFold(l.GetName(), name) {
+				return l.GetName(), nil
+			}
+		}
+	}
+	available := make([]string, len(lists))
+	for i, l := range lists {
+		available[i] = l.GetName()
+	}
+	return "", fmt.Errorf("%w: tried %s; the board has %s", ErrNoTerminalList, strings.Join(candidates, ", "), strings.Join(available, ", "))
+}
+
+// WriteComment posts a comment on a ticket.
+func (a *BaseAgent) WriteComment(ticket board.Card, comment string) error {
+	if err := ticket.WriteComment(comment); err != nil {
+		return fmt.Errorf("failed to comment on %q: %w", ticket.GetName(), err)
+	}
+	return nil
+}
+
+// WriteToGit writes a file into the agent's repository working tree.
+func (a *BaseAgent) WriteToGit(fileName string, content []byte) error {
+	if a.GitClient == nil {
+		return fmt.Errorf("git client not configured")
+	}
+	if err := a.GitClient.WriteFile(fileName, content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", fileName, err)
+	}
+	return nil
+}
+
+// AcquireTicket marks a ticket as being processed by this agent and makes it the CurrentTicketID.
+// It returns false, leaving everything unchanged, when the ticket is already in flight,
+// so overlapping polls never work on the same ticket twice. Tickets are keyed by URL.
+func (a *BaseAgent) AcquireTicket(ticket board.Card) bool {
+	id := ticket.GetURL()
+	a.ticketsMu.Lock()
+	defer a.ticketsMu.Unlock()
+	if _, busy := a.inFlight[id]; busy {
+		return false
+	}
+	if a.inFlight == nil {
+		a.inFlight = make(map[string]struct{})
+	}
+	a.inFlight[id] = struct{}{}
+	a.CurrentTicketID = id
+	return true
+}
+
+// ReleaseTicket marks a ticket acquired with AcquireTicket as done and clears CurrentTicketID if it points at it.
+func (a *BaseAgent) ReleaseTicket(ticket board.Card) {
+	id := ticket.GetURL()
+	a.ticketsMu.Lock()
+	defer a.ticketsMu.Unlock()
+	delete(a.inFlight, id)
+	if a.CurrentTicketID == id {
+		a.CurrentTicketID = ""
+	}
+}
+
+// WithTicket runs fn while holding the ticket. When the ticket is already being processed fn is
+// skipped and handled is false. The ticket is released even if fn panics.
+func (a *BaseAgent) WithTicket(ticket board.Card, fn func() error) (handled bool, err error) {
+	if !a.AcquireTicket(ticket) {
+		return false, nil
+	}
+	defer a.ReleaseTicket(ticket)
+	return true, fn()
+}
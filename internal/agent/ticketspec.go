@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// specExtensions maps the attachment types accepted as ticket specs to whether their content is plain text.
+// Text specs are inlined into the prompt; all of them are uploaded to the vector store when one is configured.
+var specExtensions = map[string]bool{
+	".md":       true,
+	".markdown": true,
+	".txt":      true,
+	".pdf":      false,
+}
+
+// maxInlinedSpec caps how much of a text attachment is copied into the prompt.
+const maxInlinedSpec = 20000
+
+// specAttachment is a downloaded ticket attachment.
+type specAttachment struct {
+	name    string
+	content []byte
+	text    bool
+}
+
+// IngestTicketSpec reads the spec documents attached to the ticket, creates thoughts grounded in them and
+// remembers those thoughts. Attachments of unsupported types or that fail to download are skipped with a warning,
+// as are non-text attachments when there is no vector store to search them.
+// It returns the created thoughts; a ticket without usable attachments yields none.
+func (em *EngineeringManagerAgent) IngestTicketSpec(ticket board.Card) ([]context.EasyMemory, error) {
+	specs, err := em.ticketSpecs(ticket)
+	if err != nil {
+		return nil, err
+	}
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	var files []model.FileAttachment
+	if em.VectorStorage != nil {
+		files, err = em.uploadSpecs(specs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Ticket: %s (%s)\nThe ticket is specified by the attached documents.\n", ticket.GetName(), ticket.GetURL())
+	for _, s := range specs {
+		if !s.text {
+			if len(files) > 0 {
+				fmt.Fprintf(&b, "\nAttached document %q is available through file search.\n", s.name)
+			}
+			continue
+		}
+		content := string(s.content)
+		if len(content) > maxInlinedSpec {
+			content = content[:maxInlinedSpec] + "\n..."
+		}
+		fmt.Fprintf(&b, "\nAttached document %q:\n%s\n", s.name, content)
+	}
+
+	thoughts, err := em.CreateThoughts(b.String(), files, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create thoughts from ticket spec: %w", err)
+	}
+	for _, t := range thoughts {
+		if err := em.Context.Remember(t); err != nil {
+			return thoughts, fmt.Errorf("failed to remember ticket spec thought: %w", err)
+		}
+	}
+	return thoughts, nil
+}
+
+// ticketSpecs downloads the ticket's attachments of a supported spec type.
+func (em *EngineeringManagerAgent) ticketSpecs(ticket board.Card) ([]specAttachment, error) {
+	attachments, err := ticket.GetAttachments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	var specs []specAttachment
+	for _, att := range attachments {
+		name := att.Name
+		if name == "" {
+			name = path.Base(att.URL)
+		}
+		text, ok := specExtensions[strings.ToLower(path.Ext(name))]
+		if !ok || att.URL == "" {
+			fmt.Printf("Warning: skipping unsupported attachment %q on %q\n", name, ticket.GetName())
+			continue
+		}
+		if !text && em.VectorStorage == nil {
+			// Only text specs can be read without file search.
+			fmt.Printf("Warning: skipping attachment %q on %q: no vector store to search it\n", name, ticket.GetName())
+			continue
+		}
+		content, err := em.DownloadAttachment(att)
+		if err != nil {
+			fmt.Printf("Warning: skipping attachment %q on %q: %v\n", name, ticket.GetName(), err)
+			continue
+		}
+		specs = append(specs, specAttachment{name: name, content: content, text: text})
+	}
+	return specs, nil
+}
+
+// DownloadAttachment fetches the content of a card attachment from its URL, using HTTPClient when set.
+func (em *EngineeringManagerAgent) DownloadAttachment(att board.Attachment) ([]byte, error) {
+	client := em.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(att.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download attachment: status %d", resp.StatusCode)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	return content, nil
+}
+
+// uploadSpecs uploads the specs to the "aiagents" vector store and returns the attachments for CreateThoughts.
+func (em *EngineeringManagerAgent) uploadSpecs(specs []specAttachment) ([]model.FileAttachment, error) {
+	vectorStoreID, err := em.ensureVectorStore()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "ticket-spec-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	var files []model.FileAttachment
+	for _, s := range specs {
+		filePath := filepath.Join(dir, filepath.Base(s.name))
+		if err := os.WriteFile(filePath, s.content, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write attachment %s: %w", s.name, err)
+		}
+		uploaded, err := em.ModelClient.UploadFile(filePath, string(model.FilePurposeAssistants))
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload attachment %s: %w", s.name, err)
+		}
+		if _, err := em.VectorStorage.AttachFile(vectorStoreID, uploaded.ID); err != nil {
+			return nil, fmt.Errorf("failed to attach %s to vector store: %w", s.name, err)
+		}
+		files = append(files, model.FileAttachment{FileID: uploaded.ID, VectorStoreID: vectorStoreID})
+	}
+	return files, nil
+}
@@ -0,0 +1,59 @@
+// Package apierr defines sentinel errors shared by the external service clients so callers
+// can react to failures with errors.Is instead of matching error strings.
+package apierr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrRateLimited means the service rejected the request because of rate limiting.
+	ErrRateLimited = errors.New("rate limited")
+	// ErrNotFound means the requested resource does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized means the credentials are missing, invalid or lack permission.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrAlreadyUpToDate means there was nothing to do, e.g. a push with no new commits.
+	ErrAlreadyUpToDate = errors.New("already up to date")
+)
+
+// FromStatus returns the sentinel matching an HTTP status code, or nil if none applies.
+func FromStatus(code int) error {
+	switch code {
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// StatusError is an unexpected HTTP response. It unwraps to the sentinel matching its status code.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+// NewStatusError creates a StatusError for the given status code and response body.
+func NewStatusError(code int, body string) *StatusError {
+	return &StatusError{StatusCode: code, Body: body}
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", e.StatusCode, e.Body)
+}
+
+// Unwrap returns the sentinel for the status code, so errors.Is(err, ErrNotFound) works.
+func (e *StatusError) Unwrap() error {
+	return FromStatus(e.StatusCode)
+}
+
+// IsKnown reports whether err already wraps one of the sentinels.
+func IsKnown(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrNotFound) ||
+		errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrAlreadyUpToDate)
+}
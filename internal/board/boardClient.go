@@ -1,15 +1,17 @@
 package board
 
+import "time"
+
 // Member represents a board member.
 type Member struct {
-	ID   string
-	Name string
+	ID   string `json:"id"`
+	Name string `json:"name"`
 }
 
 // Comment represents a comment on a card.
 type Comment struct {
-	Text   string
-	Member *Member
+	Text   string  `json:"text"`
+	Member *Member `json:"member,omitempty"`
 }
 
 // Attachment represents an attachment on a card.
@@ -19,6 +21,22 @@ type Attachment struct {
 	URL  string
 }
 
+// CardSpec describes a card to create with all of its initial attributes.
+type CardSpec struct {
+	Name        string
+	Description string
+	// ListName is the name of the list (column) the card is created in.
+	ListName string
+	// Assignees are member usernames or full names.
+	Assignees []string
+	// Labels are label names (or colors for unnamed labels).
+	Labels []string
+	// DueDate is optional.
+	DueDate *time.Time
+	// Position is "top", "bottom" or a numeric position; empty keeps the backend default.
+	Position string
+}
+
 // Card defines the operations available on a card.
 type Card interface {
 	// GetName returns the name of the card.
@@ -45,6 +63,11 @@ type Card interface {
 	GetAttachments() ([]Attachment, error)
 	// AddAttachment adds a new attachment to the card.
 	AddAttachment(attachment Attachment) error
+	// GetCustomFields returns the card's custom field values keyed by field name.
+	// Dropdown values are resolved to their labels.
+	GetCustomFields() (map[string]string, error)
+	// SetCustomField sets a custom field by name. An empty value clears the field.
+	SetCustomField(name, value string) error
 }
 
 // List defines operations for a board column (list).
@@ -67,12 +90,24 @@ type Board interface {
 	GetCards() ([]Card, error)
 	// CreateCard creates a new card on the board.
 	CreateCard(name, description, listName string) (Card, error)
+	// CreateCardDetailed creates a card with its assignees, labels, due date and position set.
+	CreateCardDetailed(spec CardSpec) (Card, error)
+	// GetCard fetches a single card by its ID.
+	GetCard(id string) (Card, error)
 	// GetCardsAssignedTo returns all cards assigned to a specific member.
 	GetCardsAssignedTo(userName string) ([]Card, error)
 	// GetCardsFromList returns all cards in a specific list.
 	GetCardsFromList(listName string) ([]Card, error)
+	// GetCardsUpdatedSince returns the cards that were created, changed or commented on after since.
+	GetCardsUpdatedSince(since time.Time) ([]Card, error)
 	// GetLists retrieves all lists (columns) on the board.
 	GetLists() ([]List, error)
+	// CreateList adds a new list (column) to the board.
+	CreateList(name string) (List, error)
+	// RenameList changes the name of the list with the given ID.
+	RenameList(listID, newName string) error
+	// MoveList changes the position of the list with the given ID: "top", "bottom" or a numeric position.
+	MoveList(listID, pos string) error
 }
 
 // BoardClient is the main dependency injection interface for board connectors.
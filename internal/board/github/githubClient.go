@@ -0,0 +1,523 @@
+// internal/board/github/githubClient.go
+package githubClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	bc "github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/httputil"
+)
+
+// StatusLabelKey is the label key that holds a card's list, e.g. the label "status: In Progress"
+// puts an issue in the "In Progress" list. Other "key: value" labels are exposed as custom fields.
+const StatusLabelKey = "status"
+
+// labelSeparator separates a label key from its value.
+const labelSeparator = ": "
+
+// perPage is the page size used for list endpoints.
+const perPage = 100
+
+// -------------------------
+// Concrete GitHubClient
+// -------------------------
+
+// GitHubClient implements the bc.BoardClient interface on top of GitHub Issues.
+// Lists are "status: <name>" labels, cards are open issues, comments are issue comments
+// and members are the repository's assignable users.
+type GitHubClient struct {
+	Owner      string
+	Repo       string
+	Token      string
+	BaseURL    string // e.g. "https://api.github.com"
+	HTTPClient *http.Client
+	Retry      httputil.RetryPolicy // retries of rate-limited and failing requests; zero sends each request once
+}
+
+// NewGitHubClient constructs a new GitHubClient for owner/repo.
+func NewGitHubClient(token, owner, repo string) *GitHubClient {
+	return &GitHubClient{
+		Owner:      owner,
+		Repo:       repo,
+		Token:      token,
+		BaseURL:    "https://api.github.com",
+		HTTPClient: &http.Client{},
+		Retry:      httputil.DefaultRetryPolicy,
+	}
+}
+
+// ghUser is a GitHub user as returned by the API.
+type ghUser struct {
+	Login string `json:"login"`
+}
+
+// ghLabel is a GitHub label as returned by the API.
+type ghLabel struct {
+	Name string `json:"name"`
+}
+
+// ghIssue is the subset of a GitHub issue used by the client.
+type ghIssue struct {
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	Body        string          `json:"body"`
+	HTMLURL     string          `json:"html_url"`
+	Labels      []ghLabel       `json:"labels"`
+	Assignees   []ghUser        `json:"assignees"`
+	PullRequest json.RawMessage `json:"pull_request,omitempty"`
+}
+
+// repoPath returns the API path of a repository sub-resource.
+func (gc *GitHubClient) repoPath(suffix string) string {
+	return fmt.Sprintf("/repos/%s/%s%s", gc.Owner, gc.Repo, suffix)
+}
+
+// do performs an API request, encoding body as JSON and decoding the response into target.
+func (gc *GitHubClient) do(method, path string, body, target interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewBuffer(data)
+	}
+	req, err := http.NewRequest(method, gc.BaseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if gc.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+gc.Token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := httputil.Do(req.Context(), gc.HTTPClient, req, gc.Retry)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	defer resp.Body.Close()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s failed: %w", method, path, apierr.NewStatusError(resp.StatusCode, string(respBytes)))
+	}
+	if target != nil && len(respBytes) > 0 {
+		if err := json.Unmarshal(respBytes, target); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// getPaged fetches every page of a list endpoint and returns the concatenated items.
+func getPaged[T any](gc *GitHubClient, path string) ([]T, error) {
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	var all []T
+	for page := 1; ; page++ {
+		var items []T
+		if err := gc.do("GET", fmt.Sprintf("%s%sper_page=%d&page=%d", path, sep, perPage, page), nil, &items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < perPage {
+			return all, nil
+		}
+	}
+}
+
+func (gc *GitHubClient) GetName() string {
+	return gc.Owner + "/" + gc.Repo
+}
+
+func (gc *GitHubClient) GetURL() string {
+	var repo struct {
+		HTMLURL string `json:"html_url"`
+	}
+	if err := gc.do("GET", gc.repoPath(""), nil, &repo); err != nil {
+		return ""
+	}
+	return repo.HTMLURL
+}
+
+func (gc *GitHubClient) GetMembers() ([]bc.Member, error) {
+	users, err := getPaged[ghUser](gc, gc.repoPath("/assignees"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignees: %w", err)
+	}
+	var result []bc.Member
+	for _, u := range users {
+		result = append(result, bc.Member{ID: u.Login, Name: u.Login})
+	}
+	return result, nil
+}
+
+func (gc *GitHubClient) GetLists() ([]bc.List, error) {
+	labels, err := getPaged[ghLabel](gc, gc.repoPath("/labels"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get labels: %w", err)
+	}
+	var result []bc.List
+	for _, l := range labels {
+		if name, ok := labelValue(l.Name, StatusLabelKey); ok {
+			result = append(result, &GitHubList{Label: l.Name, Name: name})
+		}
+	}
+	return result, nil
+}
+
+// CreateList creates the status label backing a new list.
+func (gc *GitHubClient) CreateList(name string) (bc.List, error) {
+	label := labelName(StatusLabelKey, name)
+	if err := gc.do("POST", gc.repoPath("/labels"), map[string]string{"name": label, "color": "ededed"}, nil); err != nil {
+		return nil, fmt.Errorf("failed to create list %s: %w", name, err)
+	}
+	return &GitHubList{Label: label, Name: name}, nil
+}
+
+// RenameList renames the status label backing a list; issues keep the label under its new name.
+func (gc *GitHubClient) RenameList(listID, newName string) error {
+	payload := map[string]string{"new_name": labelName(StatusLabelKey, newName)}
+	if err := gc.do("PATCH", gc.repoPath("/labels/"+url.PathEscape(listID)), payload, nil); err != nil {
+		return fmt.Errorf("failed to rename list %s: %w", listID, err)
+	}
+	return nil
+}
+
+// MoveList is not supported: labels have no position on GitHub.
+func (gc *GitHubClient) MoveList(listID, pos string) error {
+	return fmt.Errorf("failed to move list %s: GitHub labels have no position", listID)
+}
+
+// CreateCard opens a new issue in the given list.
+func (gc *GitHubClient) CreateCard(name, description, listName string) (bc.Card, error) {
+	return gc.CreateCardDetailed(bc.CardSpec{Name: name, Description: description, ListName: listName})
+}
+
+// CreateCardDetailed opens an issue with its assignees and labels set. GitHub issues have no
+// due date or position, so a due date is recorded in the body and Position is ignored.
+func (gc *GitHubClient) CreateCardDetailed(spec bc.CardSpec) (bc.Card, error) {
+	list, err := gc.findList(spec.ListName)
+	if err != nil {
+		return nil, err
+	}
+	body := spec.Description
+	if spec.DueDate != nil {
+		body = strings.TrimSpace(body + "\n\nDue: " + spec.DueDate.Format("2006-01-02"))
+	}
+	payload := map[string]interface{}{
+		"title":  spec.Name,
+		"body":   body,
+		"labels": append([]string{list.Label}, spec.Labels...),
+	}
+	if len(spec.Assignees) > 0 {
+		payload["assignees"] = spec.Assignees
+	}
+	var issue ghIssue
+	if err := gc.do("POST", gc.repoPath("/issues"), payload, &issue); err != nil {
+		return nil, fmt.Errorf("failed to create issue: %w", err)
+	}
+	return gc.newCard(issue), nil
+}
+
+// GetCard fetches an issue by its number.
+func (gc *GitHubClient) GetCard(id string) (bc.Card, error) {
+	number, err := strconv.Atoi(strings.TrimPrefix(id, "#"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid issue number %q: %w", id, err)
+	}
+	var issue ghIssue
+	if err := gc.do("GET", gc.repoPath(fmt.Sprintf("/issues/%d", number)), nil, &issue); err != nil {
+		return nil, fmt.Errorf("failed to get issue %d: %w", number, err)
+	}
+	return gc.newCard(issue), nil
+}
+
+// GetCards returns every open issue, excluding pull requests.
+func (gc *GitHubClient) GetCards() ([]bc.Card, error) {
+	return gc.listIssues("")
+}
+
+func (gc *GitHubClient) GetCardsAssignedTo(userName string) ([]bc.Card, error) {
+	return gc.listIssues("&assignee=" + url.QueryEscape(userName))
+}
+
+func (gc *GitHubClient) GetCardsFromList(listName string) ([]bc.Card, error) {
+	list, err := gc.findList(listName)
+	if err != nil {
+		return nil, err
+	}
+	return gc.listIssues("&labels=" + url.QueryEscape(list.Label))
+}
+
+// GetCardsUpdatedSince returns the open issues updated after since; comments count as updates.
+func (gc *GitHubClient) GetCardsUpdatedSince(since time.Time) ([]bc.Card, error) {
+	return gc.listIssues("&since=" + url.QueryEscape(since.UTC().Format(time.RFC3339)))
+}
+
+// listIssues lists open issues with the given extra query parameters.
+func (gc *GitHubClient) listIssues(query string) ([]bc.Card, error) {
+	issues, err := getPaged[ghIssue](gc, gc.repoPath("/issues?state=open"+query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issues: %w", err)
+	}
+	var result []bc.Card
+	for _, issue := range issues {
+		if len(issue.PullRequest) > 0 {
+			continue
+		}
+		result = append(result, gc.newCard(issue))
+	}
+	return result, nil
+}
+
+// findList returns the list with the given name, compared case-insensitively.
+func (gc *GitHubClient) findList(listName string) (*GitHubList, error) {
+	lists, err := gc.GetLists()
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lists {
+		if strings.EqualFold(l.GetName(), listName) {
+			return l.(*GitHubList), nil
+		}
+	}
+	return nil, fmt.Errorf("list %s not found", listName)
+}
+
+// newCard wraps an API issue in a GitHubCard.
+func (gc *GitHubClient) newCard(issue ghIssue) *GitHubCard {
+	card := &GitHubCard{
+		Number:      issue.Number,
+		Title:       issue.Title,
+		Description: issue.Body,
+		URL:         issue.HTMLURL,
+		BoardClient: gc,
+	}
+	for _, l := range issue.Labels {
+		card.labels = append(card.labels, l.Name)
+	}
+	for _, a := range issue.Assignees {
+		card.assignees = append(card.assignees, a.Login)
+	}
+	return card
+}
+
+// labelName builds a "key: value" label.
+func labelName(key, value string) string {
+	return key + labelSeparator + value
+}
+
+// labelValue returns the value of a "key: value" label if its key matches.
+func labelValue(label, key string) (string, bool) {
+	k, v, ok := strings.Cut(label, labelSeparator)
+	if !ok || !strings.EqualFold(strings.TrimSpace(k), key) {
+		return "", false
+	}
+	return strings.TrimSpace(v), true
+}
+
+// -------------------------
+// Concrete GitHubList
+// -------------------------
+
+// GitHubList is a list backed by a status label.
+type GitHubList struct {
+	Label string // Full label name, e.g. "status: To Do".
+	Name  string // List name, e.g. "To Do".
+}
+
+func (gl *GitHubList) GetName() string {
+	return gl.Name
+}
+
+func (gl *GitHubList) GetID() string {
+	return gl.Label
+}
+
+// -------------------------
+// Concrete GitHubCard
+// -------------------------
+
+// GitHubCard is a card backed by a GitHub issue.
+type GitHubCard struct {
+	Number      int
+	Title       string
+	Description string
+	URL         string
+	BoardClient *GitHubClient
+
+	labels    []string
+	assignees []string
+}
+
+func (c *GitHubCard) GetName() string {
+	return c.Title
+}
+
+func (c *GitHubCard) ChangeName(newName string) error {
+	if err := c.BoardClient.do("PATCH", c.issuePath(""), map[string]string{"title": newName}, nil); err != nil {
+		return fmt.Errorf("failed to rename issue: %w", err)
+	}
+	c.Title = newName
+	return nil
+}
+
+func (c *GitHubCard) GetURL() string {
+	return c.URL
+}
+
+// GetList returns the list given by the issue's status label.
+func (c *GitHubCard) GetList() (bc.List, error) {
+	for _, l := range c.labels {
+		if name, ok := labelValue(l, StatusLabelKey); ok {
+			return &GitHubList{Label: l, Name: name}, nil
+		}
+	}
+	return nil, fmt.Errorf("issue #%d has no %s label", c.Number, StatusLabelKey)
+}
+
+// Move replaces the issue's status label with the label of the target list.
+func (c *GitHubCard) Move(newListName string) error {
+	list, err := c.BoardClient.findList(newListName)
+	if err != nil {
+		return err
+	}
+	return c.replaceLabel(StatusLabelKey, list.Label)
+}
+
+func (c *GitHubCard) GetAssignedMembers() ([]bc.Member, error) {
+	var members []bc.Member
+	for _, a := range c.assignees {
+		members = append(members, bc.Member{ID: a, Name: a})
+	}
+	return members, nil
+}
+
+func (c *GitHubCard) AssignTo(userName string) error {
+	var issue ghIssue
+	if err := c.BoardClient.do("POST", c.issuePath("/assignees"), map[string][]string{"assignees": {userName}}, &issue); err != nil {
+		return fmt.Errorf("failed to assign %s: %w", userName, err)
+	}
+	c.assignees = nil
+	for _, a := range issue.Assignees {
+		c.assignees = append(c.assignees, a.Login)
+	}
+	return nil
+}
+
+func (c *GitHubCard) UnassignFrom(userName string) error {
+	var issue ghIssue
+	if err := c.BoardClient.do("DELETE", c.issuePath("/assignees"), map[string][]string{"assignees": {userName}}, &issue); err != nil {
+		return fmt.Errorf("failed to unassign %s: %w", userName, err)
+	}
+	c.assignees = nil
+	for _, a := range issue.Assignees {
+		c.assignees = append(c.assignees, a.Login)
+	}
+	return nil
+}
+
+func (c *GitHubCard) ReadComments() ([]bc.Comment, error) {
+	type ghComment struct {
+		Body string `json:"body"`
+		User ghUser `json:"user"`
+	}
+	comments, err := getPaged[ghComment](c.BoardClient, c.issuePath("/comments"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	var result []bc.Comment
+	for _, cm := range comments {
+		result = append(result, bc.Comment{
+			Text:   cm.Body,
+			Member: &bc.Member{ID: cm.User.Login, Name: cm.User.Login},
+		})
+	}
+	return result, nil
+}
+
+func (c *GitHubCard) WriteComment(comment string) error {
+	if err := c.BoardClient.do("POST", c.issuePath("/comments"), map[string]string{"body": comment}, nil); err != nil {
+		return fmt.Errorf("failed to post comment: %w", err)
+	}
+	return nil
+}
+
+// GetAttachments returns nothing: GitHub issues have no attachments, links live in comments.
+func (c *GitHubCard) GetAttachments() ([]bc.Attachment, error) {
+	return nil, nil
+}
+
+// AddAttachment posts the attachment as a Markdown link comment.
+func (c *GitHubCard) AddAttachment(attachment bc.Attachment) error {
+	return c.WriteComment(fmt.Sprintf("Attachment: [%s](%s)", attachment.Name, attachment.URL))
+}
+
+// GetCustomFields returns the issue's "key: value" labels, except the status label.
+func (c *GitHubCard) GetCustomFields() (map[string]string, error) {
+	fields := make(map[string]string)
+	for _, l := range c.labels {
+		k, v, ok := strings.Cut(l, labelSeparator)
+		if !ok || strings.EqualFold(strings.TrimSpace(k), StatusLabelKey) {
+			continue
+		}
+		fields[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return fields, nil
+}
+
+// SetCustomField replaces the issue's "name: value" label. An empty value removes it.
+func (c *GitHubCard) SetCustomField(name, value string) error {
+	if strings.EqualFold(name, StatusLabelKey) {
+		return fmt.Errorf("use Move to change the %s label", StatusLabelKey)
+	}
+	label := ""
+	if value != "" {
+		label = labelName(name, value)
+	}
+	return c.replaceLabel(name, label)
+}
+
+// replaceLabel removes every label with the given key and adds newLabel, if non-empty.
+func (c *GitHubCard) replaceLabel(key, newLabel string) error {
+	labels := []string{}
+	for _, l := range c.labels {
+		if _, ok := labelValue(l, key); !ok {
+			labels = append(labels, l)
+		}
+	}
+	if newLabel != "" {
+		labels = append(labels, newLabel)
+	}
+	if err := c.BoardClient.do("PUT", c.issuePath("/labels"), map[string][]string{"labels": labels}, nil); err != nil {
+		return fmt.Errorf("failed to update labels: %w", err)
+	}
+	c.labels = labels
+	return nil
+}
+
+// issuePath returns the API path of an issue sub-resource.
+func (c *GitHubCard) issuePath(suffix string) string {
+	return c.BoardClient.repoPath(fmt.Sprintf("/issues/%d%s", c.Number, suffix))
+}
+
+var _ bc.BoardClient = (*GitHubClient)(nil)
+var _ bc.Card = (*GitHubCard)(nil)
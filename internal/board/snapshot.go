@@ -0,0 +1,24 @@
+package board
+
+// BoardSnapshot is a JSON-marshalable copy of a board's state, used to reproduce bugs
+// and to seed fake boards in tests.
+type BoardSnapshot struct {
+	Name    string         `json:"name"`
+	Lists   []string       `json:"lists"`
+	Members []Member       `json:"members"`
+	Cards   []CardSnapshot `json:"cards"`
+}
+
+// CardSnapshot is the state of a single card within a BoardSnapshot.
+type CardSnapshot struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// List is the name of the list the card is in.
+	List string `json:"list"`
+	// Assignees are the names of the members the card is assigned to.
+	Assignees []string `json:"assignees,omitempty"`
+	// Labels are label names, or colors for unnamed labels.
+	Labels   []string  `json:"labels,omitempty"`
+	Comments []Comment `json:"comments,omitempty"`
+}
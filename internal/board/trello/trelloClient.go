@@ -2,16 +2,49 @@
 package trelloClient
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"net/url"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/adlio/trello"
+	"github.com/egobogo/aiagents/internal/apierr"
 	bc "github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/httputil"
 )
 
+// trelloErr tags errors from the Trello API with the matching apierr sentinel so callers can
+// use errors.Is. Errors that are nil or already tagged are returned unchanged.
+func trelloErr(err error) error {
+	if err == nil || apierr.IsKnown(err) {
+		return err
+	}
+	var rateLimited interface{ IsRateLimit() bool }
+	var notFound interface{ IsNotFound() bool }
+	var denied interface{ IsPermissionDenied() bool }
+	switch {
+	case errors.As(err, &rateLimited) && rateLimited.IsRateLimit():
+		return fmt.Errorf("%w: %w", apierr.ErrRateLimited, err)
+	case errors.As(err, &notFound) && notFound.IsNotFound():
+		return fmt.Errorf("%w: %w", apierr.ErrNotFound, err)
+	case errors.As(err, &denied) && denied.IsPermissionDenied():
+		return fmt.Errorf("%w: %w", apierr.ErrUnauthorized, err)
+	}
+	return err
+}
+
 // -------------------------
 // Concrete TrelloBoardClient
 // -------------------------
@@ -22,17 +55,83 @@ type TrelloClient struct {
 	BoardID string
 	APIKey  string
 	Token   string
+	// Retry applies to the requests the client sends itself; the trello library's calls are not retried.
+	Retry httputil.RetryPolicy
+	// MembersTTL is how long the board members are cached for member lookups; zero disables the cache.
+	MembersTTL time.Duration
+	// MaxAttachmentNameLength caps the length of attachment names in characters; zero disables the check.
+	MaxAttachmentNameLength int
+
+	membersMu      sync.Mutex
+	members        []*trello.Member
+	membersFetched time.Time
 }
 
+// DefaultMembersTTL is the MembersTTL of clients created with NewTrelloClient.
+const DefaultMembersTTL = time.Minute
+
+// DefaultMaxAttachmentNameLength is the MaxAttachmentNameLength of clients created with NewTrelloClient,
+// matching the limit of the Trello API.
+const DefaultMaxAttachmentNameLength = 256
+
+// ErrInvalidAttachment is returned by AddAttachment for an attachment without a usable URL or with a name
+// that is too long.
+var ErrInvalidAttachment = errors.New("invalid attachment")
+
 // NewTrelloClient constructs a new TrelloClient.
 func NewTrelloClient(apiKey, token, boardID string) *TrelloClient {
 	client := trello.NewClient(apiKey, token)
 	return &TrelloClient{
-		Client:  client,
-		BoardID: boardID,
-		APIKey:  apiKey,
-		Token:   token,
+		Client:     client,
+		BoardID:    boardID,
+		APIKey:     apiKey,
+		Token:      token,
+		Retry:      httputil.DefaultRetryPolicy,
+		MembersTTL: DefaultMembersTTL,
+
+		MaxAttachmentNameLength: DefaultMaxAttachmentNameLength,
+	}
+}
+
+// boardMembers returns the board members, from the cache while it is younger than MembersTTL.
+func (tc *TrelloClient) boardMembers() ([]*trello.Member, error) {
+	tc.membersMu.Lock()
+	defer tc.membersMu.Unlock()
+	if tc.members != nil && time.Since(tc.membersFetched) < tc.MembersTTL {
+		return tc.members, nil
+	}
+	var members []*trello.Member
+	if err := tc.Client.Get("boards/"+tc.BoardID+"/members", trello.Defaults(), &members); err != nil {
+		return nil, fmt.Errorf("failed to get board members: %w", trelloErr(err))
 	}
+	tc.members, tc.membersFetched = members, time.Now()
+	return members, nil
+}
+
+// InvalidateMembers drops the cached board members so the next lookup fetches them again.
+// Call it after adding or removing board members.
+func (tc *TrelloClient) InvalidateMembers() {
+	tc.membersMu.Lock()
+	defer tc.membersMu.Unlock()
+	tc.members = nil
+}
+
+// findMember returns the board member whose username or full name matches name, case-insensitively.
+// A cached member list without a match is refreshed once, in case the member joined the board since.
+func (tc *TrelloClient) findMember(name string) (*trello.Member, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		members, err := tc.boardMembers()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range members {
+			if strings.EqualFold(m.Username, name) || strings.EqualFold(m.FullName, name) {
+				return m, nil
+			}
+		}
+		tc.InvalidateMembers()
+	}
+	return nil, fmt.Errorf("member %s not found", name)
 }
 
 func (tc *TrelloClient) GetName() string {
@@ -52,13 +151,9 @@ func (tc *TrelloClient) GetURL() string {
 }
 
 func (tc *TrelloClient) GetMembers() ([]bc.Member, error) {
-	b, err := tc.Client.GetBoard(tc.BoardID, trello.Defaults())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get board: %w", err)
-	}
-	members, err := b.GetMembers(trello.Defaults())
+	members, err := tc.boardMembers()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get board members: %w", err)
+		return nil, err
 	}
 	var result []bc.Member
 	for _, m := range members {
@@ -73,11 +168,11 @@ func (tc *TrelloClient) GetMembers() ([]bc.Member, error) {
 func (tc *TrelloClient) GetLists() ([]bc.List, error) {
 	b, err := tc.Client.GetBoard(tc.BoardID, trello.Defaults())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get board: %w", err)
+		return nil, fmt.Errorf("failed to get board: %w", trelloErr(err))
 	}
 	lists, err := b.GetLists(trello.Defaults())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get lists: %w", err)
+		return nil, fmt.Errorf("failed to get lists: %w", trelloErr(err))
 	}
 	var result []bc.List
 	for _, l := range lists {
@@ -89,41 +184,154 @@ func (tc *TrelloClient) GetLists() ([]bc.List, error) {
 	return result, nil
 }
 
+// ExportBoard captures the board's lists, members and cards, including each card's list,
+// assignees, labels and comments. Comments are fetched per card, so large boards take a while.
+func (tc *TrelloClient) ExportBoard() (bc.BoardSnapshot, error) {
+	b, err := tc.Client.GetBoard(tc.BoardID, trello.Defaults())
+	if err != nil {
+		return bc.BoardSnapshot{}, fmt.Errorf("failed to get board: %w", trelloErr(err))
+	}
+	snapshot := bc.BoardSnapshot{Name: b.Name}
+
+	lists, err := b.GetLists(trello.Defaults())
+	if err != nil {
+		return bc.BoardSnapshot{}, fmt.Errorf("failed to get lists: %w", trelloErr(err))
+	}
+	listNames := make(map[string]string, len(lists))
+	for _, l := range lists {
+		listNames[l.ID] = l.Name
+		snapshot.Lists = append(snapshot.Lists, l.Name)
+	}
+
+	members, err := tc.boardMembers()
+	if err != nil {
+		return bc.BoardSnapshot{}, err
+	}
+	memberNames := make(map[string]string, len(members))
+	for _, m := range members {
+		memberNames[m.ID] = m.FullName
+		snapshot.Members = append(snapshot.Members, bc.Member{ID: m.ID, Name: m.FullName})
+	}
+
+	cards, err := b.GetCards(trello.Defaults())
+	if err != nil {
+		return bc.BoardSnapshot{}, fmt.Errorf("failed to get cards: %w", trelloErr(err))
+	}
+	for _, c := range cards {
+		card := bc.CardSnapshot{ID: c.ID, Name: c.Name, Description: c.Desc, List: listNames[c.IDList]}
+		for _, id := range c.IDMembers {
+			if name, ok := memberNames[id]; ok {
+				card.Assignees = append(card.Assignees, name)
+			}
+		}
+		for _, l := range c.Labels {
+			if l.Name != "" {
+				card.Labels = append(card.Labels, l.Name)
+			} else {
+				card.Labels = append(card.Labels, l.Color)
+			}
+		}
+		tcCard := &TrelloCard{ID: c.ID, CardName: c.Name, BoardClient: tc, Client: tc.Client}
+		if card.Comments, err = tcCard.ReadComments(); err != nil {
+			return bc.BoardSnapshot{}, fmt.Errorf("failed to export card %s: %w", c.Name, err)
+		}
+		snapshot.Cards = append(snapshot.Cards, card)
+	}
+	return snapshot, nil
+}
+
+// CreateList adds a new list at the end of the board.
+func (tc *TrelloClient) CreateList(name string) (bc.List, error) {
+	b, err := tc.Client.GetBoard(tc.BoardID, trello.Defaults())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", trelloErr(err))
+	}
+	l, err := b.CreateList(name, trello.Arguments{"pos": "bottom"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create list %s: %w", name, trelloErr(err))
+	}
+	return &TrelloList{ID: l.ID, Name: l.Name}, nil
+}
+
+// RenameList changes the name of a list.
+func (tc *TrelloClient) RenameList(listID, newName string) error {
+	if err := tc.updateList(listID, trello.Arguments{"name": newName}); err != nil {
+		return fmt.Errorf("failed to rename list %s: %w", listID, err)
+	}
+	return nil
+}
+
+// MoveList changes the position of a list on the board.
+func (tc *TrelloClient) MoveList(listID, pos string) error {
+	if err := tc.updateList(listID, trello.Arguments{"pos": pos}); err != nil {
+		return fmt.Errorf("failed to move list %s: %w", listID, err)
+	}
+	return nil
+}
+
+// updateList sends a PUT to the list with the given arguments.
+func (tc *TrelloClient) updateList(listID string, args trello.Arguments) error {
+	var l trello.List
+	return trelloErr(tc.Client.Put("lists/"+listID, args, &l))
+}
+
 // CreateCard creates a new card on the board given a name, description, and target list name.
 func (tc *TrelloClient) CreateCard(name, description, listName string) (bc.Card, error) {
+	return tc.CreateCardDetailed(bc.CardSpec{Name: name, Description: description, ListName: listName})
+}
+
+// CreateCardDetailed creates a card with members, labels, due date and position in a single
+// Trello call. Assignees and labels are resolved by name before the card is created.
+func (tc *TrelloClient) CreateCardDetailed(spec bc.CardSpec) (bc.Card, error) {
 	// Retrieve board lists.
 	lists, err := tc.GetLists()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get lists: %w", err)
+		return nil, fmt.Errorf("failed to get lists: %w", trelloErr(err))
 	}
 
 	var targetListID string
 	var targetList bc.List
 	for _, l := range lists {
-		if strings.EqualFold(l.GetName(), listName) {
+		if strings.EqualFold(l.GetName(), spec.ListName) {
 			targetListID = l.GetID()
 			targetList = l
 			break
 		}
 	}
 	if targetListID == "" {
-		return nil, fmt.Errorf("list %s not found", listName)
+		return nil, fmt.Errorf("list %s not found", spec.ListName)
 	}
 
 	newCard := trello.Card{
-		Name: name,
-		Desc: description,
+		Name: spec.Name,
+		Desc: spec.Description,
+		Due:  spec.DueDate,
+	}
+	if len(spec.Assignees) > 0 || len(spec.Labels) > 0 {
+		b, err := tc.Client.GetBoard(tc.BoardID, trello.Defaults())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get board: %w", trelloErr(err))
+		}
+		if newCard.IDMembers, err = tc.resolveMemberIDs(spec.Assignees); err != nil {
+			return nil, err
+		}
+		if newCard.IDLabels, err = resolveLabelIDs(b, spec.Labels); err != nil {
+			return nil, err
+		}
 	}
 	args := trello.Arguments{"idList": targetListID}
+	if spec.Position != "" {
+		args["pos"] = spec.Position
+	}
 	if err := tc.Client.CreateCard(&newCard, args); err != nil {
-		return nil, fmt.Errorf("failed to create card: %w", err)
+		return nil, fmt.Errorf("failed to create card: %w", trelloErr(err))
 	}
 
 	// Construct a concrete TrelloCard that implements bc.Card.
 	tcCard := &TrelloCard{
 		ID:          newCard.ID,
-		CardName:    name,
-		Description: description,
+		CardName:    spec.Name,
+		Description: spec.Description,
 		URL:         newCard.ShortURL,
 		List:        targetList,
 		BoardClient: tc,
@@ -132,45 +340,201 @@ func (tc *TrelloClient) CreateCard(name, description, listName string) (bc.Card,
 	return tcCard, nil
 }
 
+// resolveMemberIDs maps member usernames or full names to Trello member IDs.
+func (tc *TrelloClient) resolveMemberIDs(names []string) ([]string, error) {
+	var ids []string
+	for _, name := range names {
+		m, err := tc.findMember(name)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, m.ID)
+	}
+	return ids, nil
+}
+
+// resolveLabelIDs maps label names (or colors for unnamed labels) to Trello label IDs.
+func resolveLabelIDs(b *trello.Board, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	labels, err := b.GetLabels(trello.Defaults())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board labels: %w", trelloErr(err))
+	}
+	var ids []string
+	for _, name := range names {
+		var id string
+		for _, l := range labels {
+			if strings.EqualFold(l.Name, name) || (l.Name == "" && strings.EqualFold(l.Color, name)) {
+				id = l.ID
+				break
+			}
+		}
+		if id == "" {
+			return nil, fmt.Errorf("label %s not found", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
 func (tc *TrelloClient) GetCards() ([]bc.Card, error) {
+	_, cards, err := tc.boardCards()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]bc.Card, 0, len(cards))
+	for _, c := range cards {
+		result = append(result, c)
+	}
+	return result, nil
+}
+
+// boardCards fetches every card on the board, returning the Trello cards and, index for index,
+// TrelloCards with their list and clients set. Cards in a list that is not on the board have no list.
+func (tc *TrelloClient) boardCards() ([]*trello.Card, []*TrelloCard, error) {
 	b, err := tc.Client.GetBoard(tc.BoardID, trello.Defaults())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get board: %w", err)
+		return nil, nil, fmt.Errorf("failed to get board: %w", trelloErr(err))
+	}
+	lists, err := b.GetLists(trello.Defaults())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get lists: %w", trelloErr(err))
+	}
+	listsByID := make(map[string]*TrelloList, len(lists))
+	for _, l := range lists {
+		listsByID[l.ID] = &TrelloList{ID: l.ID, Name: l.Name}
 	}
 	cards, err := b.GetCards(trello.Defaults())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get cards: %w", err)
+		return nil, nil, fmt.Errorf("failed to get cards: %w", trelloErr(err))
 	}
-	var result []bc.Card
+	result := make([]*TrelloCard, 0, len(cards))
 	for _, c := range cards {
 		tcCard := &TrelloCard{
 			ID:          c.ID,
 			CardName:    c.Name,
 			Description: c.Desc,
 			URL:         c.ShortURL,
-			// List, BoardClient and Client should be set appropriately if needed.
+			BoardClient: tc,
+			Client:      tc.Client,
+		}
+		if l, ok := listsByID[c.IDList]; ok {
+			tcCard.List = l
 		}
 		result = append(result, tcCard)
 	}
-	return result, nil
+	return cards, result, nil
 }
 
-func (tc *TrelloClient) GetCardsAssignedTo(userName string) ([]bc.Card, error) {
-	allCards, err := tc.GetCards()
+// GetCard fetches a single card by ID, including the list it is in.
+func (tc *TrelloClient) GetCard(id string) (bc.Card, error) {
+	c, err := tc.Client.GetCard(id, trello.Defaults())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get card %s: %w", id, trelloErr(err))
+	}
+	if c.IDBoard != "" && c.IDBoard != tc.BoardID {
+		return nil, fmt.Errorf("card %s does not belong to board %s", id, tc.BoardID)
+	}
+	l, err := tc.Client.GetList(c.IDList, trello.Defaults())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list for card %s: %w", id, trelloErr(err))
+	}
+	return &TrelloCard{
+		ID:          c.ID,
+		CardName:    c.Name,
+		Description: c.Desc,
+		URL:         c.ShortURL,
+		List:        &TrelloList{ID: l.ID, Name: l.Name},
+		BoardClient: tc,
+		Client:      tc.Client,
+	}, nil
+}
+
+// cardActivityTypes are the board actions that mark a card as changed.
+const cardActivityTypes = "createCard,updateCard,commentCard,addMemberToCard,removeMemberFromCard,addAttachmentToCard"
+
+// actionsPageSize is the most board actions Trello returns per request.
+const actionsPageSize = 1000
+
+// GetCardsUpdatedSince returns the cards touched by board actions after since, each fetched once.
+// Cards that were deleted or moved to another board in the meantime are skipped.
+func (tc *TrelloClient) GetCardsUpdatedSince(since time.Time) ([]bc.Card, error) {
+	actions, err := tc.getActionsSince(since)
 	if err != nil {
 		return nil, err
 	}
+	seen := make(map[string]bool)
 	var result []bc.Card
-	for _, card := range allCards {
-		members, err := card.GetAssignedMembers()
-		if err != nil {
+	for _, a := range actions {
+		if a.Data == nil || a.Data.Card == nil || seen[a.Data.Card.ID] {
 			continue
 		}
-		for _, m := range members {
-			if strings.EqualFold(m.Name, userName) {
-				result = append(result, card)
-				break
-			}
+		seen[a.Data.Card.ID] = true
+		card, err := tc.GetCard(a.Data.Card.ID)
+		if errors.Is(err, apierr.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, card)
+	}
+	return result, nil
+}
+
+// getActionsSince returns every card action on the board after since. Trello returns actions newest
+// first, so older pages are requested with before set to the oldest action seen, until a page comes
+// back short.
+func (tc *TrelloClient) getActionsSince(since time.Time) ([]*trello.Action, error) {
+	var all []*trello.Action
+	before := ""
+	for {
+		args := trello.Arguments{
+			"filter": cardActivityTypes,
+			"since":  since.UTC().Format(time.RFC3339Nano),
+			"limit":  strconv.Itoa(actionsPageSize),
+		}
+		if before != "" {
+			args["before"] = before
+		}
+		var page []*trello.Action
+		if err := tc.Client.Get("boards/"+tc.BoardID+"/actions", args, &page); err != nil {
+			return nil, fmt.Errorf("failed to get board actions: %w", trelloErr(err))
+		}
+		all = append(all, page...)
+		if len(page) < actionsPageSize {
+			return all, nil
+		}
+		before = page[len(page)-1].ID
+	}
+}
+
+// GetCardsAssignedTo returns the cards assigned to the board member whose full name or username
+// matches userName. Assignees are read from the board's card listing, so no card is fetched on its own.
+func (tc *TrelloClient) GetCardsAssignedTo(userName string) ([]bc.Card, error) {
+	members, err := tc.boardMembers()
+	if err != nil {
+		return nil, err
+	}
+	memberIDs := make(map[string]bool)
+	for _, m := range members {
+		if strings.EqualFold(m.FullName, userName) || strings.EqualFold(m.Username, userName) {
+			memberIDs[m.ID] = true
+		}
+	}
+	if len(memberIDs) == 0 {
+		return nil, nil
+	}
+	cards, tcCards, err := tc.boardCards()
+	if err != nil {
+		return nil, err
+	}
+	var result []bc.Card
+	for i, c := range cards {
+		if slices.ContainsFunc(c.IDMembers, func(id string) bool { return memberIDs[id] }) {
+			result = append(result, tcCards[i])
 		}
 	}
 	return result, nil
@@ -234,11 +598,11 @@ func (tc *TrelloCard) GetName() string {
 func (tc *TrelloCard) ChangeName(newName string) error {
 	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
 	if err != nil {
-		return fmt.Errorf("failed to get card: %w", err)
+		return fmt.Errorf("failed to get card: %w", trelloErr(err))
 	}
 	args := trello.Arguments{"name": newName}
 	if err := tCard.Update(args); err != nil {
-		return err
+		return trelloErr(err)
 	}
 	tc.CardName = newName
 	return nil
@@ -272,22 +636,33 @@ func (tc *TrelloCard) Move(newListName string) error {
 	}
 	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
 	if err != nil {
-		return fmt.Errorf("failed to get card: %w", err)
+		return fmt.Errorf("failed to get card: %w", trelloErr(err))
 	}
 	args := trello.Arguments{"idList": targetID}
-	return tCard.Update(args)
+	return trelloErr(tCard.Update(args))
 }
 
 func (tc *TrelloCard) GetAssignedMembers() ([]bc.Member, error) {
 	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get card: %w", err)
+		return nil, fmt.Errorf("failed to get card: %w", trelloErr(err))
+	}
+	boardMembers, err := tc.BoardClient.boardMembers()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*trello.Member, len(boardMembers))
+	for _, m := range boardMembers {
+		byID[m.ID] = m
 	}
 	var members []bc.Member
 	for _, mID := range tCard.IDMembers {
-		member, err := tc.Client.GetMember(mID, trello.Defaults())
-		if err != nil {
-			continue
+		member, ok := byID[mID]
+		if !ok {
+			// Not (or no longer) a board member; look it up directly.
+			if member, err = tc.Client.GetMember(mID, trello.Defaults()); err != nil {
+				continue
+			}
 		}
 		members = append(members, bc.Member{
 			ID:   member.ID,
@@ -298,56 +673,30 @@ func (tc *TrelloCard) GetAssignedMembers() ([]bc.Member, error) {
 }
 
 func (tc *TrelloCard) AssignTo(userName string) error {
-	b, err := tc.Client.GetBoard(tc.BoardClient.BoardID, trello.Defaults())
+	member, err := tc.BoardClient.findMember(userName)
 	if err != nil {
-		return fmt.Errorf("failed to get board: %w", err)
-	}
-	members, err := b.GetMembers(trello.Defaults())
-	if err != nil {
-		return fmt.Errorf("failed to get board members: %w", err)
-	}
-	var targetID string
-	for _, m := range members {
-		if strings.EqualFold(m.Username, userName) || strings.EqualFold(m.FullName, userName) {
-			targetID = m.ID
-			break
-		}
-	}
-	if targetID == "" {
-		return fmt.Errorf("member %s not found", userName)
+		return err
 	}
+	targetID := member.ID
 	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
 	if err != nil {
-		return fmt.Errorf("failed to get card: %w", err)
+		return fmt.Errorf("failed to get card: %w", trelloErr(err))
 	}
 	args := trello.Arguments{"idMembers": targetID}
-	return tCard.Update(args)
+	return trelloErr(tCard.Update(args))
 }
 
 func (tc *TrelloCard) UnassignFrom(userName string) error {
 	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
 	if err != nil {
-		return fmt.Errorf("failed to get card: %w", err)
+		return fmt.Errorf("failed to get card: %w", trelloErr(err))
 	}
 	current := tCard.IDMembers
-	b, err := tc.Client.GetBoard(tc.BoardClient.BoardID, trello.Defaults())
-	if err != nil {
-		return fmt.Errorf("failed to get board: %w", err)
-	}
-	members, err := b.GetMembers(trello.Defaults())
+	member, err := tc.BoardClient.findMember(userName)
 	if err != nil {
-		return fmt.Errorf("failed to get members: %w", err)
-	}
-	var targetID string
-	for _, m := range members {
-		if strings.EqualFold(m.Username, userName) || strings.EqualFold(m.FullName, userName) {
-			targetID = m.ID
-			break
-		}
-	}
-	if targetID == "" {
-		return fmt.Errorf("member %s not found", userName)
+		return err
 	}
+	targetID := member.ID
 	var newMembers []string
 	for _, id := range current {
 		if id != targetID {
@@ -355,48 +704,83 @@ func (tc *TrelloCard) UnassignFrom(userName string) error {
 		}
 	}
 	args := trello.Arguments{"idMembers": strings.Join(newMembers, ",")}
-	return tCard.Update(args)
+	return trelloErr(tCard.Update(args))
 }
 
+// commentPageSize is how many comment actions are requested per page; Trello caps it at 1000.
+const commentPageSize = 1000
+
+// ReadComments returns every comment on the card, oldest first.
 func (tc *TrelloCard) ReadComments() ([]bc.Comment, error) {
-	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
-	if err != nil {
-		return nil, fmt.Errorf("failed to get card: %w", err)
-	}
-	actions, err := tCard.GetActions(map[string]string{"filter": "commentCard"})
-	if err != nil {
-		return nil, fmt.Errorf("failed to get comments: %w", err)
+	return tc.readComments(time.Time{})
+}
+
+// ReadCommentsSince returns the comments posted after since, oldest first.
+func (tc *TrelloCard) ReadCommentsSince(since time.Time) ([]bc.Comment, error) {
+	return tc.readComments(since)
+}
+
+// readComments pages through the card's comment actions with "before" until a short page,
+// so long-lived cards do not lose their older comments. A zero since fetches all of them.
+func (tc *TrelloCard) readComments(since time.Time) ([]bc.Comment, error) {
+	var actions []*trello.Action
+	before := ""
+	for {
+		args := trello.Arguments{"filter": "commentCard", "limit": strconv.Itoa(commentPageSize)}
+		if !since.IsZero() {
+			args["since"] = since.UTC().Format(time.RFC3339Nano)
+		}
+		if before != "" {
+			args["before"] = before
+		}
+		var page []*trello.Action
+		if err := tc.Client.Get("cards/"+tc.ID+"/actions", args, &page); err != nil {
+			return nil, fmt.Errorf("failed to get comments: %w", trelloErr(err))
+		}
+		actions = append(actions, page...)
+		if len(page) < commentPageSize {
+			break
+		}
+		before = page[len(page)-1].ID
 	}
+
+	// Trello returns the newest action first.
 	var comments []bc.Comment
-	for _, a := range actions {
-		// Use a.Data.Text instead of indexing a.Data.
-		text := a.Data.Text
-		if text == "" {
+	for i := len(actions) - 1; i >= 0; i-- {
+		a := actions[i]
+		if a.Data == nil || a.Data.Text == "" {
 			continue
 		}
-		comments = append(comments, bc.Comment{
-			Text: text,
-		})
+		comment := bc.Comment{Text: a.Data.Text}
+		if a.MemberCreator != nil {
+			comment.Member = &bc.Member{ID: a.MemberCreator.ID, Name: a.MemberCreator.FullName}
+		}
+		comments = append(comments, comment)
 	}
 	return comments, nil
 }
 
 func (tc *TrelloCard) WriteComment(comment string) error {
-	endpoint := fmt.Sprintf("https://api.trello.com/1/cards/%s/actions/comments", tc.ID)
+	endpoint := fmt.Sprintf("%s/cards/%s/actions/comments", tc.Client.BaseURL, tc.ID)
 	values := url.Values{}
 	values.Set("text", comment)
 	values.Set("key", tc.BoardClient.APIKey)
 	values.Set("token", tc.BoardClient.Token)
 
-	resp, err := http.PostForm(endpoint, values)
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(values.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create comment request: %w", trelloErr(err))
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := httputil.Do(req.Context(), tc.Client.Client, req, tc.BoardClient.Retry)
 	if err != nil {
-		return fmt.Errorf("failed to post comment: %w", err)
+		return fmt.Errorf("failed to post comment: %w", trelloErr(err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("failed to post comment, status: %d, response: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to post comment: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
 	}
 	return nil
 }
@@ -404,11 +788,11 @@ func (tc *TrelloCard) WriteComment(comment string) error {
 func (tc *TrelloCard) GetAttachments() ([]bc.Attachment, error) {
 	tCard, err := tc.Client.GetCard(tc.ID, trello.Defaults())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get card: %w", err)
+		return nil, fmt.Errorf("failed to get card: %w", trelloErr(err))
 	}
 	atts, err := tCard.GetAttachments(trello.Defaults())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get attachments: %w", err)
+		return nil, fmt.Errorf("failed to get attachments: %w", trelloErr(err))
 	}
 	var result []bc.Attachment
 	for _, a := range atts {
@@ -421,18 +805,189 @@ func (tc *TrelloCard) GetAttachments() ([]bc.Attachment, error) {
 	return result, nil
 }
 
+// AddAttachment attaches a link to the card. The URL must be absolute; the name is limited to
+// MaxAttachmentNameLength characters of the board client, and a MIME type is derived from the URL's extension.
 func (tc *TrelloCard) AddAttachment(attachment bc.Attachment) error {
-	endpoint := fmt.Sprintf("https://api.trello.com/1/cards/%s/attachments", tc.ID)
-	query := fmt.Sprintf("url=%s&name=%s&key=%s&token=%s",
-		attachment.URL, attachment.Name, tc.BoardClient.APIKey, tc.BoardClient.Token)
-	url := endpoint + "?" + query
-	resp, err := http.DefaultClient.Get(url)
+	if err := tc.BoardClient.validateAttachment(attachment); err != nil {
+		return err
+	}
+	values := url.Values{}
+	values.Set("url", attachment.URL)
+	if attachment.Name != "" {
+		values.Set("name", attachment.Name)
+	}
+	if mimeType := attachmentMIMEType(attachment.URL); mimeType != "" {
+		values.Set("mimeType", mimeType)
+	}
+	values.Set("key", tc.BoardClient.APIKey)
+	values.Set("token", tc.BoardClient.Token)
+
+	endpoint := fmt.Sprintf("%s/cards/%s/attachments?%s", tc.Client.BaseURL, url.PathEscape(tc.ID), values.Encode())
+	req, err := http.NewRequest("POST", endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment request: %w", err)
+	}
+	resp, err := httputil.Do(req.Context(), tc.Client.Client, req, tc.BoardClient.Retry)
+	if err != nil {
+		return fmt.Errorf("failed to add attachment: %w", trelloErr(err))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to add attachment: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
+	}
+	return nil
+}
+
+// validateAttachment checks that an attachment has an absolute URL and a name within MaxAttachmentNameLength.
+func (tc *TrelloClient) validateAttachment(attachment bc.Attachment) error {
+	if strings.TrimSpace(attachment.URL) == "" {
+		return fmt.Errorf("%w: missing URL", ErrInvalidAttachment)
+	}
+	u, err := url.Parse(attachment.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("%w: %q is not an absolute URL", ErrInvalidAttachment, attachment.URL)
+	}
+	if tc.MaxAttachmentNameLength > 0 && utf8.RuneCountInString(attachment.Name) > tc.MaxAttachmentNameLength {
+		return fmt.Errorf("%w: name longer than %d characters", ErrInvalidAttachment, tc.MaxAttachmentNameLength)
+	}
+	return nil
+}
+
+// attachmentMIMEType returns the MIME type registered for the extension of the URL's path, or "" if unknown.
+func attachmentMIMEType(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return mime.TypeByExtension(strings.ToLower(path.Ext(u.Path)))
+}
+
+// getBoardCustomFields returns the custom field definitions of the card's board.
+func (tc *TrelloCard) getBoardCustomFields() ([]*trello.CustomField, error) {
+	var fields []*trello.CustomField
+	path := fmt.Sprintf("boards/%s/customFields", tc.BoardClient.BoardID)
+	if err := tc.Client.Get(path, trello.Defaults(), &fields); err != nil {
+		return nil, fmt.Errorf("failed to get board custom fields: %w", trelloErr(err))
+	}
+	return fields, nil
+}
+
+// GetCustomFields returns the card's custom field values keyed by field name.
+// List (dropdown) fields are resolved from option IDs to their labels.
+func (tc *TrelloCard) GetCustomFields() (map[string]string, error) {
+	fields, err := tc.getBoardCustomFields()
+	if err != nil {
+		return nil, err
+	}
+	var items []struct {
+		IDCustomField string            `json:"idCustomField"`
+		IDValue       string            `json:"idValue"`
+		Value         map[string]string `json:"value"`
+	}
+	path := fmt.Sprintf("cards/%s/customFieldItems", tc.ID)
+	if err := tc.Client.Get(path, trello.Defaults(), &items); err != nil {
+		return nil, fmt.Errorf("failed to get card custom field items: %w", trelloErr(err))
+	}
+
+	byID := make(map[string]*trello.CustomField, len(fields))
+	for _, f := range fields {
+		byID[f.ID] = f
+	}
+	result := make(map[string]string)
+	for _, item := range items {
+		field, ok := byID[item.IDCustomField]
+		if !ok {
+			continue
+		}
+		if field.Type == "list" {
+			for _, opt := range field.Options {
+				if opt.ID == item.IDValue {
+					result[field.Name] = opt.Value.Text
+					break
+				}
+			}
+			continue
+		}
+		// Non-list values come keyed by type: text, number, date or checked.
+		for _, v := range item.Value {
+			result[field.Name] = v
+		}
+	}
+	return result, nil
+}
+
+// SetCustomField sets the custom field with the given name on the card.
+// For list fields the value must match one of the option labels. An empty value clears the field.
+func (tc *TrelloCard) SetCustomField(name, value string) error {
+	fields, err := tc.getBoardCustomFields()
+	if err != nil {
+		return err
+	}
+	var field *trello.CustomField
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, name) {
+			field = f
+			break
+		}
+	}
+	if field == nil {
+		return fmt.Errorf("custom field %s not found", name)
+	}
+
+	var payload map[string]interface{}
+	switch {
+	case value == "" && field.Type == "list":
+		payload = map[string]interface{}{"idValue": ""}
+	case value == "":
+		payload = map[string]interface{}{"value": ""}
+	case field.Type == "list":
+		optionID := ""
+		for _, opt := range field.Options {
+			if strings.EqualFold(opt.Value.Text, value) {
+				optionID = opt.ID
+				break
+			}
+		}
+		if optionID == "" {
+			return fmt.Errorf("option %s not found for custom field %s", value, name)
+		}
+		payload = map[string]interface{}{"idValue": optionID}
+	case field.Type == "checkbox":
+		payload = map[string]interface{}{"value": map[string]string{"checked": value}}
+	case field.Type == "number" || field.Type == "date":
+		payload = map[string]interface{}{"value": map[string]string{field.Type: value}}
+	default:
+		payload = map[string]interface{}{"value": map[string]string{"text": value}}
+	}
+
+	body, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to add attachment: %w", err)
+		return fmt.Errorf("failed to marshal custom field payload: %w", trelloErr(err))
+	}
+	endpoint := fmt.Sprintf("%s/cards/%s/customField/%s/item?key=%s&token=%s",
+		tc.Client.BaseURL, tc.ID, field.ID, url.QueryEscape(tc.BoardClient.APIKey), url.QueryEscape(tc.BoardClient.Token))
+	req, err := http.NewRequest("PUT", endpoint, bytes.NewBuffer(body))
+	if err != nil {
+		return fmt.Errorf("failed to create custom field request: %w", trelloErr(err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httputil.Do(req.Context(), tc.Client.Client, req, tc.BoardClient.Retry)
+	if err != nil {
+		return fmt.Errorf("failed to set custom field: %w", trelloErr(err))
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to add attachment, status: %d", resp.StatusCode)
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to set custom field: %w", apierr.NewStatusError(resp.StatusCode, string(respBody)))
+	}
+	return nil
+}
+
+// Ping checks the credentials and board ID by fetching the board.
+func (tc *TrelloClient) Ping(ctx context.Context) error {
+	if _, err := tc.Client.WithContext(ctx).GetBoard(tc.BoardID, trello.Defaults()); err != nil {
+		return fmt.Errorf("Trello health check failed: %w", trelloErr(err))
 	}
 	return nil
 }
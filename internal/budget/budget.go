@@ -0,0 +1,78 @@
+package budget
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ErrBudgetExceeded is returned once the cumulative estimated spend reaches the configured ceiling.
+var ErrBudgetExceeded = fmt.Errorf("budget exceeded")
+
+// UploadRateKey is the rate key used to price file uploads, estimated from the uploaded size.
+const UploadRateKey = "file_upload"
+
+// BudgetGuard tracks the cumulative estimated cost of API calls and refuses new calls past a ceiling.
+// A single guard can be shared between several clients so they draw from the same budget.
+type BudgetGuard struct {
+	mu      sync.Mutex
+	ceiling float64            // maximum spend in dollars; zero or less disables the guard
+	rates   map[string]float64 // dollars per 1K tokens, keyed by model name (or UploadRateKey)
+	spent   float64
+}
+
+// NewBudgetGuard creates a BudgetGuard with the given ceiling (in dollars) and per-model $/1K token rates.
+func NewBudgetGuard(ceiling float64, rates map[string]float64) *BudgetGuard {
+	copied := make(map[string]float64, len(rates))
+	for k, v := range rates {
+		copied[k] = v
+	}
+	return &BudgetGuard{
+		ceiling: ceiling,
+		rates:   copied,
+	}
+}
+
+// Check returns ErrBudgetExceeded if the ceiling has already been reached.
+// Clients call it before issuing a billable request.
+func (g *BudgetGuard) Check() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.checkLocked()
+}
+
+// Record adds the estimated cost of the given number of tokens for a model.
+// Models without a configured rate are counted as free.
+func (g *BudgetGuard) Record(model string, tokens int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.spent += g.rates[model] * float64(tokens) / 1000
+}
+
+// Spent returns the cumulative estimated spend in dollars.
+func (g *BudgetGuard) Spent() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.spent
+}
+
+// Remaining returns how many dollars may still be spent before the guard trips.
+func (g *BudgetGuard) Remaining() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.ceiling-g.spent < 0 {
+		return 0
+	}
+	return g.ceiling - g.spent
+}
+
+func (g *BudgetGuard) checkLocked() error {
+	if g.ceiling > 0 && g.spent >= g.ceiling {
+		return fmt.Errorf("spent $%.4f of $%.4f: %w", g.spent, g.ceiling, ErrBudgetExceeded)
+	}
+	return nil
+}
+
+// EstimateTokens roughly converts a byte count into tokens (about four bytes per token).
+func EstimateTokens(byteCount int) int {
+	return (byteCount + 3) / 4
+}
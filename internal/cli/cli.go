@@ -0,0 +1,52 @@
+// Package cli dispatches the subcommands of the aiagent binary.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrUnknownCommand is returned by Dispatch when no command matches the requested name.
+var ErrUnknownCommand = errors.New("unknown command")
+
+// Command is a named subcommand. Run receives the arguments following the command name.
+type Command struct {
+	Name    string
+	Summary string
+	Run     func(args []string) error
+}
+
+// Dispatch runs the command named by args[0] with the remaining arguments.
+// When args is empty or starts with a flag, the command named defaultName runs with all of args,
+// so invocations that predate subcommands keep working. "help", "-h" and "--help" print the
+// usage to out; an unknown name prints the usage and returns ErrUnknownCommand.
+func Dispatch(commands []Command, defaultName string, args []string, out io.Writer) error {
+	name := defaultName
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	} else if len(args) > 0 && (args[0] == "-h" || args[0] == "--help") {
+		name = "help"
+	}
+	if name == "help" {
+		Usage(commands, out)
+		return nil
+	}
+	for _, cmd := range commands {
+		if cmd.Name == name {
+			return cmd.Run(args)
+		}
+	}
+	Usage(commands, out)
+	return fmt.Errorf("%w %q", ErrUnknownCommand, name)
+}
+
+// Usage writes the list of commands and their summaries to out.
+func Usage(commands []Command, out io.Writer) {
+	fmt.Fprintln(out, "Usage: aiagent <command> [flags]")
+	fmt.Fprintln(out, "\nCommands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(out, "  %-14s %s\n", cmd.Name, cmd.Summary)
+	}
+}
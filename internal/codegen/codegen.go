@@ -0,0 +1,144 @@
+// Package codegen parses model responses that contain generated files and writes them to a repository.
+//
+// A response lists files using path markers. Each file starts with a line holding the marker
+// followed by the file's path relative to the repository root; the file's content is every
+// following line up to the next marker or the end of the response:
+//
+//	!!path!! internal/foo/foo.go
+//	package foo
+//	!!path!! README.md
+//	# Foo
+//
+// Text before the first marker is ignored. A content block wrapped in a Markdown code fence
+// has the fence removed.
+package codegen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// PathMarker starts the line that introduces a generated file.
+const PathMarker = "!!path!!"
+
+var (
+	// ErrNoFiles is returned when a response contains no path markers.
+	ErrNoFiles = errors.New("no generated files found in response")
+	// ErrMalformedMarker is returned when a path marker is empty, duplicated or escapes the repository.
+	ErrMalformedMarker = errors.New("malformed path marker")
+)
+
+// GeneratedFile is a single file extracted from a model response.
+type GeneratedFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	IsTest  bool   `json:"isTest"` // Whether the file holds tests rather than implementation
+}
+
+// SplitTests separates implementation files from test files, keeping their order.
+func SplitTests(files []GeneratedFile) (impl, tests []GeneratedFile) {
+	for _, f := range files {
+		if f.IsTest {
+			tests = append(tests, f)
+		} else {
+			impl = append(impl, f)
+		}
+	}
+	return impl, tests
+}
+
+// ParseGeneratedFiles extracts the files introduced by PathMarker lines in response.
+func ParseGeneratedFiles(response string) ([]GeneratedFile, error) {
+	var files []GeneratedFile
+	seen := make(map[string]bool)
+	var current *GeneratedFile
+	var body []string
+
+	flush := func() {
+		if current != nil {
+			current.Content = stripFence(body)
+			files = append(files, *current)
+		}
+	}
+
+	for i, line := range strings.Split(response, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, PathMarker) {
+			if current != nil {
+				body = append(body, strings.TrimSuffix(line, "\r"))
+			}
+			continue
+		}
+		p, err := cleanPath(strings.TrimSpace(strings.TrimPrefix(trimmed, PathMarker)))
+		if err != nil {
+			return nil, fmt.Errorf("%w on line %d: %w", ErrMalformedMarker, i+1, err)
+		}
+		if seen[p] {
+			return nil, fmt.Errorf("%w on line %d: duplicate path %q", ErrMalformedMarker, i+1, p)
+		}
+		seen[p] = true
+		flush()
+		current = &GeneratedFile{Path: p}
+		body = nil
+	}
+	flush()
+
+	if len(files) == 0 {
+		return nil, ErrNoFiles
+	}
+	return files, nil
+}
+
+// WriteFiles writes files into the repository managed by gc, creating parent directories as needed.
+func WriteFiles(gc *gitrepo.GitClient, files []GeneratedFile) error {
+	for _, f := range files {
+		p, err := cleanPath(f.Path)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrMalformedMarker, err)
+		}
+		if err := os.MkdirAll(filepath.Join(gc.RepoPath, filepath.Dir(filepath.FromSlash(p))), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", p, err)
+		}
+		if err := gc.WriteFile(filepath.FromSlash(p), []byte(f.Content)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// cleanPath normalizes a marker path and rejects paths that are empty or leave the repository;
+// the latter wrap gitrepo.ErrPathOutsideRepo.
+func cleanPath(p string) (string, error) {
+	p = strings.Trim(p, "`\"'")
+	if p == "" {
+		return "", errors.New("empty path")
+	}
+	p = path.Clean(filepath.ToSlash(p))
+	if path.IsAbs(p) || filepath.IsAbs(p) || p == ".." || strings.HasPrefix(p, "../") {
+		return "", fmt.Errorf("path %q: %w", p, gitrepo.ErrPathOutsideRepo)
+	}
+	return p, nil
+}
+
+// stripFence joins body lines, trimming surrounding blank lines and a wrapping Markdown code fence.
+func stripFence(body []string) string {
+	for len(body) > 0 && strings.TrimSpace(body[0]) == "" {
+		body = body[1:]
+	}
+	for len(body) > 0 && strings.TrimSpace(body[len(body)-1]) == "" {
+		body = body[:len(body)-1]
+	}
+	if len(body) >= 2 && strings.HasPrefix(strings.TrimSpace(body[0]), "```") && strings.TrimSpace(body[len(body)-1]) == "```" {
+		body = body[1 : len(body)-1]
+	}
+	if len(body) == 0 {
+		return ""
+	}
+	return strings.Join(body, "\n") + "\n"
+}
@@ -1,34 +1,88 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
 
 // Config represents the entire YAML configuration.
 type Config struct {
-	Roles map[string]struct {
-		Name          string `yaml:"name" json:"name"`
-		Prompt        string `yaml:"prompt" json:"prompt"`
-		DefaultAction string `yaml:"defaultAction" json:"defaultAction"`
-		Actions       []struct {
-			ID     string `yaml:"id" json:"id"`
-			Name   string `yaml:"name" json:"name"`
-			Mode   string `yaml:"mode" json:"mode"`
-			Prompt string `yaml:"prompt,omitempty" json:"prompt,omitempty"`
-		} `yaml:"actions" json:"actions"`
-	} `yaml:"roles" json:"roles"`
+	// ProjectGoal describes the project the agents work on; it opens every system prompt.
+	// Empty means DefaultProjectGoal.
+	ProjectGoal string `yaml:"projectGoal" json:"projectGoal"`
+
+	Roles map[string]Role `yaml:"roles" json:"roles"`
 
 	GlobalModes map[string]string `yaml:"globalModes" json:"globalModes"`
 
+	// ModeTemperatures sets the sampling temperature per mode, e.g. Summarize: 0.3.
+	// Modes missing from the map use the model client's temperature.
+	ModeTemperatures map[string]float64 `yaml:"modeTemperatures" json:"modeTemperatures"`
+
+	// ModeModels sets the model per mode, e.g. Summarize: gpt-4o-mini, GenerateCode: gpt-4o.
+	// Modes missing from the map use the model client's model.
+	ModeModels map[string]string `yaml:"modeModels" json:"modeModels"`
+
 	Workflow struct {
 		HighLevelTask string `yaml:"highLevelTask" json:"highLevelTask"`
 		Steps         []Step `yaml:"steps" json:"steps"`
 	} `yaml:"workflow" json:"workflow"`
 
+	Context struct {
+		MaxTokens        int `yaml:"maxTokens" json:"maxTokens"`               // Hot-context budget before it is summarized; 0 uses the default
+		MaxMemories      int `yaml:"maxMemories" json:"maxMemories"`           // Old memories merged into the hot context per update; 0 uses the default
+		MaxLowImportance int `yaml:"maxLowImportance" json:"maxLowImportance"` // Low-importance memories among them; 0 uses the default, negative means no cap
+	} `yaml:"context" json:"context"`
+
+	RateLimit struct {
+		RequestsPerMinute int `yaml:"requestsPerMinute" json:"requestsPerMinute"` // Combined cap on OpenAI requests; 0 uses the default
+		Burst             int `yaml:"burst" json:"burst"`                         // Requests allowed back to back before throttling; 0 uses the default
+	} `yaml:"rateLimit" json:"rateLimit"`
+
+	ResponseCache struct {
+		Entries int    `yaml:"entries" json:"entries"` // In-memory LRU size; 0 disables the cache unless dir is set
+		Dir     string `yaml:"dir" json:"dir"`         // When set, responses are cached on disk in this directory
+	} `yaml:"responseCache" json:"responseCache"`
+
+	Budget struct {
+		Ceiling float64            `yaml:"ceiling" json:"ceiling"` // Maximum estimated OpenAI spend in dollars; 0 disables the guard
+		Rates   map[string]float64 `yaml:"rates" json:"rates"`     // Dollars per 1K tokens by model name, added to the built-in rates
+	} `yaml:"budget" json:"budget"`
+
+	Board struct {
+		// TerminalLists names the lists that mean a ticket is finished, in order of preference, matched
+		// case-insensitively. The columns of close_ticket workflow steps and DefaultTerminalLists follow them.
+		TerminalLists []string `yaml:"terminalLists" json:"terminalLists"`
+	} `yaml:"board" json:"board"`
+
+	HTTP struct {
+		Proxy  string `yaml:"proxy" json:"proxy"`   // Proxy URL for outbound requests; empty uses HTTP_PROXY/HTTPS_PROXY
+		CAFile string `yaml:"caFile" json:"caFile"` // PEM file of extra trusted certificate authorities
+	} `yaml:"http" json:"http"`
+
 	WorkflowControl struct {
 		CurrentStep string   `yaml:"currentStep" json:"currentStep"`
 		StepsOrder  []string `yaml:"stepsOrder" json:"stepsOrder"`
 	} `yaml:"workflowControl" json:"workflowControl"`
 }
 
+// Role describes an agent role: its instruction and the actions (modes) it performs.
+type Role struct {
+	Name          string       `yaml:"name" json:"name"`
+	Prompt        string       `yaml:"prompt" json:"prompt"`
+	DefaultAction string       `yaml:"defaultAction" json:"defaultAction"`
+	Actions       []RoleAction `yaml:"actions" json:"actions"`
+}
+
+// RoleAction is an action of a role; a non-empty Prompt overrides the global prompt of its mode.
+type RoleAction struct {
+	ID     string `yaml:"id" json:"id"`
+	Name   string `yaml:"name" json:"name"`
+	Mode   string `yaml:"mode" json:"mode"`
+	Prompt string `yaml:"prompt,omitempty" json:"prompt,omitempty"`
+}
+
 // Step represents an individual step in the workflow.
 type Step struct {
 	ID          string      `yaml:"id" json:"id"`
@@ -36,6 +90,7 @@ type Step struct {
 	Actor       string      `yaml:"actor" json:"actor"`
 	Action      string      `yaml:"action" json:"action"`
 	Description string      `yaml:"description" json:"description"`
+	Column      string      `yaml:"column,omitempty" json:"column,omitempty"` // Board list the ticket sits in during this step; defaults to Name
 	Next        interface{} `yaml:"next,omitempty" json:"next,omitempty"`
 	Options     interface{} `yaml:"options,omitempty" json:"options,omitempty"` // New field for decision branches
 }
@@ -47,6 +102,7 @@ type ConfigProvider interface {
 
 // Global references
 var (
+	mu           sync.RWMutex // guards provider and loadedConfig
 	provider     ConfigProvider
 	loadedConfig *Config
 	ErrNotLoaded = fmt.Errorf("configuration not loaded")
@@ -54,55 +110,113 @@ var (
 
 // SetProvider sets the configuration provider.
 func SetProvider(p ConfigProvider) {
+	mu.Lock()
+	defer mu.Unlock()
 	provider = p
 }
 
 // Load uses the current provider to load configuration from the given path.
+// The configuration is read without holding the lock and then replaces the loaded one at once.
 func Load(path string) error {
-	if provider == nil {
+	mu.RLock()
+	p := provider
+	mu.RUnlock()
+	if p == nil {
 		return fmt.Errorf("no config provider set")
 	}
-	cfg, err := provider.LoadConfig(path)
+	cfg, err := p.LoadConfig(path)
 	if err != nil {
 		return err
 	}
+	mu.Lock()
 	loadedConfig = cfg
+	mu.Unlock()
 	return nil
 }
 
+// GetLoadedConfig returns the configuration loaded last, or nil. Load replaces the configuration rather than
+// changing it, so callers may keep and read the returned value but must not modify it.
 func GetLoadedConfig() *Config {
+	mu.RLock()
+	defer mu.RUnlock()
 	return loadedConfig
 }
 
-func GetRoleInstruction(role string) (string, error) {
-	if loadedConfig == nil {
-		return "", ErrNotLoaded
+// DefaultProjectGoal is used when the configuration sets no projectGoal.
+const DefaultProjectGoal = "Project: A software project developed by an agile team."
+
+// GetProjectGoal returns the configured project goal, or DefaultProjectGoal when
+// no configuration is loaded or it sets none.
+func GetProjectGoal() string {
+	cfg := GetLoadedConfig()
+	if cfg == nil || cfg.ProjectGoal == "" {
+		return DefaultProjectGoal
 	}
-	r, ok := loadedConfig.Roles[role]
-	if !ok {
-		return "", fmt.Errorf("role %q not found", role)
+	return cfg.ProjectGoal
+}
+
+// GetModeTemperature returns the temperature configured for mode, or fallback when
+// no configuration is loaded or the mode has no entry.
+func GetModeTemperature(mode string, fallback float64) float64 {
+	cfg := GetLoadedConfig()
+	if cfg == nil {
+		return fallback
 	}
-	return r.Prompt, nil
+	if t, ok := cfg.ModeTemperatures[mode]; ok {
+		return t
+	}
+	return fallback
 }
 
-// GetRoleMode returns the prompt for a given role and mode.
-// It checks the role-specific modes first, then falls back to globalModes.
-func GetRoleMode(role, mode string) (string, error) {
-	if loadedConfig == nil {
-		return "", ErrNotLoaded
+// GetModeModel returns the model configured for mode, or fallback when
+// no configuration is loaded or the mode has no entry.
+func GetModeModel(mode, fallback string) string {
+	cfg := GetLoadedConfig()
+	if cfg == nil {
+		return fallback
+	}
+	if m := cfg.ModeModels[mode]; m != "" {
+		return m
 	}
-	if roleData, found := loadedConfig.Roles[role]; found {
-		for _, act := range roleData.Actions {
-			if act.Mode == mode {
-				if act.Prompt != "" {
-					return act.Prompt, nil
-				}
-				break
+	return fallback
+}
+
+// CloseTicketAction is the workflow action of the step that closes a ticket.
+const CloseTicketAction = "close_ticket"
+
+// DefaultTerminalLists are the list names tried last when looking for the list of finished tickets.
+var DefaultTerminalLists = []string{"Done"}
+
+// GetTerminalLists returns the names of the lists that hold finished tickets, in order of preference:
+// the configured board.terminalLists, then the column (or name) of every close_ticket workflow step,
+// then DefaultTerminalLists. Names are returned once, compared case-insensitively.
+func GetTerminalLists() []string {
+	var candidates []string
+	if cfg := GetLoadedConfig(); cfg != nil {
+		candidates = append(candidates, cfg.Board.TerminalLists...)
+		for _, step := range cfg.Workflow.Steps {
+			if !strings.EqualFold(step.Action, CloseTicketAction) {
+				continue
+			}
+			if step.Column != "" {
+				candidates = append(candidates, step.Column)
+			} else if step.Name != "" {
+				candidates = append(candidates, step.Name)
 			}
 		}
 	}
-	if prompt, ok := loadedConfig.GlobalModes[mode]; ok {
-		return prompt, nil
+	candidates = append(candidates, DefaultTerminalLists...)
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range candidates {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
 	}
-	return "", fmt.Errorf("mode %q not found for role %q and no global mode available", mode, role)
+	return names
 }
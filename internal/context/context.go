@@ -1,6 +1,26 @@
 package context
 
-import "time"
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+// Importance bounds of a memory. DefaultImportance is used when the importance is unset (zero).
+const (
+	MinImportance     = 1
+	MaxImportance     = 10
+	DefaultImportance = 5
+)
+
+// DefaultCategory is given to memories without a category.
+const DefaultCategory = "General"
+
+// Categories are the known memory categories, in their canonical spelling.
+var Categories = []string{
+	"Architecture", "Requirements", "Decisions", "Performance", "Security",
+	"Testing", "Ops", "Docs", "Process", DefaultCategory,
+}
 
 // MemoryEntry represents a unit of knowledge.
 type MemoryEntry struct {
@@ -8,15 +28,45 @@ type MemoryEntry struct {
 	Category   string    `json:"category"`             // e.g. "Architecture", "Performance", etc.
 	Content    string    `json:"content"`              // The actual knowledge detail or summary.
 	Timestamp  time.Time `json:"timestamp"`            // When this entry was added.
-	Importance int       `json:"importance,omitempty"` // Relative importance score.
+	Importance int       `json:"importance,omitempty"` // Relative importance score, from MinImportance to MaxImportance.
 	Embedding  []float64 `json:"embedding,omitempty"`  // Embedding for similarity search.
+	Links      []string  `json:"links,omitempty"`      // IDs of related memories, e.g. the memory this one refines.
+	Tags       []string  `json:"tags,omitempty"`       // Free-form labels such as a ticket name or component.
 }
 
 // EasyMemory is a simplified memory structure.
 type EasyMemory struct {
-	Category   string `json:"category"`   // e.g. "Architecture", "Performance", etc.
-	Content    string `json:"content"`    // The actual knowledge detail or summary.
-	Importance int    `json:"importance"` // Relative importance score.
+	Category   string   `json:"category"`   // e.g. "Architecture", "Performance", etc.
+	Content    string   `json:"content"`    // The actual knowledge detail or summary.
+	Importance int      `json:"importance"` // Relative importance score.
+	Links      []string `json:"links"`      // IDs of related memories.
+	Tags       []string `json:"tags"`       // Free-form labels such as a ticket name or component.
+}
+
+// NormalizeMemory clamps the importance to [MinImportance, MaxImportance], using DefaultImportance
+// when it is unset, and gives the category its canonical spelling (DefaultCategory when empty).
+// known is false when the category is not one of Categories; such a category is kept as given.
+func NormalizeMemory(me EasyMemory) (normalized EasyMemory, known bool) {
+	switch {
+	case me.Importance == 0:
+		me.Importance = DefaultImportance
+	case me.Importance < MinImportance:
+		me.Importance = MinImportance
+	case me.Importance > MaxImportance:
+		me.Importance = MaxImportance
+	}
+	me.Category = strings.TrimSpace(me.Category)
+	if me.Category == "" {
+		me.Category = DefaultCategory
+		return me, true
+	}
+	for _, c := range Categories {
+		if strings.EqualFold(c, me.Category) {
+			me.Category = c
+			return me, true
+		}
+	}
+	return me, false
 }
 
 // ContextStorage defines operations for storing and managing conversation context.
@@ -27,6 +77,16 @@ type ContextStorage interface {
 	GetContext() string
 	GetMemories() []MemoryEntry
 	SearchMemories(query string) []MemoryEntry
+	// SearchMemoriesWithParams returns up to k memories whose similarity to the query is at least threshold.
+	SearchMemoriesWithParams(query string, k int, threshold float64) []MemoryEntry
 	FilterRelatedMemories(newMems []EasyMemory) []MemoryEntry
 	MemoryExists(id string) bool
+	// GetLinkedMemories returns the memories the given memory links to and the memories linking to it.
+	GetLinkedMemories(id string) []MemoryEntry
+	// ExportMemories writes every memory, embeddings included, to w as a JSON array.
+	ExportMemories(w io.Writer) error
+	// ImportMemories reads a JSON array written by ExportMemories and adds its memories to the storage.
+	ImportMemories(r io.Reader) error
+	// Stats reports the number of memories by category, their importance and age, and the index size.
+	Stats() ContextStats
 }
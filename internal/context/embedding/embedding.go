@@ -1,6 +1,40 @@
 package embedding
 
+import "fmt"
+
 // EmbeddingProvider defines an interface for computing embeddings from text.
 type EmbeddingProvider interface {
 	ComputeEmbedding(text string) ([]float64, error)
+	// Dimensions returns the length of the vectors ComputeEmbedding produces, or 0 if unknown.
+	Dimensions() int
+}
+
+// BatchEmbeddingProvider is implemented by providers that can embed several texts in one call.
+type BatchEmbeddingProvider interface {
+	EmbeddingProvider
+	// ComputeEmbeddings returns one embedding per text, in the order of texts.
+	ComputeEmbeddings(texts []string) ([][]float64, error)
+}
+
+// ComputeAll embeds texts with p, in one call when p is a BatchEmbeddingProvider and one call per text otherwise.
+func ComputeAll(p EmbeddingProvider, texts []string) ([][]float64, error) {
+	if bp, ok := p.(BatchEmbeddingProvider); ok {
+		embeddings, err := bp.ComputeEmbeddings(texts)
+		if err != nil {
+			return nil, err
+		}
+		if len(embeddings) != len(texts) {
+			return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embeddings))
+		}
+		return embeddings, nil
+	}
+	embeddings := make([][]float64, len(texts))
+	for i, text := range texts {
+		emb, err := p.ComputeEmbedding(text)
+		if err != nil {
+			return nil, err
+		}
+		embeddings[i] = emb
+	}
+	return embeddings, nil
 }
@@ -0,0 +1,78 @@
+// File: internal/context/embedding/hashing/hashing.go
+package hashing
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// DefaultDimension is used when NewHashingEmbeddingProvider is given a non-positive dimension.
+const DefaultDimension = 256
+
+// HashingEmbeddingProvider implements EmbeddingProvider without any network calls.
+// Each lower-cased word and word bigram is hashed into one of Dimensions buckets with a
+// hash-derived sign, and the resulting vector is L2-normalized. The vectors are not
+// semantically rich, but they are deterministic and free, which makes the context
+// subsystem usable in tests and offline development.
+type HashingEmbeddingProvider struct {
+	dimension int
+}
+
+// NewHashingEmbeddingProvider creates a provider producing vectors of the given dimension.
+// The dimension must match the one the similarity searcher is configured for.
+func NewHashingEmbeddingProvider(dimension int) *HashingEmbeddingProvider {
+	if dimension <= 0 {
+		dimension = DefaultDimension
+	}
+	return &HashingEmbeddingProvider{dimension: dimension}
+}
+
+// Dimensions returns the length of the vectors produced by the provider.
+func (p *HashingEmbeddingProvider) Dimensions() int {
+	return p.dimension
+}
+
+// ComputeEmbedding returns a deterministic, normalized feature-hashing vector for text.
+func (p *HashingEmbeddingProvider) ComputeEmbedding(text string) ([]float64, error) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) == 0 {
+		return nil, fmt.Errorf("cannot embed text without words")
+	}
+
+	vec := make([]float64, p.dimension)
+	for i, w := range words {
+		p.add(vec, w)
+		if i > 0 {
+			p.add(vec, words[i-1]+" "+w)
+		}
+	}
+
+	var norm float64
+	for _, v := range vec {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+	if norm > 0 {
+		for i := range vec {
+			vec[i] /= norm
+		}
+	}
+	return vec, nil
+}
+
+// add hashes feature into a bucket of vec, using the top hash bit as the sign.
+func (p *HashingEmbeddingProvider) add(vec []float64, feature string) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+	sign := 1.0
+	if sum>>63 == 1 {
+		sign = -1.0
+	}
+	vec[sum%uint64(p.dimension)] += sign
+}
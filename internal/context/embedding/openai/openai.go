@@ -3,15 +3,34 @@ package openai
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/budget"
+	"github.com/egobogo/aiagents/internal/httputil"
+	"github.com/egobogo/aiagents/internal/ratelimit"
 )
 
 // EmbeddingProvider defines the interface for computing embeddings.
 type EmbeddingProvider interface {
 	ComputeEmbedding(text string) ([]float64, error)
+	Dimensions() int
+}
+
+// Embedder embeds a batch of texts through another OpenAI client, such as chatgpt.ChatGPTClient.
+type Embedder interface {
+	Embeddings(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// modelDimensions lists the default output dimension of known OpenAI embedding models.
+var modelDimensions = map[string]int{
+	"text-embedding-ada-002": 1536,
+	"text-embedding-3-small": 1536,
+	"text-embedding-3-large": 3072,
 }
 
 // OpenAIEmbeddingProvider implements EmbeddingProvider using direct HTTP calls to OpenAI's API.
@@ -19,6 +38,11 @@ type OpenAIEmbeddingProvider struct {
 	apiKey    string
 	modelName string
 	endpoint  string
+	budget    *budget.BudgetGuard // optional spend meter shared with other clients
+	limiter   *ratelimit.Limiter  // optional request rate cap shared with other clients
+	retry     httputil.RetryPolicy
+	client    *http.Client // optional; a default client is used when nil
+	embedder  Embedder     // optional; when set, embedding requests are delegated to it
 }
 
 // NewOpenAIEmbeddingProvider creates a new OpenAIEmbeddingProvider instance.
@@ -28,9 +52,38 @@ func NewOpenAIEmbeddingProvider(apiKey, modelName string) *OpenAIEmbeddingProvid
 		modelName: modelName,
 		// OpenAI embeddings endpoint.
 		endpoint: "https://api.openai.com/v1/embeddings",
+		retry:    httputil.DefaultRetryPolicy,
 	}
 }
 
+// SetRetryPolicy replaces the retry policy of embedding calls; the zero policy sends each request once.
+func (p *OpenAIEmbeddingProvider) SetRetryPolicy(policy httputil.RetryPolicy) {
+	p.retry = policy
+}
+
+// SetHTTPClient sets the client embedding requests are sent with, e.g. one configured for a proxy.
+// A nil client restores the default.
+func (p *OpenAIEmbeddingProvider) SetHTTPClient(client *http.Client) {
+	p.client = client
+}
+
+// SetEmbedder delegates embedding requests to e, which then supplies the key, HTTP client, rate limit and
+// budget; the provider's own settings are not used. The model of e should match the provider's model name
+// so Dimensions stays accurate. A nil embedder restores direct requests.
+func (p *OpenAIEmbeddingProvider) SetEmbedder(e Embedder) {
+	p.embedder = e
+}
+
+// SetRateLimiter attaches a request rate cap; embedding calls wait for it before being sent.
+func (p *OpenAIEmbeddingProvider) SetRateLimiter(limiter *ratelimit.Limiter) {
+	p.limiter = limiter
+}
+
+// SetBudgetGuard attaches a spend meter; embedding calls fail with budget.ErrBudgetExceeded once it trips.
+func (p *OpenAIEmbeddingProvider) SetBudgetGuard(guard *budget.BudgetGuard) {
+	p.budget = guard
+}
+
 // embeddingRequest represents the JSON payload sent to the OpenAI API.
 type embeddingRequest struct {
 	Model string   `json:"model"`
@@ -55,11 +108,43 @@ type embeddingResponse struct {
 	} `json:"usage"`
 }
 
+// Dimensions returns the output dimension of the configured model, or 0 if the model is unknown.
+func (p *OpenAIEmbeddingProvider) Dimensions() int {
+	return modelDimensions[p.modelName]
+}
+
 // ComputeEmbedding calls the OpenAI API and returns the embedding vector for the provided text.
 func (p *OpenAIEmbeddingProvider) ComputeEmbedding(text string) ([]float64, error) {
+	embeddings, err := p.ComputeEmbeddings([]string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// ComputeEmbeddings embeds all texts in a single API call and returns the vectors in the order of texts.
+func (p *OpenAIEmbeddingProvider) ComputeEmbeddings(texts []string) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	if p.embedder != nil {
+		embeddings, err := p.embedder.Embeddings(context.Background(), texts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute embeddings: %w", err)
+		}
+		if len(embeddings) != len(texts) {
+			return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embeddings))
+		}
+		return embeddings, nil
+	}
+	if p.budget != nil {
+		if err := p.budget.Check(); err != nil {
+			return nil, err
+		}
+	}
 	reqBody := embeddingRequest{
 		Model: p.modelName,
-		Input: []string{text},
+		Input: texts,
 	}
 	jsonData, err := json.Marshal(reqBody)
 	if err != nil {
@@ -73,8 +158,11 @@ func (p *OpenAIEmbeddingProvider) ComputeEmbedding(text string) ([]float64, erro
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", p.apiKey))
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	client := p.client
+	if client == nil {
+		client = &http.Client{}
+	}
+	resp, err := httputil.Do(req.Context(), p.limiter.Wrap(client), req, p.retry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call OpenAI API: %w", err)
 	}
@@ -82,7 +170,7 @@ func (p *OpenAIEmbeddingProvider) ComputeEmbedding(text string) ([]float64, erro
 
 	if resp.StatusCode != http.StatusOK {
 		bodyBytes, _ := ioutil.ReadAll(resp.Body)
-		return nil, fmt.Errorf("OpenAI API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+		return nil, fmt.Errorf("OpenAI API request failed: %w", apierr.NewStatusError(resp.StatusCode, string(bodyBytes)))
 	}
 
 	bodyBytes, err := ioutil.ReadAll(resp.Body)
@@ -94,11 +182,21 @@ func (p *OpenAIEmbeddingProvider) ComputeEmbedding(text string) ([]float64, erro
 	if err := json.Unmarshal(bodyBytes, &embResp); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal API response: %w", err)
 	}
+	if p.budget != nil {
+		p.budget.Record(p.modelName, embResp.Usage.TotalTokens)
+	}
 
-	if len(embResp.Data) == 0 {
-		return nil, fmt.Errorf("no embedding data returned")
+	if len(embResp.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(embResp.Data))
 	}
 
-	// We requested a single input so we return the first embedding.
-	return embResp.Data[0].Embedding, nil
+	// Results carry the index of their input; place them accordingly.
+	embeddings := make([][]float64, len(texts))
+	for _, d := range embResp.Data {
+		if d.Index < 0 || d.Index >= len(texts) || embeddings[d.Index] != nil {
+			return nil, fmt.Errorf("unexpected embedding index %d", d.Index)
+		}
+		embeddings[d.Index] = d.Embedding
+	}
+	return embeddings, nil
 }
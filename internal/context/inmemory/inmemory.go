@@ -2,7 +2,12 @@
 package inmemory
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,17 +26,46 @@ type InMemoryContextStorage struct {
 
 	embProvider embedding.EmbeddingProvider   // Dependency to compute embeddings.
 	simSearcher similarity.SimilaritySearcher // Dependency to index and search embeddings.
+
+	searchK         int     // Default number of results returned by SearchMemories.
+	searchThreshold float64 // Default minimum similarity used by SearchMemories.
 }
 
+// reindexBatchSize is the number of memories embedded per call by ReindexWithProvider.
+const reindexBatchSize = 100
+
+// Default search parameters used by SearchMemories and FilterRelatedMemories.
+// The threshold is a minimum cosine similarity (see similarity.SimilaritySearcher.Search).
+const (
+	DefaultSearchK         = 10
+	DefaultSearchThreshold = 0.1
+)
+
 // NewInMemoryContextStorage constructs a new instance of InMemoryContextStorage with the provided
-// EmbeddingProvider and SimilaritySearcher.
-func NewInMemoryContextStorage(embProvider embedding.EmbeddingProvider, simSearcher similarity.SimilaritySearcher) *InMemoryContextStorage {
+// EmbeddingProvider and SimilaritySearcher. It fails if both report a dimension and they differ,
+// so a mismatched pair is caught here rather than on the first Remember.
+func NewInMemoryContextStorage(embProvider embedding.EmbeddingProvider, simSearcher similarity.SimilaritySearcher) (*InMemoryContextStorage, error) {
+	embDim, searchDim := embProvider.Dimensions(), simSearcher.Dimensions()
+	if embDim != 0 && searchDim != 0 && embDim != searchDim {
+		return nil, fmt.Errorf("embedding provider produces %d-dimensional vectors but similarity searcher expects %d", embDim, searchDim)
+	}
 	return &InMemoryContextStorage{
 		coldStorage: make(map[string]context.MemoryEntry),
 		hotContext:  "",
 		embProvider: embProvider,
 		simSearcher: simSearcher,
-	}
+
+		searchK:         DefaultSearchK,
+		searchThreshold: DefaultSearchThreshold,
+	}, nil
+}
+
+// SetSearchDefaults changes the k and threshold used by SearchMemories and FilterRelatedMemories.
+func (s *InMemoryContextStorage) SetSearchDefaults(k int, threshold float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.searchK = k
+	s.searchThreshold = threshold
 }
 
 // MemoryExists returns true if a memory with the given ID is present in coldStorage.
@@ -46,12 +80,14 @@ func (s *InMemoryContextStorage) MemoryExists(id string) bool {
 // and returns a deduplicated slice of related MemoryEntry.
 func (s *InMemoryContextStorage) FilterRelatedMemories(newMems []context.EasyMemory) []context.MemoryEntry {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
+	provider, searcher := s.embProvider, s.simSearcher
+	k, threshold := s.searchK, s.searchThreshold
+	s.mu.RUnlock()
 
 	resultsMap := make(map[string]context.MemoryEntry)
 	for _, nm := range newMems {
 		// Search for related memories based on the content of the new memory.
-		related := s.SearchMemories(nm.Content)
+		related := searchLocked(provider, searcher, nm.Content, k, threshold)
 		for _, mem := range related {
 			// If this memory is not already in the results, add it.
 			if _, exists := resultsMap[mem.ID]; !exists {
@@ -71,10 +107,16 @@ func (s *InMemoryContextStorage) FilterRelatedMemories(newMems []context.EasyMem
 // It computes the embedding via the injected EmbeddingProvider,
 // assigns a unique ID and current timestamp, stores it in cold storage,
 // and indexes it via the injected SimilaritySearcher.
+// Importance and category are normalized with context.NormalizeMemory first.
 func (s *InMemoryContextStorage) Remember(easyMem context.EasyMemory) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	easyMem, known := context.NormalizeMemory(easyMem)
+	if !known {
+		fmt.Printf("Warning: memory has unknown category %q\n", easyMem.Category)
+	}
+
 	// Create a new MemoryEntry.
 	entry := context.MemoryEntry{
 		ID:         uuid.New().String(),
@@ -82,10 +124,12 @@ func (s *InMemoryContextStorage) Remember(easyMem context.EasyMemory) error {
 		Content:    easyMem.Content,
 		Importance: easyMem.Importance,
 		Timestamp:  time.Now(),
+		Links:      easyMem.Links,
+		Tags:       easyMem.Tags,
 	}
 
-	// Compute the embedding.
-	embedding, err := s.embProvider.ComputeEmbedding(easyMem.Content)
+	// Compute the embedding; tags are embedded with the content so searches match them too.
+	embedding, err := s.embProvider.ComputeEmbedding(embeddingText(entry))
 	if err != nil {
 		return fmt.Errorf("failed to compute embedding: %w", err)
 	}
@@ -129,14 +173,192 @@ func (m *InMemoryContextStorage) GetMemories() []context.MemoryEntry {
 	return memorySlice
 }
 
-// SearchMemories computes an embedding for the query text and uses the injected SimilaritySearcher
-// to retrieve similar memories.
+// ExportMemories writes all memories, including their embeddings, to w as JSON.
+// Entries are ordered by timestamp so repeated exports of the same storage are identical.
+func (s *InMemoryContextStorage) ExportMemories(w io.Writer) error {
+	memories := s.GetMemories()
+	sort.Slice(memories, func(i, j int) bool {
+		if !memories[i].Timestamp.Equal(memories[j].Timestamp) {
+			return memories[i].Timestamp.Before(memories[j].Timestamp)
+		}
+		return memories[i].ID < memories[j].ID
+	})
+	if memories == nil {
+		memories = []context.MemoryEntry{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(memories); err != nil {
+		return fmt.Errorf("failed to encode memories: %w", err)
+	}
+	return nil
+}
+
+// ImportMemories reads memories written by ExportMemories, stores them in cold storage
+// and indexes them in the similarity searcher. Entries whose ID is already present are skipped.
+// Embeddings missing from the input, or sized for a different searcher, are recomputed.
+func (s *InMemoryContextStorage) ImportMemories(r io.Reader) error {
+	var memories []context.MemoryEntry
+	if err := json.NewDecoder(r).Decode(&memories); err != nil {
+		return fmt.Errorf("failed to decode memories: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dim := s.simSearcher.Dimensions()
+	for _, entry := range memories {
+		if entry.ID == "" {
+			entry.ID = uuid.New().String()
+		}
+		if _, exists := s.coldStorage[entry.ID]; exists {
+			continue
+		}
+		if entry.Timestamp.IsZero() {
+			entry.Timestamp = time.Now()
+		}
+		if len(entry.Embedding) == 0 || (dim != 0 && len(entry.Embedding) != dim) {
+			embedding, err := s.embProvider.ComputeEmbedding(embeddingText(entry))
+			if err != nil {
+				return fmt.Errorf("failed to compute embedding for memory %s: %w", entry.ID, err)
+			}
+			entry.Embedding = embedding
+		}
+		if err := s.simSearcher.IndexMemory(entry); err != nil {
+			return fmt.Errorf("failed to index memory %s: %w", entry.ID, err)
+		}
+		s.coldStorage[entry.ID] = entry
+	}
+	return nil
+}
+
+// ReindexWithProvider switches the storage to embedding provider p, for example after a change of
+// embedding model. Every stored memory is re-embedded with p in batches, the similarity index is reset
+// to the new dimension and rebuilt, and p is used for all later memories and queries.
+// The searcher must implement similarity.Resetter. If embedding fails, the storage is left unchanged.
+func (s *InMemoryContextStorage) ReindexWithProvider(p embedding.EmbeddingProvider) error {
+	resetter, ok := s.simSearcher.(similarity.Resetter)
+	if !ok {
+		return fmt.Errorf("similarity searcher %T cannot be reset", s.simSearcher)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.coldStorage))
+	for id := range s.coldStorage {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	embeddings := make([][]float64, 0, len(ids))
+	for start := 0; start < len(ids); start += reindexBatchSize {
+		end := min(start+reindexBatchSize, len(ids))
+		texts := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			texts = append(texts, embeddingText(s.coldStorage[id]))
+		}
+		batch, err := embedding.ComputeAll(p, texts)
+		if err != nil {
+			return fmt.Errorf("failed to compute embeddings: %w", err)
+		}
+		embeddings = append(embeddings, batch...)
+	}
+
+	dim := p.Dimensions()
+	if dim == 0 && len(embeddings) > 0 {
+		dim = len(embeddings[0])
+	}
+	for i, emb := range embeddings {
+		if dim != 0 && len(emb) != dim {
+			return fmt.Errorf("memory %s embedded to %d dimensions, expected %d", ids[i], len(emb), dim)
+		}
+	}
+
+	if dim != 0 {
+		if err := resetter.Reset(dim); err != nil {
+			return fmt.Errorf("failed to reset similarity index: %w", err)
+		}
+	}
+	s.embProvider = p
+	for i, id := range ids {
+		entry := s.coldStorage[id]
+		entry.Embedding = embeddings[i]
+		s.coldStorage[id] = entry
+		if err := s.simSearcher.IndexMemory(entry); err != nil {
+			return fmt.Errorf("failed to index memory %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// GetLinkedMemories returns the neighbors of the memory with the given ID in the link graph:
+// the memories it links to and the memories that link to it. Links to unknown IDs are ignored.
+func (s *InMemoryContextStorage) GetLinkedMemories(id string) []context.MemoryEntry {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, exists := s.coldStorage[id]
+	if !exists {
+		return nil
+	}
+	seen := map[string]bool{id: true}
+	var linked []context.MemoryEntry
+	add := func(mem context.MemoryEntry) {
+		if seen[mem.ID] {
+			return
+		}
+		seen[mem.ID] = true
+		mem.Embedding = nil
+		linked = append(linked, mem)
+	}
+	for _, linkID := range entry.Links {
+		if mem, ok := s.coldStorage[linkID]; ok {
+			add(mem)
+		}
+	}
+	for _, mem := range s.coldStorage {
+		if slices.Contains(mem.Links, id) {
+			add(mem)
+		}
+	}
+	sort.SliceStable(linked, func(i, j int) bool { return linked[i].Timestamp.Before(linked[j].Timestamp) })
+	return linked
+}
+
+// embeddingText returns the text embedded for a memory: its content followed by its tags.
+func embeddingText(entry context.MemoryEntry) string {
+	if len(entry.Tags) == 0 {
+		return entry.Content
+	}
+	return entry.Content + "\nTags: " + strings.Join(entry.Tags, ", ")
+}
+
+// SearchMemories searches memories using the storage's default k and threshold.
 func (s *InMemoryContextStorage) SearchMemories(query string) []context.MemoryEntry {
-	emb, err := s.embProvider.ComputeEmbedding(query)
+	s.mu.RLock()
+	provider, searcher := s.embProvider, s.simSearcher
+	k, threshold := s.searchK, s.searchThreshold
+	s.mu.RUnlock()
+	return searchLocked(provider, searcher, query, k, threshold)
+}
+
+// SearchMemoriesWithParams computes an embedding for the query text and uses the injected SimilaritySearcher
+// to retrieve up to k memories with similarity at least threshold.
+func (s *InMemoryContextStorage) SearchMemoriesWithParams(query string, k int, threshold float64) []context.MemoryEntry {
+	s.mu.RLock()
+	provider, searcher := s.embProvider, s.simSearcher
+	s.mu.RUnlock()
+	return searchLocked(provider, searcher, query, k, threshold)
+}
+
+// searchLocked embeds query with provider and searches searcher. Callers snapshot provider and
+// searcher under s.mu, which ReindexWithProvider holds while swapping them, and call it unlocked.
+func searchLocked(provider embedding.EmbeddingProvider, searcher similarity.SimilaritySearcher, query string, k int, threshold float64) []context.MemoryEntry {
+	emb, err := provider.ComputeEmbedding(query)
 	if err != nil {
 		return nil
 	}
-	results, err := s.simSearcher.Search(emb, 10, 0.1)
+	results, err := searcher.Search(emb, k, threshold)
 	if err != nil {
 		return nil
 	}
@@ -147,6 +369,23 @@ func (s *InMemoryContextStorage) SearchMemories(query string) []context.MemoryEn
 	return results
 }
 
+// Stats summarizes the stored memories. IndexSize is -1 when the similarity searcher does not
+// implement similarity.Sizer; forgotten memories stay in the index, so it may exceed Total.
+func (s *InMemoryContextStorage) Stats() context.ContextStats {
+	s.mu.RLock()
+	memories := make([]context.MemoryEntry, 0, len(s.coldStorage))
+	for _, m := range s.coldStorage {
+		memories = append(memories, m)
+	}
+	s.mu.RUnlock()
+
+	stats := context.ComputeStats(memories)
+	if sizer, ok := s.simSearcher.(similarity.Sizer); ok {
+		stats.IndexSize = sizer.Len()
+	}
+	return stats
+}
+
 // Forget removes the memory with the given ID from cold storage.
 func (s *InMemoryContextStorage) Forget(id string) error {
 	s.mu.Lock()
@@ -0,0 +1,59 @@
+package context
+
+import "sort"
+
+// Defaults for SelectContextMemories.
+const (
+	// DefaultContextBudget is how many memories are merged into the hot context when none is configured.
+	DefaultContextBudget = 20
+	// DefaultMaxLowImportance caps how many low-importance memories SelectContextMemories keeps.
+	DefaultMaxLowImportance = 3
+	// LowImportance is the highest importance that counts as low.
+	LowImportance = 3
+)
+
+// SelectContextMemories picks at most budget memories for the hot context, most important first,
+// keeping no more than DefaultMaxLowImportance low-importance ones. See SelectContextMemoriesCapped.
+func SelectContextMemories(candidates []MemoryEntry, budget int) []MemoryEntry {
+	return SelectContextMemoriesCapped(candidates, budget, DefaultMaxLowImportance)
+}
+
+// SelectContextMemoriesCapped picks at most budget memories (all when budget <= 0), ordered by
+// importance with newer memories first among equals. At most maxLow memories with an importance of
+// LowImportance or less are kept; a negative maxLow means no cap. Unset importance counts as
+// DefaultImportance. The candidates slice is not modified.
+func SelectContextMemoriesCapped(candidates []MemoryEntry, budget, maxLow int) []MemoryEntry {
+	sorted := make([]MemoryEntry, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ii, ij := effectiveImportance(sorted[i]), effectiveImportance(sorted[j])
+		if ii != ij {
+			return ii > ij
+		}
+		return sorted[i].Timestamp.After(sorted[j].Timestamp)
+	})
+
+	var selected []MemoryEntry
+	low := 0
+	for _, mem := range sorted {
+		if budget > 0 && len(selected) >= budget {
+			break
+		}
+		if effectiveImportance(mem) <= LowImportance {
+			if maxLow >= 0 && low >= maxLow {
+				continue
+			}
+			low++
+		}
+		selected = append(selected, mem)
+	}
+	return selected
+}
+
+// effectiveImportance returns the memory's importance, DefaultImportance when unset.
+func effectiveImportance(mem MemoryEntry) int {
+	if mem.Importance == 0 {
+		return DefaultImportance
+	}
+	return mem.Importance
+}
@@ -0,0 +1,91 @@
+package exact
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/context"
+)
+
+// ExactSimilaritySearcher compares the query against every indexed memory.
+// It is meant for small sets, such as a single batch of new thoughts, where building a graph is not worth it.
+type ExactSimilaritySearcher struct {
+	memories []context.MemoryEntry
+	mu       sync.Mutex
+}
+
+// New creates an empty ExactSimilaritySearcher.
+func New() *ExactSimilaritySearcher {
+	return &ExactSimilaritySearcher{}
+}
+
+// Dimensions returns 0 because vectors of any length are accepted.
+func (s *ExactSimilaritySearcher) Dimensions() int {
+	return 0
+}
+
+// Reset removes every indexed memory. The dimension is ignored since any length is accepted.
+func (s *ExactSimilaritySearcher) Reset(dim int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.memories = nil
+	return nil
+}
+
+// Len returns the number of indexed memories.
+func (s *ExactSimilaritySearcher) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.memories)
+}
+
+// IndexMemory adds a memory entry to the searcher.
+func (s *ExactSimilaritySearcher) IndexMemory(mem context.MemoryEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.memories = append(s.memories, mem)
+	return nil
+}
+
+// Search returns up to k memories whose cosine similarity to the query is at least threshold,
+// most similar first. Ties keep indexing order.
+func (s *ExactSimilaritySearcher) Search(query []float64, k int, threshold float64) ([]context.MemoryEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	type scored struct {
+		mem context.MemoryEntry
+		sim float64
+	}
+	var hits []scored
+	for _, mem := range s.memories {
+		if sim := cosineSimilarity(query, mem.Embedding); sim >= threshold {
+			hits = append(hits, scored{mem, sim})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].sim > hits[j].sim })
+
+	var matches []context.MemoryEntry
+	for i := 0; i < len(hits) && i < k; i++ {
+		matches = append(matches, hits[i].mem)
+	}
+	return matches, nil
+}
+
+// cosineSimilarity computes the cosine similarity between two vectors; mismatched lengths score 0.
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
@@ -2,6 +2,7 @@ package hnsw
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"sync"
 
@@ -19,6 +20,9 @@ type HNSWSimilaritySearcher struct {
 
 // New creates a new HNSWSimilaritySearcher with the given embedding dimension.
 func New(dim int) (*HNSWSimilaritySearcher, error) {
+	if dim <= 0 {
+		return nil, fmt.Errorf("invalid embedding dimension %d", dim)
+	}
 	// Create a new generic graph for string keys.
 	g := hnsw.NewGraph[string]()
 	return &HNSWSimilaritySearcher{
@@ -28,6 +32,33 @@ func New(dim int) (*HNSWSimilaritySearcher, error) {
 	}, nil
 }
 
+// Dimensions returns the embedding dimension the graph was created (or last reset) with.
+func (s *HNSWSimilaritySearcher) Dimensions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dim
+}
+
+// Reset replaces the graph with an empty one for embeddings of length dim.
+func (s *HNSWSimilaritySearcher) Reset(dim int) error {
+	if dim <= 0 {
+		return fmt.Errorf("invalid embedding dimension %d", dim)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.graph = hnsw.NewGraph[string]()
+	s.dim = dim
+	s.memMap = make(map[string]context.MemoryEntry)
+	return nil
+}
+
+// Len returns the number of nodes in the graph.
+func (s *HNSWSimilaritySearcher) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.graph.Len()
+}
+
 // IndexMemory adds a memory entry to the HNSW graph.
 // It expects that mem.Embedding has length equal to the dimension.
 func (s *HNSWSimilaritySearcher) IndexMemory(mem context.MemoryEntry) error {
@@ -49,8 +80,8 @@ func (s *HNSWSimilaritySearcher) IndexMemory(mem context.MemoryEntry) error {
 }
 
 // Search performs a similarity search for the query embedding, returning up to k matching memories
-// with cosine similarity above the threshold.
-// We compute cosine similarity as: similarity = 1.0 - cosineSimilarity(query, node.Value)
+// whose cosine similarity to the query is at least threshold.
+// Similarity ranges from -1 (opposite) through 0 (unrelated) to 1 (same direction).
 func (s *HNSWSimilaritySearcher) Search(query []float64, k int, threshold float64) ([]context.MemoryEntry, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -66,7 +97,7 @@ func (s *HNSWSimilaritySearcher) Search(query []float64, k int, threshold float6
 	var matches []context.MemoryEntry
 	for _, node := range neighbors {
 		// Compute cosine similarity between the query and the node's vector stored in Value.
-		sim := 1.0 - cosineSimilarity(q, node.Value)
+		sim := cosineSimilarity(q, node.Value)
 		if sim >= threshold {
 			if mem, ok := s.memMap[node.Key]; ok {
 				matches = append(matches, mem)
@@ -6,6 +6,23 @@ import "github.com/egobogo/aiagents/internal/context"
 type SimilaritySearcher interface {
 	// IndexMemory adds a memory entry to the search index.
 	IndexMemory(mem context.MemoryEntry) error
-	// Search takes a query embedding and returns matching memory entries whose similarity is above threshold.
+	// Search takes a query embedding and returns up to k memory entries whose similarity is at least threshold.
+	// Similarity is the cosine of the angle between the embeddings: 1 for the same direction, 0 for
+	// unrelated (orthogonal) and -1 for opposite ones, so a higher threshold keeps fewer, closer memories.
 	Search(query []float64, k int, threshold float64) ([]context.MemoryEntry, error)
+	// Dimensions returns the embedding length the index accepts, or 0 if it accepts any length.
+	Dimensions() int
+}
+
+// Sizer is implemented by searchers that can report how many entries their index holds.
+type Sizer interface {
+	// Len returns the number of indexed entries.
+	Len() int
+}
+
+// Resetter is implemented by searchers that can drop their index and start over, for example
+// when stored memories are re-embedded with a provider of a different dimension.
+type Resetter interface {
+	// Reset removes every indexed memory and makes the index accept vectors of length dim.
+	Reset(dim int) error
 }
@@ -0,0 +1,43 @@
+package context
+
+import "time"
+
+// ContextStats summarizes the memories held by a ContextStorage.
+type ContextStats struct {
+	Total             int            `json:"total"`             // Number of stored memories.
+	ByCategory        map[string]int `json:"byCategory"`        // Number of memories per category.
+	AverageImportance float64        `json:"averageImportance"` // Mean importance, counting unset importance as DefaultImportance.
+	Oldest            time.Time      `json:"oldest"`            // Timestamp of the oldest memory; zero when empty.
+	Newest            time.Time      `json:"newest"`            // Timestamp of the newest memory; zero when empty.
+	IndexSize         int            `json:"indexSize"`         // Entries in the similarity index; -1 when the index does not report it.
+}
+
+// ComputeStats returns the statistics of memories. IndexSize is left at -1 for the caller to fill in.
+func ComputeStats(memories []MemoryEntry) ContextStats {
+	stats := ContextStats{
+		Total:      len(memories),
+		ByCategory: make(map[string]int),
+		IndexSize:  -1,
+	}
+	importance := 0
+	for _, m := range memories {
+		stats.ByCategory[m.Category]++
+		if m.Importance == 0 {
+			importance += DefaultImportance
+		} else {
+			importance += m.Importance
+		}
+		if !m.Timestamp.IsZero() {
+			if stats.Oldest.IsZero() || m.Timestamp.Before(stats.Oldest) {
+				stats.Oldest = m.Timestamp
+			}
+			if m.Timestamp.After(stats.Newest) {
+				stats.Newest = m.Timestamp
+			}
+		}
+	}
+	if len(memories) > 0 {
+		stats.AverageImportance = float64(importance) / float64(len(memories))
+	}
+	return stats
+}
@@ -0,0 +1,55 @@
+// internal/context/summarizing/summarizing.go
+package summarizing
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/budget"
+	"github.com/egobogo/aiagents/internal/context"
+	mclient "github.com/egobogo/aiagents/internal/model"
+)
+
+// DefaultMaxTokens is the hot-context budget used when none is configured.
+const DefaultMaxTokens = 4000
+
+// summarizePrompt asks the model to compress the hot context to the given token budget.
+const summarizePrompt = `The following is the working context of a software agent. It has grown too large.
+Rewrite it as a compact summary of at most %d tokens. Keep every decision, requirement, open question,
+name and identifier; drop repetition and filler. Reply with the summary only.
+
+%s`
+
+// SummarizingContextStorage decorates a ContextStorage so that a hot context larger than
+// MaxTokens is compressed by the model before it is stored.
+type SummarizingContextStorage struct {
+	context.ContextStorage
+
+	Model     mclient.ModelClient
+	MaxTokens int
+}
+
+// New wraps inner. A non-positive maxTokens uses DefaultMaxTokens.
+func New(inner context.ContextStorage, model mclient.ModelClient, maxTokens int) *SummarizingContextStorage {
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxTokens
+	}
+	return &SummarizingContextStorage{ContextStorage: inner, Model: model, MaxTokens: maxTokens}
+}
+
+// SetContext stores summary, first compressing it with the model if it exceeds MaxTokens.
+// If the model's summary is not shorter, the original is stored unchanged.
+func (s *SummarizingContextStorage) SetContext(summary string) error {
+	if budget.EstimateTokens(len(summary)) <= s.MaxTokens {
+		return s.ContextStorage.SetContext(summary)
+	}
+	compressed, err := s.Model.Chat(fmt.Sprintf(summarizePrompt, s.MaxTokens, summary))
+	if err != nil {
+		return fmt.Errorf("failed to summarize context: %w", err)
+	}
+	compressed = strings.TrimSpace(compressed)
+	if compressed == "" || len(compressed) >= len(summary) {
+		return s.ContextStorage.SetContext(summary)
+	}
+	return s.ContextStorage.SetContext(compressed)
+}
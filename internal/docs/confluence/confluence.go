@@ -0,0 +1,359 @@
+package confluence
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/docs"
+	"github.com/egobogo/aiagents/internal/httputil"
+)
+
+// pageLimit is the page size used for paginated content endpoints.
+const pageLimit = 50
+
+// ConfluenceClient is a concrete implementation of docs.DocumentationClient using the
+// Confluence Cloud REST API. Pages are kept in one space, under an optional root page.
+type ConfluenceClient struct {
+	BaseURL    string // Site wiki URL, e.g. "https://acme.atlassian.net/wiki"
+	Email      string // Account email used for basic auth
+	APIToken   string // Atlassian API token
+	SpaceKey   string // Space the wiki lives in
+	ParentPage string // Root page ID; empty means the whole space
+	HTTPClient *http.Client
+	Retry      httputil.RetryPolicy // retries of rate-limited and failing requests; zero sends each request once
+}
+
+// NewConfluenceClient creates a new ConfluenceClient instance.
+func NewConfluenceClient(baseURL, email, apiToken, spaceKey, parentPage string) *ConfluenceClient {
+	return &ConfluenceClient{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Email:      email,
+		APIToken:   apiToken,
+		SpaceKey:   spaceKey,
+		ParentPage: parentPage,
+		HTTPClient: &http.Client{},
+		Retry:      httputil.DefaultRetryPolicy,
+	}
+}
+
+// content is the subset of a Confluence content object used by the client.
+type content struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Body  struct {
+		Storage struct {
+			Value string `json:"value"`
+		} `json:"storage"`
+	} `json:"body"`
+	Version struct {
+		Number int    `json:"number"`
+		When   string `json:"when"`
+	} `json:"version"`
+	Ancestors []struct {
+		ID    string `json:"id"`
+		Title string `json:"title"`
+	} `json:"ancestors"`
+	Links struct {
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+// contentList is a paginated list of content.
+type contentList struct {
+	Results []content `json:"results"`
+	Size    int       `json:"size"`
+}
+
+// do performs an API request, retrying rate-limited and temporarily unavailable responses.
+func (cc *ConfluenceClient) do(method, path string, body, target interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+	var reader io.Reader
+	if data != nil {
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, cc.BaseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(cc.Email, cc.APIToken)
+	req.Header.Set("Accept", "application/json")
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := httputil.Do(req.Context(), cc.HTTPClient, req, cc.Retry)
+	if err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s failed: %w", method, path, apierr.NewStatusError(resp.StatusCode, string(respBytes)))
+	}
+	if target != nil && len(respBytes) > 0 {
+		if err := json.Unmarshal(respBytes, target); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// listAll fetches every page of a paginated content endpoint.
+func (cc *ConfluenceClient) listAll(path string) ([]content, error) {
+	var all []content
+	for start := 0; ; start += pageLimit {
+		var list contentList
+		if err := cc.do("GET", fmt.Sprintf("%s&limit=%d&start=%d", path, pageLimit, start), nil, &list); err != nil {
+			return nil, err
+		}
+		all = append(all, list.Results...)
+		if len(list.Results) < pageLimit {
+			return all, nil
+		}
+	}
+}
+
+// CreatePage creates a new page as a child of the specified parent page.
+// If parentPageID is an empty string, the page is created under the root.
+func (cc *ConfluenceClient) CreatePage(title string, text string, parentPageID string) (docs.Page, error) {
+	if parentPageID == "" {
+		parentPageID = cc.ParentPage
+	}
+	payload := map[string]interface{}{
+		"type":  "page",
+		"title": title,
+		"space": map[string]string{"key": cc.SpaceKey},
+		"body": map[string]interface{}{
+			"storage": map[string]string{"value": toStorage(text), "representation": "storage"},
+		},
+	}
+	if parentPageID != "" {
+		payload["ancestors"] = []map[string]string{{"id": parentPageID}}
+	}
+	var created content
+	if err := cc.do("POST", "/rest/api/content", payload, &created); err != nil {
+		return docs.Page{}, fmt.Errorf("failed to create page: %w", err)
+	}
+	page := cc.toPage(created)
+	page.Content = text
+	page.ParentID = parentPageID
+	return page, nil
+}
+
+// UpdatePage updates the content of a page.
+// If replace is true, the existing body is replaced; otherwise the new content is appended.
+// Child pages are separate content in Confluence and are never affected.
+func (cc *ConfluenceClient) UpdatePage(pageID string, text string, replace bool) error {
+	current, err := cc.getContent(pageID)
+	if err != nil {
+		return err
+	}
+	body := toStorage(text)
+	if !replace {
+		body = current.Body.Storage.Value + body
+	}
+	payload := map[string]interface{}{
+		"id":      pageID,
+		"type":    "page",
+		"title":   current.Title,
+		"version": map[string]int{"number": current.Version.Number + 1},
+		"body": map[string]interface{}{
+			"storage": map[string]string{"value": body, "representation": "storage"},
+		},
+	}
+	if err := cc.do("PUT", "/rest/api/content/"+pageID, payload, nil); err != nil {
+		return fmt.Errorf("failed to update page: %w", err)
+	}
+	return nil
+}
+
+// UpdateTitle renames a page, keeping its body.
+func (cc *ConfluenceClient) UpdateTitle(pageID, newTitle string) error {
+	current, err := cc.getContent(pageID)
+	if err != nil {
+		return err
+	}
+	payload := map[string]interface{}{
+		"id":      pageID,
+		"type":    "page",
+		"title":   newTitle,
+		"version": map[string]int{"number": current.Version.Number + 1},
+		"body": map[string]interface{}{
+			"storage": map[string]string{"value": current.Body.Storage.Value, "representation": "storage"},
+		},
+	}
+	if err := cc.do("PUT", "/rest/api/content/"+pageID, payload, nil); err != nil {
+		return fmt.Errorf("failed to update title: %w", err)
+	}
+	return nil
+}
+
+// ReadPage retrieves a page with its content as plain text.
+func (cc *ConfluenceClient) ReadPage(pageID string) (docs.Page, error) {
+	c, err := cc.getContent(pageID)
+	if err != nil {
+		return docs.Page{}, err
+	}
+	return cc.toPage(c), nil
+}
+
+// getContent fetches a page with its body, version and ancestors.
+func (cc *ConfluenceClient) getContent(pageID string) (content, error) {
+	var c content
+	if err := cc.do("GET", "/rest/api/content/"+pageID+"?expand=body.storage,version,ancestors", nil, &c); err != nil {
+		return content{}, fmt.Errorf("failed to read page: %w", err)
+	}
+	return c, nil
+}
+
+// DeletePage moves a page to the space trash.
+func (cc *ConfluenceClient) DeletePage(pageID string) error {
+	if err := cc.do("DELETE", "/rest/api/content/"+pageID, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete page: %w", err)
+	}
+	return nil
+}
+
+// ListSubPages lists the direct child pages of the given parent page.
+func (cc *ConfluenceClient) ListSubPages(parentPageID string) ([]docs.Page, error) {
+	children, err := cc.listAll("/rest/api/content/" + parentPageID + "/child/page?expand=version,ancestors")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sub pages: %w", err)
+	}
+	var pages []docs.Page
+	for _, c := range children {
+		pages = append(pages, cc.toPage(c))
+	}
+	return pages, nil
+}
+
+// ListPages returns the root page and every page below it, with Path built from ancestors.
+// Page content is not included.
+func (cc *ConfluenceClient) ListPages() ([]docs.Page, error) {
+	all, err := cc.listAll("/rest/api/content?type=page&spaceKey=" + url.QueryEscape(cc.SpaceKey) + "&expand=version,ancestors")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pages: %w", err)
+	}
+	var pages []docs.Page
+	for _, c := range all {
+		if cc.ParentPage == "" || c.ID == cc.ParentPage || cc.underRoot(c) {
+			pages = append(pages, cc.toPage(c))
+		}
+	}
+	return pages, nil
+}
+
+// SearchPages finds pages in the space whose title or text matches query.
+// An empty query returns every page in the space.
+func (cc *ConfluenceClient) SearchPages(query string) ([]docs.Page, error) {
+	cql := fmt.Sprintf(`type=page and space="%s"`, cc.SpaceKey)
+	if query != "" {
+		q := strings.ReplaceAll(query, `"`, `\"`)
+		cql += fmt.Sprintf(` and (title~"%s" or text~"%s")`, q, q)
+	}
+	results, err := cc.listAll("/rest/api/content/search?cql=" + url.QueryEscape(cql) + "&expand=version,ancestors")
+	if err != nil {
+		return nil, fmt.Errorf("search request failed: %w", err)
+	}
+	var pages []docs.Page
+	for _, c := range results {
+		pages = append(pages, cc.toPage(c))
+	}
+	return pages, nil
+}
+
+// PrintTree returns an indented tree of the pages under the root.
+func (cc *ConfluenceClient) PrintTree() (string, error) {
+	pages, err := cc.ListPages()
+	if err != nil {
+		return "", fmt.Errorf("failed to list pages: %w", err)
+	}
+	var builder strings.Builder
+	for _, p := range pages {
+		depth := strings.Count(p.Path, "/")
+		builder.WriteString(fmt.Sprintf("%s%s (ID: %s, URL: %s)\n", strings.Repeat("    ", depth), p.Title, p.ID, p.URL))
+	}
+	return builder.String(), nil
+}
+
+// underRoot reports whether the root page is one of c's ancestors.
+func (cc *ConfluenceClient) underRoot(c content) bool {
+	for _, a := range c.Ancestors {
+		if a.ID == cc.ParentPage {
+			return true
+		}
+	}
+	return false
+}
+
+// toPage converts API content to a docs.Page. Path starts at the root page when it is an
+// ancestor, otherwise at the top of the space.
+func (cc *ConfluenceClient) toPage(c content) docs.Page {
+	page := docs.Page{
+		ID:      c.ID,
+		Title:   c.Title,
+		Content: fromStorage(c.Body.Storage.Value),
+	}
+	if c.Links.WebUI != "" {
+		page.URL = cc.BaseURL + c.Links.WebUI
+	}
+	if t, err := time.Parse(time.RFC3339, c.Version.When); err == nil {
+		page.LastEdited = t
+	}
+	var path []string
+	for _, a := range c.Ancestors {
+		if a.ID == cc.ParentPage {
+			path = nil
+		}
+		path = append(path, a.Title)
+	}
+	page.Path = strings.Join(append(path, c.Title), "/")
+	if n := len(c.Ancestors); n > 0 {
+		page.ParentID = c.Ancestors[n-1].ID
+	}
+	return page
+}
+
+// toStorage converts plain text to Confluence storage format, one paragraph per line.
+func toStorage(text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		b.WriteString("<p>" + html.EscapeString(line) + "</p>")
+	}
+	return b.String()
+}
+
+var (
+	blockEnd = regexp.MustCompile(`(?i)</(p|h[1-6]|li|pre|div|tr)>|<br\s*/?>`)
+	anyTag   = regexp.MustCompile(`<[^>]+>`)
+)
+
+// fromStorage converts Confluence storage format to plain text.
+func fromStorage(storage string) string {
+	text := blockEnd.ReplaceAllString(storage, "\n")
+	text = anyTag.ReplaceAllString(text, "")
+	return strings.TrimSpace(html.UnescapeString(text))
+}
+
+var _ docs.DocumentationClient = (*ConfluenceClient)(nil)
@@ -1,5 +1,7 @@
 package docs
 
+import "time"
+
 // DocumentationClient defines operations for managing documentation pages.
 type DocumentationClient interface {
 	// CreatePage creates a new page. If parentPageID is empty, the page is created under the root.
@@ -8,6 +10,9 @@ type DocumentationClient interface {
 	// UpdatePage updates a page's content. If replace is true, the existing content (excluding child pages) is replaced.
 	UpdatePage(pageID string, content string, replace bool) error
 
+	// UpdateTitle renames a page. Backends that derive page IDs from titles keep the existing ID.
+	UpdateTitle(pageID, newTitle string) error
+
 	ReadPage(pageID string) (Page, error)
 	SearchPages(query string) ([]Page, error)
 	ListPages() ([]Page, error)
@@ -25,4 +30,6 @@ type Page struct {
 	URL      string `json:"url"`
 	Path     string `json:"path"`
 	ParentID string `json:"ParentID"`
+	// LastEdited is when the page was last modified, if the backend reports it.
+	LastEdited time.Time `json:"last_edited,omitempty"`
 }
@@ -0,0 +1,302 @@
+// Package mdfs implements docs.DocumentationClient on a directory of Markdown files, for
+// offline use, tests and git-versioned documentation.
+//
+// A page is a Markdown file whose first line is "# <title>". Its sub-pages live in a folder
+// named like the file without the ".md" extension:
+//
+//	architecture.md
+//	architecture/
+//	    storage.md
+//
+// Page IDs are the slash-separated file paths relative to the root, e.g. "architecture/storage.md".
+package mdfs
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/docs"
+)
+
+// MarkdownClient is a concrete implementation of docs.DocumentationClient backed by Markdown files.
+type MarkdownClient struct {
+	Root string // Directory holding the top-level pages
+}
+
+// NewMarkdownClient creates a MarkdownClient rooted at dir, creating the directory if needed.
+func NewMarkdownClient(dir string) (*MarkdownClient, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create docs directory: %w", err)
+	}
+	return &MarkdownClient{Root: dir}, nil
+}
+
+// CreatePage writes a new page file. If parentPageID is empty, the page is created at the root.
+func (mc *MarkdownClient) CreatePage(title string, content string, parentPageID string) (docs.Page, error) {
+	dir := ""
+	if parentPageID != "" {
+		if _, err := mc.ReadPage(parentPageID); err != nil {
+			return docs.Page{}, fmt.Errorf("parent page: %w", err)
+		}
+		dir = strings.TrimSuffix(parentPageID, ".md")
+	}
+	if err := os.MkdirAll(mc.abs(dir), 0755); err != nil {
+		return docs.Page{}, fmt.Errorf("failed to create page directory: %w", err)
+	}
+
+	base := slugify(title)
+	id := path.Join(dir, base+".md")
+	for n := 2; ; n++ {
+		if _, err := os.Stat(mc.abs(id)); os.IsNotExist(err) {
+			break
+		}
+		id = path.Join(dir, fmt.Sprintf("%s-%d.md", base, n))
+	}
+	if err := mc.write(id, title, content); err != nil {
+		return docs.Page{}, err
+	}
+	return mc.ReadPage(id)
+}
+
+// UpdatePage replaces or appends to a page's content. Sub-pages are separate files and are never affected.
+func (mc *MarkdownClient) UpdatePage(pageID string, content string, replace bool) error {
+	page, err := mc.ReadPage(pageID)
+	if err != nil {
+		return err
+	}
+	if !replace && page.Content != "" {
+		content = page.Content + "\n\n" + content
+	}
+	return mc.write(pageID, page.Title, content)
+}
+
+// UpdateTitle rewrites the title line of a page. The page keeps its ID, so the file name is unchanged.
+func (mc *MarkdownClient) UpdateTitle(pageID, newTitle string) error {
+	page, err := mc.ReadPage(pageID)
+	if err != nil {
+		return err
+	}
+	return mc.write(pageID, newTitle, page.Content)
+}
+
+// ReadPage reads a page file.
+func (mc *MarkdownClient) ReadPage(pageID string) (docs.Page, error) {
+	if err := validID(pageID); err != nil {
+		return docs.Page{}, err
+	}
+	data, err := os.ReadFile(mc.abs(pageID))
+	if err != nil {
+		return docs.Page{}, fmt.Errorf("failed to read page %s: %w", pageID, err)
+	}
+	info, err := os.Stat(mc.abs(pageID))
+	if err != nil {
+		return docs.Page{}, fmt.Errorf("failed to stat page %s: %w", pageID, err)
+	}
+	title, content := parsePage(string(data), pageID)
+	page := docs.Page{
+		ID:         pageID,
+		Title:      title,
+		Content:    content,
+		URL:        "file://" + filepath.ToSlash(mc.abs(pageID)),
+		ParentID:   parentID(pageID),
+		LastEdited: info.ModTime(),
+	}
+	page.Path = mc.titlePath(page)
+	return page, nil
+}
+
+// DeletePage removes a page file together with its sub-pages.
+func (mc *MarkdownClient) DeletePage(pageID string) error {
+	if _, err := mc.ReadPage(pageID); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(mc.abs(strings.TrimSuffix(pageID, ".md"))); err != nil {
+		return fmt.Errorf("failed to delete sub pages of %s: %w", pageID, err)
+	}
+	if err := os.Remove(mc.abs(pageID)); err != nil {
+		return fmt.Errorf("failed to delete page %s: %w", pageID, err)
+	}
+	return nil
+}
+
+// ListSubPages lists the direct child pages of parentPageID; an empty ID lists the top-level pages.
+func (mc *MarkdownClient) ListSubPages(parentPageID string) ([]docs.Page, error) {
+	dir := strings.TrimSuffix(parentPageID, ".md")
+	entries, err := os.ReadDir(mc.abs(dir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	var pages []docs.Page
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".md") {
+			continue
+		}
+		page, err := mc.ReadPage(path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// ListPages walks the tree and returns every page, parents before their children.
+func (mc *MarkdownClient) ListPages() ([]docs.Page, error) {
+	var ids []string
+	err := filepath.WalkDir(mc.Root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(mc.Root, p)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk docs directory: %w", err)
+	}
+	// A page's ID without ".md" is a prefix of its children's IDs, so it sorts before them.
+	sort.Slice(ids, func(i, j int) bool {
+		return strings.TrimSuffix(ids[i], ".md") < strings.TrimSuffix(ids[j], ".md")
+	})
+	var pages []docs.Page
+	for _, id := range ids {
+		page, err := mc.ReadPage(id)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// SearchPages returns pages whose title or content contains query, ignoring case.
+// An empty query returns every page.
+func (mc *MarkdownClient) SearchPages(query string) ([]docs.Page, error) {
+	pages, err := mc.ListPages()
+	if err != nil {
+		return nil, err
+	}
+	q := strings.ToLower(query)
+	var result []docs.Page
+	for _, p := range pages {
+		if strings.Contains(strings.ToLower(p.Title), q) || strings.Contains(strings.ToLower(p.Content), q) {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// PrintTree renders the page hierarchy, starting with the root directory.
+func (mc *MarkdownClient) PrintTree() (string, error) {
+	var builder strings.Builder
+	builder.WriteString(filepath.Base(mc.Root) + "/\n")
+	var walk func(parentID, prefix string) error
+	walk = func(parentID, prefix string) error {
+		children, err := mc.ListSubPages(parentID)
+		if err != nil {
+			return err
+		}
+		for i, child := range children {
+			connector, next := "├── ", "│   "
+			if i == len(children)-1 {
+				connector, next = "└── ", "    "
+			}
+			builder.WriteString(fmt.Sprintf("%s%s%s (ID: %s)\n", prefix, connector, child.Title, child.ID))
+			if err := walk(child.ID, prefix+next); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk("", ""); err != nil {
+		return "", fmt.Errorf("failed to build tree: %w", err)
+	}
+	return builder.String(), nil
+}
+
+// abs returns the filesystem path of a slash-separated path relative to the root.
+func (mc *MarkdownClient) abs(rel string) string {
+	return filepath.Join(mc.Root, filepath.FromSlash(rel))
+}
+
+// write stores a page file with its title heading.
+func (mc *MarkdownClient) write(pageID, title, content string) error {
+	data := "# " + title + "\n"
+	if content = strings.TrimSpace(content); content != "" {
+		data += "\n" + content + "\n"
+	}
+	if err := os.WriteFile(mc.abs(pageID), []byte(data), 0644); err != nil {
+		return fmt.Errorf("failed to write page %s: %w", pageID, err)
+	}
+	return nil
+}
+
+// titlePath builds the "/"-joined title path of a page from its ancestors' titles.
+func (mc *MarkdownClient) titlePath(page docs.Page) string {
+	titles := []string{page.Title}
+	for id := page.ParentID; id != ""; id = parentID(id) {
+		data, err := os.ReadFile(mc.abs(id))
+		if err != nil {
+			break
+		}
+		title, _ := parsePage(string(data), id)
+		titles = append([]string{title}, titles...)
+	}
+	return strings.Join(titles, "/")
+}
+
+// parentID returns the ID of the page whose folder holds pageID, or "" for top-level pages.
+func parentID(pageID string) string {
+	dir := path.Dir(pageID)
+	if dir == "." {
+		return ""
+	}
+	return dir + ".md"
+}
+
+// parsePage splits a page file into its title heading and content. Files without a heading
+// use their file name as the title.
+func parsePage(data, pageID string) (string, string) {
+	first, rest, _ := strings.Cut(data, "\n")
+	if strings.HasPrefix(first, "# ") {
+		return strings.TrimSpace(strings.TrimPrefix(first, "# ")), strings.TrimSpace(rest)
+	}
+	return strings.TrimSuffix(path.Base(pageID), ".md"), strings.TrimSpace(data)
+}
+
+// validID rejects IDs that are not Markdown files inside the root.
+func validID(pageID string) error {
+	clean := path.Clean(pageID)
+	if !strings.HasSuffix(clean, ".md") || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("invalid page ID %q", pageID)
+	}
+	return nil
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify turns a title into a file name.
+func slugify(title string) string {
+	slug := strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if slug == "" {
+		slug = "page"
+	}
+	return slug
+}
+
+var _ docs.DocumentationClient = (*MarkdownClient)(nil)
@@ -0,0 +1,113 @@
+package notion
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/docs"
+)
+
+// maxConcurrentCreates bounds the number of in-flight CreatePage calls in CreatePages.
+const maxConcurrentCreates = 4
+
+// PageSpec describes one page to create with CreatePages.
+type PageSpec struct {
+	// Ref is an optional batch-local name other specs can use as their ParentID.
+	Ref     string
+	Title   string
+	Content string
+	// ParentID is either an existing page ID, the Ref of another spec in the batch,
+	// or empty for the root page.
+	ParentID string
+}
+
+// CreatePages creates the given pages concurrently, level by level, so every parent
+// referenced by Ref exists before its children are created. A page that already exists under
+// its parent with the same title is reused (see CreatePageIfAbsent), so re-running a batch
+// does not duplicate it. The returned slice is aligned
+// with specs; entries that could not be created are zero Pages and their failures are
+// joined into the returned error.
+func (nc *NotionClient) CreatePages(specs []PageSpec) ([]docs.Page, error) {
+	refs := make(map[string]int, len(specs))
+	for i, spec := range specs {
+		if spec.Ref == "" {
+			continue
+		}
+		if _, dup := refs[spec.Ref]; dup {
+			return nil, fmt.Errorf("duplicate page ref %q", spec.Ref)
+		}
+		refs[spec.Ref] = i
+	}
+
+	pages := make([]docs.Page, len(specs))
+	done := make([]bool, len(specs))
+	failed := make([]bool, len(specs))
+	var errs []error
+
+	for remaining := len(specs); remaining > 0; {
+		// Collect every pending spec whose parent is resolved.
+		var level []int
+		parents := make(map[int]string)
+		for i, spec := range specs {
+			if done[i] || failed[i] {
+				continue
+			}
+			parentIdx, isRef := refs[spec.ParentID]
+			switch {
+			case !isRef:
+				parents[i] = spec.ParentID
+			case failed[parentIdx]:
+				failed[i] = true
+				remaining--
+				errs = append(errs, fmt.Errorf("page %q: parent %q was not created", spec.Title, spec.ParentID))
+				continue
+			case done[parentIdx]:
+				parents[i] = pages[parentIdx].ID
+			default:
+				continue
+			}
+			level = append(level, i)
+		}
+		if len(level) == 0 {
+			// Nothing is ready but pages remain, so the remaining refs form a cycle.
+			for i, spec := range specs {
+				if !done[i] && !failed[i] {
+					errs = append(errs, fmt.Errorf("page %q: parent ref %q forms a cycle", spec.Title, spec.ParentID))
+				}
+			}
+			break
+		}
+
+		levelErrs := make([]error, len(specs))
+		sem := make(chan struct{}, maxConcurrentCreates)
+		var wg sync.WaitGroup
+		for _, i := range level {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				page, _, err := nc.CreatePageIfAbsent(specs[i].Title, specs[i].Content, parents[i])
+				if err != nil {
+					levelErrs[i] = fmt.Errorf("page %q: %w", specs[i].Title, err)
+					return
+				}
+				pages[i] = page
+			}(i)
+		}
+		wg.Wait()
+
+		for _, i := range level {
+			remaining--
+			if levelErrs[i] != nil {
+				failed[i] = true
+				errs = append(errs, levelErrs[i])
+			} else {
+				done[i] = true
+			}
+		}
+	}
+
+	return pages, errors.Join(errs...)
+}
@@ -0,0 +1,105 @@
+package notion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+)
+
+const (
+	// maxRichTextLength is Notion's limit on the content of a single rich_text item.
+	maxRichTextLength = 2000
+	// maxAppendChildren is Notion's limit on blocks appended in one request.
+	maxAppendChildren = 100
+)
+
+// AppendCodeBlock appends code to the page as Notion code blocks with the given language
+// (e.g. "go", "python"; empty means "plain text"). Code longer than Notion's rich-text limit
+// is split on line boundaries across consecutive code blocks.
+func (nc *NotionClient) AppendCodeBlock(pageID, language, code string) error {
+	if language == "" {
+		language = "plain text"
+	}
+	var blocks []map[string]interface{}
+	for _, chunk := range splitCode(code, maxRichTextLength) {
+		blocks = append(blocks, map[string]interface{}{
+			"object": "block",
+			"type":   "code",
+			"code": map[string]interface{}{
+				"language": language,
+				"rich_text": []map[string]interface{}{
+					{"type": "text", "text": map[string]string{"content": chunk}},
+				},
+			},
+		})
+	}
+
+	return nc.appendAllBlocks(pageID, blocks)
+}
+
+// appendAllBlocks appends blocks to pageID in batches of at most maxAppendChildren.
+func (nc *NotionClient) appendAllBlocks(pageID string, blocks []map[string]interface{}) error {
+	for start := 0; start < len(blocks); start += maxAppendChildren {
+		end := start + maxAppendChildren
+		if end > len(blocks) {
+			end = len(blocks)
+		}
+		if err := nc.appendBlocks(pageID, blocks[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendBlocks appends the given blocks as children of pageID.
+func (nc *NotionClient) appendBlocks(pageID string, blocks []map[string]interface{}) error {
+	data, err := json.Marshal(map[string]interface{}{"children": blocks})
+	if err != nil {
+		return fmt.Errorf("failed to marshal append payload: %w", err)
+	}
+	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/blocks/%s/children", nc.BaseURL, pageID), bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("failed to create append request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+nc.Token)
+	req.Header.Add("Notion-Version", nc.APIVersion)
+	req.Header.Add("Content-Type", "application/json")
+	resp, err := nc.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to append blocks: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to append blocks: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
+	}
+	return nil
+}
+
+// splitCode splits code into chunks of at most limit runes, preferring to break after a newline.
+func splitCode(code string, limit int) []string {
+	runes := []rune(code)
+	if len(runes) <= limit {
+		return []string{code}
+	}
+	var chunks []string
+	for len(runes) > limit {
+		cut := limit
+		for i := limit - 1; i > 0; i-- {
+			if runes[i] == '\n' {
+				cut = i + 1
+				break
+			}
+		}
+		chunks = append(chunks, string(runes[:cut]))
+		runes = runes[cut:]
+	}
+	if len(runes) > 0 {
+		chunks = append(chunks, string(runes))
+	}
+	return chunks
+}
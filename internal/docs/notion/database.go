@@ -0,0 +1,150 @@
+package notion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/docs"
+)
+
+// database is the subset of a Notion database object used by the client.
+type database struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// titleProperty decodes the database's top-level "title" rich-text list.
+	titleProperty
+}
+
+// databaseRow is a page returned by a database query. Its title lives in whichever property has type "title".
+type databaseRow struct {
+	ID         string `json:"id"`
+	URL        string `json:"url"`
+	Properties map[string]struct {
+		Type string `json:"type"`
+		titleProperty
+	} `json:"properties"`
+	Parent struct {
+		DatabaseID string `json:"database_id"`
+	} `json:"parent"`
+	LastEditedTime time.Time `json:"last_edited_time"`
+}
+
+// page maps the row to a docs.Page whose parent is the database.
+func (r databaseRow) page() docs.Page {
+	var title string
+	for _, prop := range r.Properties {
+		if prop.Type == "title" {
+			title = prop.text()
+			break
+		}
+	}
+	return docs.Page{
+		ID:         r.ID,
+		Title:      title,
+		URL:        r.URL,
+		ParentID:   r.Parent.DatabaseID,
+		LastEdited: r.LastEditedTime,
+	}
+}
+
+// getDatabase retrieves a database object. ok is false when the ID does not name a database
+// (for example because it is a page), in which case err is nil.
+func (nc *NotionClient) getDatabase(databaseID string) (db database, ok bool, err error) {
+	req, err := http.NewRequest("GET", nc.BaseURL+"/databases/"+databaseID, nil)
+	if err != nil {
+		return database{}, false, fmt.Errorf("failed to create database request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+nc.Token)
+	req.Header.Add("Notion-Version", nc.APIVersion)
+	resp, err := nc.do(req)
+	if err != nil {
+		return database{}, false, fmt.Errorf("failed to get database: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusBadRequest:
+		return database{}, false, nil
+	default:
+		body, _ := ioutil.ReadAll(resp.Body)
+		return database{}, false, fmt.Errorf("failed to get database: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&db); err != nil {
+		return database{}, false, fmt.Errorf("failed to decode database: %w", err)
+	}
+	return db, true, nil
+}
+
+// QueryDatabase returns every entry of a Notion database as a page, following pagination.
+// Entry content is not read; use ReadPage for that.
+func (nc *NotionClient) QueryDatabase(databaseID string) ([]docs.Page, error) {
+	var pages []docs.Page
+	var startCursor interface{} = nil
+	for {
+		payload := map[string]interface{}{}
+		if startCursor != nil {
+			payload["start_cursor"] = startCursor
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal query payload: %w", err)
+		}
+		req, err := http.NewRequest("POST", nc.BaseURL+"/databases/"+databaseID+"/query", bytes.NewBuffer(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create query request: %w", err)
+		}
+		req.Header.Add("Authorization", "Bearer "+nc.Token)
+		req.Header.Add("Notion-Version", nc.APIVersion)
+		req.Header.Add("Content-Type", "application/json")
+		resp, err := nc.do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query database: %w", err)
+		}
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read query response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("database query failed: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
+		}
+
+		var result struct {
+			Results    []databaseRow `json:"results"`
+			HasMore    bool          `json:"has_more"`
+			NextCursor string        `json:"next_cursor"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode query results: %w", err)
+		}
+		for _, row := range result.Results {
+			pages = append(pages, row.page())
+		}
+		if !result.HasMore {
+			break
+		}
+		startCursor = result.NextCursor
+	}
+	return pages, nil
+}
+
+// listDatabasePages lists a database root: the database itself followed by its entries.
+func (nc *NotionClient) listDatabasePages(db database) ([]docs.Page, error) {
+	rows, err := nc.QueryDatabase(db.ID)
+	if err != nil {
+		return nil, err
+	}
+	root := docs.Page{ID: db.ID, Title: db.text(), URL: db.URL}
+	root.Path = root.Title
+	result := []docs.Page{root}
+	for _, row := range rows {
+		row.Path = root.Path + "/" + row.Title
+		result = append(result, row)
+	}
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package notion
+
+import (
+	"fmt"
+
+	"github.com/egobogo/aiagents/internal/docs"
+)
+
+// uncopiedBlockTypes are block types that cannot be recreated by appending them: sub-pages and
+// databases are separate objects, and "unsupported" blocks are not exposed by the API.
+var uncopiedBlockTypes = map[string]bool{
+	"child_page":     true,
+	"child_database": true,
+	"unsupported":    true,
+}
+
+// DuplicatePage creates a page titled newTitle under newParentID (the root when empty) with a copy
+// of the source page's blocks, including nested blocks. Sub-pages of the source are not copied.
+func (nc *NotionClient) DuplicatePage(sourceID, newParentID, newTitle string) (docs.Page, error) {
+	blocks, err := nc.copyBlocks(sourceID)
+	if err != nil {
+		return docs.Page{}, fmt.Errorf("failed to read source page: %w", err)
+	}
+	page, err := nc.createPage(newTitle, newParentID, nil)
+	if err != nil {
+		return docs.Page{}, err
+	}
+	if err := nc.appendAllBlocks(page.ID, blocks); err != nil {
+		return page, fmt.Errorf("failed to copy blocks to %s: %w", page.ID, err)
+	}
+	return page, nil
+}
+
+// copyBlocks returns the children of blockID as blocks ready to be appended elsewhere: read-only
+// fields such as IDs and timestamps are dropped and nested children are copied inline.
+func (nc *NotionClient) copyBlocks(blockID string) ([]map[string]interface{}, error) {
+	blocks, err := nc.listBlockChildren(blockID)
+	if err != nil {
+		return nil, err
+	}
+	var copies []map[string]interface{}
+	for _, block := range blocks {
+		if block.Type == "" || uncopiedBlockTypes[block.Type] {
+			continue
+		}
+		content, _ := block.raw[block.Type].(map[string]interface{})
+		if content == nil {
+			content = map[string]interface{}{}
+		}
+		if block.HasChildren {
+			children, err := nc.copyBlocks(block.ID)
+			if err != nil {
+				return nil, err
+			}
+			if len(children) > 0 {
+				content["children"] = children
+			}
+		}
+		copies = append(copies, map[string]interface{}{
+			"object":   "block",
+			"type":     block.Type,
+			block.Type: content,
+		})
+	}
+	return copies, nil
+}
@@ -0,0 +1,45 @@
+package notion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/docs"
+)
+
+// CreatePageIfAbsent returns the sub-page of parentID (the root when empty) titled title if one exists,
+// and creates it with content otherwise. created reports whether a new page was made. The content of an
+// existing page is neither read nor changed, and its URL is left empty.
+func (nc *NotionClient) CreatePageIfAbsent(title, content, parentID string) (page docs.Page, created bool, err error) {
+	if parentID == "" {
+		parentID = nc.ParentPage
+	}
+	existing, found, err := nc.findChildPage(parentID, title)
+	if err != nil {
+		return docs.Page{}, false, fmt.Errorf("failed to look for existing page %q: %w", title, err)
+	}
+	if found {
+		return existing, false, nil
+	}
+	page, err = nc.CreatePage(title, content, parentID)
+	if err != nil {
+		return docs.Page{}, false, err
+	}
+	return page, true, nil
+}
+
+// findChildPage looks through the child_page blocks of parentID for a page titled title.
+// Titles are compared after trimming surrounding whitespace.
+func (nc *NotionClient) findChildPage(parentID, title string) (docs.Page, bool, error) {
+	title = strings.TrimSpace(title)
+	blocks, err := nc.listBlockChildren(parentID)
+	if err != nil {
+		return docs.Page{}, false, err
+	}
+	for _, block := range blocks {
+		if block.Type == "child_page" && strings.TrimSpace(block.ChildPage.Title) == title {
+			return docs.Page{ID: block.ID, Title: block.ChildPage.Title, ParentID: parentID}, true, nil
+		}
+	}
+	return docs.Page{}, false, nil
+}
@@ -2,14 +2,18 @@ package notion
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/egobogo/aiagents/internal/apierr"
 	"github.com/egobogo/aiagents/internal/docs"
+	"github.com/egobogo/aiagents/internal/httputil"
 )
 
 // NotionClient is a concrete implementation of docs.DocumentationClient using the Notion API in a wiki style.
@@ -19,8 +23,22 @@ type NotionClient struct {
 	BaseURL    string // e.g., "https://api.notion.com/v1"
 	APIVersion string // e.g., "2022-06-28"
 	HTTPClient *http.Client
+	Retry      httputil.RetryPolicy // retries of rate-limited and failing requests; zero sends each request once
+	// BlockTypes lists the block types whose text ReadNestedContent returns.
+	// Empty means paragraphs and bulleted list items.
+	BlockTypes []string
 }
 
+// DefaultMaxDepth is the nesting depth ReadNestedContent reads when none is given.
+const DefaultMaxDepth = 10
+
+// defaultBlockTypes are the block types rendered when BlockTypes is empty.
+var defaultBlockTypes = []string{"paragraph", "bulleted_list_item"}
+
+// ErrRootUnreadable means the root page (ParentPage) could not be read, e.g. because it is not shared
+// with the integration.
+var ErrRootUnreadable = errors.New("failed to read root page")
+
 // NewNotionClient creates a new NotionClient instance.
 func NewNotionClient(token, parentPage string) *NotionClient {
 	return &NotionClient{
@@ -29,12 +47,65 @@ func NewNotionClient(token, parentPage string) *NotionClient {
 		BaseURL:    "https://api.notion.com/v1",
 		APIVersion: "2022-06-28",
 		HTTPClient: &http.Client{},
+		Retry:      httputil.DefaultRetryPolicy,
 	}
 }
 
+// do sends req through HTTPClient, retrying transient failures according to Retry.
+func (nc *NotionClient) do(req *http.Request) (*http.Response, error) {
+	return httputil.Do(req.Context(), nc.HTTPClient, req, nc.Retry)
+}
+
+// titleProperty is the title property of a page: a list of rich-text parts, empty for an untitled page.
+type titleProperty struct {
+	Title []struct {
+		Text struct {
+			Content string `json:"content"`
+		} `json:"text"`
+	} `json:"title"`
+}
+
+// text joins the parts of the title; an untitled page yields "".
+func (t titleProperty) text() string {
+	var b strings.Builder
+	for _, part := range t.Title {
+		b.WriteString(part.Text.Content)
+	}
+	return b.String()
+}
+
+// titlePayload builds the title property for a request. An empty title clears it.
+func titlePayload(title string) map[string]interface{} {
+	parts := []map[string]interface{}{}
+	if title != "" {
+		parts = append(parts, map[string]interface{}{"type": "text", "text": map[string]string{"content": title}})
+	}
+	return map[string]interface{}{"title": parts}
+}
+
 // CreatePage creates a new wiki page as a child of the specified parent page.
 // If parentPageID is an empty string, the page is created under the root.
 func (nc *NotionClient) CreatePage(title string, content string, parentPageID string) (docs.Page, error) {
+	page, err := nc.createPage(title, parentPageID, []map[string]interface{}{
+		{
+			"object": "block",
+			"type":   "paragraph",
+			"paragraph": map[string]interface{}{
+				"rich_text": []map[string]interface{}{
+					{"type": "text", "text": map[string]string{"content": content}},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return docs.Page{}, err
+	}
+	page.Content = content
+	return page, nil
+}
+
+// createPage creates a page with the given title and initial blocks under parentPageID (the root when empty).
+func (nc *NotionClient) createPage(title, parentPageID string, children []map[string]interface{}) (docs.Page, error) {
 	if parentPageID == "" {
 		parentPageID = nc.ParentPage
 	}
@@ -44,24 +115,12 @@ func (nc *NotionClient) CreatePage(title string, content string, parentPageID st
 			"page_id": parentPageID,
 		},
 		"properties": map[string]interface{}{
-			"title": map[string]interface{}{
-				"title": []map[string]interface{}{
-					{"text": map[string]string{"content": title}},
-				},
-			},
-		},
-		"children": []map[string]interface{}{
-			{
-				"object": "block",
-				"type":   "paragraph",
-				"paragraph": map[string]interface{}{
-					"rich_text": []map[string]interface{}{
-						{"type": "text", "text": map[string]string{"content": content}},
-					},
-				},
-			},
+			"title": titlePayload(title),
 		},
 	}
+	if len(children) > 0 {
+		payload["children"] = children
+	}
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return docs.Page{}, fmt.Errorf("failed to marshal payload: %w", err)
@@ -74,38 +133,31 @@ func (nc *NotionClient) CreatePage(title string, content string, parentPageID st
 	req.Header.Add("Notion-Version", nc.APIVersion)
 	req.Header.Add("Content-Type", "application/json")
 
-	resp, err := nc.HTTPClient.Do(req)
+	resp, err := nc.do(req)
 	if err != nil {
 		return docs.Page{}, fmt.Errorf("failed to perform request: %w", err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return docs.Page{}, fmt.Errorf("failed to create page, status: %d, body: %s", resp.StatusCode, string(body))
+		return docs.Page{}, fmt.Errorf("failed to create page: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
 	}
 	var result struct {
 		ID         string `json:"id"`
 		Properties struct {
-			Title struct {
-				Title []struct {
-					Text struct {
-						Content string `json:"content"`
-					} `json:"text"`
-				} `json:"title"`
-			} `json:"title"`
+			Title titleProperty `json:"title"`
 		} `json:"properties"`
 		URL string `json:"url"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return docs.Page{}, fmt.Errorf("failed to decode response: %w", err)
 	}
-	page := docs.Page{
-		ID:      result.ID,
-		Title:   result.Properties.Title.Title[0].Text.Content,
-		Content: content,
-		URL:     result.URL,
-	}
-	return page, nil
+	return docs.Page{
+		ID:       result.ID,
+		Title:    result.Properties.Title.text(),
+		URL:      result.URL,
+		ParentID: parentPageID,
+	}, nil
 }
 
 // UpdatePage updates the content of a page.
@@ -142,14 +194,14 @@ func (nc *NotionClient) UpdatePage(pageID string, content string, replace bool)
 	req.Header.Add("Authorization", "Bearer "+nc.Token)
 	req.Header.Add("Notion-Version", nc.APIVersion)
 	req.Header.Add("Content-Type", "application/json")
-	resp, err := nc.HTTPClient.Do(req)
+	resp, err := nc.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to append new block: %w", err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("failed to append new block, status: %d, body: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to append new block: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
 	}
 	return nil
 }
@@ -157,35 +209,12 @@ func (nc *NotionClient) UpdatePage(pageID string, content string, replace bool)
 // ClearPageContent erases all content blocks of a page except for child_page blocks.
 // It retrieves all child blocks and archives those that are not of type "child_page".
 func (nc *NotionClient) ClearPageContent(pageID string) error {
-	url := fmt.Sprintf("%s/blocks/%s/children", nc.BaseURL, pageID)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request to list blocks: %w", err)
-	}
-	req.Header.Add("Authorization", "Bearer "+nc.Token)
-	req.Header.Add("Notion-Version", nc.APIVersion)
-	resp, err := nc.HTTPClient.Do(req)
+	blocks, err := nc.listBlockChildren(pageID)
 	if err != nil {
 		return fmt.Errorf("failed to list blocks: %w", err)
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("failed to list blocks, status: %d, body: %s", resp.StatusCode, string(body))
-	}
-	var result struct {
-		Results []struct {
-			ID   string `json:"id"`
-			Type string `json:"type"`
-		} `json:"results"`
-		HasMore    bool   `json:"has_more"`
-		NextCursor string `json:"next_cursor"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode blocks: %w", err)
-	}
 	// Archive each block that is not a child_page.
-	for _, block := range result.Results {
+	for _, block := range blocks {
 		if block.Type != "child_page" {
 			patchPayload := map[string]interface{}{
 				"archived": true,
@@ -202,20 +231,51 @@ func (nc *NotionClient) ClearPageContent(pageID string) error {
 			patchReq.Header.Add("Authorization", "Bearer "+nc.Token)
 			patchReq.Header.Add("Notion-Version", nc.APIVersion)
 			patchReq.Header.Add("Content-Type", "application/json")
-			patchResp, err := nc.HTTPClient.Do(patchReq)
+			patchResp, err := nc.do(patchReq)
 			if err != nil {
 				return fmt.Errorf("failed to patch block: %w", err)
 			}
 			patchResp.Body.Close()
 			if patchResp.StatusCode != http.StatusOK {
 				body, _ := ioutil.ReadAll(patchResp.Body)
-				return fmt.Errorf("failed to patch block, status: %d, body: %s", patchResp.StatusCode, string(body))
+				return fmt.Errorf("failed to patch block: %w", apierr.NewStatusError(patchResp.StatusCode, string(body)))
 			}
 		}
 	}
 	return nil
 }
 
+// UpdateTitle renames a page by setting its title property; it works for untitled pages too.
+// An empty newTitle makes the page untitled.
+func (nc *NotionClient) UpdateTitle(pageID, newTitle string) error {
+	payload := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"title": titlePayload(newTitle),
+		},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal title payload: %w", err)
+	}
+	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/pages/%s", nc.BaseURL, pageID), bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("failed to create title request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+nc.Token)
+	req.Header.Add("Notion-Version", nc.APIVersion)
+	req.Header.Add("Content-Type", "application/json")
+	resp, err := nc.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update title: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to update title: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
+	}
+	return nil
+}
+
 // ReadPage retrieves a wiki page by its ID and assembles its full content
 // by collecting the text of its immediate children (and their children, except for child pages).
 func (nc *NotionClient) ReadPage(pageID string) (docs.Page, error) {
@@ -225,14 +285,14 @@ func (nc *NotionClient) ReadPage(pageID string) (docs.Page, error) {
 	}
 	req.Header.Add("Authorization", "Bearer "+nc.Token)
 	req.Header.Add("Notion-Version", nc.APIVersion)
-	resp, err := nc.HTTPClient.Do(req)
+	resp, err := nc.do(req)
 	if err != nil {
 		return docs.Page{}, fmt.Errorf("failed to read page: %w", err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return docs.Page{}, fmt.Errorf("failed to read page, status: %d, body: %s", resp.StatusCode, string(body))
+		return docs.Page{}, fmt.Errorf("failed to read page: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
 	}
 
 	var result struct {
@@ -242,15 +302,10 @@ func (nc *NotionClient) ReadPage(pageID string) (docs.Page, error) {
 			PageID string `json:"page_id,omitempty"`
 		} `json:"parent"`
 		Properties struct {
-			Title struct {
-				Title []struct {
-					Text struct {
-						Content string `json:"content"`
-					} `json:"text"`
-				} `json:"title"`
-			} `json:"title"`
+			Title titleProperty `json:"title"`
 		} `json:"properties"`
-		URL string `json:"url"`
+		URL            string    `json:"url"`
+		LastEditedTime time.Time `json:"last_edited_time"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return docs.Page{}, fmt.Errorf("failed to decode page: %w", err)
@@ -264,11 +319,12 @@ func (nc *NotionClient) ReadPage(pageID string) (docs.Page, error) {
 	}
 	fullContent := strings.Join(collected, "\n")
 	page := docs.Page{
-		ID:       result.ID,
-		Title:    result.Properties.Title.Title[0].Text.Content,
-		URL:      result.URL,
-		ParentID: result.Parent.PageID,
-		Content:  fullContent,
+		ID:         result.ID,
+		Title:      result.Properties.Title.text(),
+		URL:        result.URL,
+		ParentID:   result.Parent.PageID,
+		Content:    fullContent,
+		LastEdited: result.LastEditedTime,
 	}
 	return page, nil
 }
@@ -289,14 +345,14 @@ func (nc *NotionClient) DeletePage(pageID string) error {
 	req.Header.Add("Authorization", "Bearer "+nc.Token)
 	req.Header.Add("Notion-Version", nc.APIVersion)
 	req.Header.Add("Content-Type", "application/json")
-	resp, err := nc.HTTPClient.Do(req)
+	resp, err := nc.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to delete page: %w", err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		body, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete page, status: %d, body: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to delete page: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
 	}
 	return nil
 }
@@ -304,13 +360,13 @@ func (nc *NotionClient) DeletePage(pageID string) error {
 // ListSubPages returns the immediate child pages of a given parent page
 // by filtering the results from the SearchPages method.
 func (nc *NotionClient) ListSubPages(parentPageID string) ([]docs.Page, error) {
-	allPages, err := nc.SearchPages("")
+	allPages, err := nc.SearchPagesUnder(parentPageID, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to search pages: %w", err)
 	}
 	var subPages []docs.Page
 	for _, p := range allPages {
-		if p.ParentID == parentPageID {
+		if sameID(p.ParentID, parentPageID) {
 			subPages = append(subPages, p)
 		}
 	}
@@ -320,14 +376,22 @@ func (nc *NotionClient) ListSubPages(parentPageID string) ([]docs.Page, error) {
 // ListPages recursively lists every page in the wiki hierarchy starting from the root page.
 // It retrieves all pages via the Search API, then builds the full hierarchy by recursively
 // finding and appending each child page (using the ParentID field) to the result.
+// When the root is a database, its entries are listed instead (see QueryDatabase).
 func (nc *NotionClient) ListPages() ([]docs.Page, error) {
-	allPages, err := nc.SearchPages("")
+	db, isDatabase, err := nc.getDatabase(nc.ParentPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect root: %w", err)
+	}
+	if isDatabase {
+		return nc.listDatabasePages(db)
+	}
+	allPages, err := nc.SearchPagesUnder(nc.ParentPage, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to search pages: %w", err)
 	}
 	root, err := nc.ReadPage(nc.ParentPage)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read root page: %w", err)
+		return nil, fmt.Errorf("%w %s: %w", ErrRootUnreadable, nc.ParentPage, err)
 	}
 	root.Path = root.Title
 	var result []docs.Page
@@ -335,7 +399,7 @@ func (nc *NotionClient) ListPages() ([]docs.Page, error) {
 	var addChildren func(parent docs.Page)
 	addChildren = func(parent docs.Page) {
 		for _, p := range allPages {
-			if p.ParentID == parent.ID {
+			if sameID(p.ParentID, parent.ID) {
 				p.Path = parent.Path + "/" + p.Title
 				result = append(result, p)
 				addChildren(p)
@@ -346,9 +410,47 @@ func (nc *NotionClient) ListPages() ([]docs.Page, error) {
 	return result, nil
 }
 
+// SortDirection orders search results by when the pages were last edited.
+type SortDirection string
+
+const (
+	SortAscending  SortDirection = "ascending"
+	SortDescending SortDirection = "descending"
+)
+
+// SearchFilter narrows SearchPagesWithFilter. The zero value returns every page the query matches.
+type SearchFilter struct {
+	TitleContains string        // Case-insensitive text the title must contain
+	TitlePrefix   string        // Case-insensitive text the title must start with
+	SortByEdited  SortDirection // Order by last edit time; empty keeps Notion's relevance order
+}
+
+// matches reports whether a page title passes the title conditions of the filter.
+func (f SearchFilter) matches(title string) bool {
+	title = strings.ToLower(title)
+	if f.TitleContains != "" && !strings.Contains(title, strings.ToLower(f.TitleContains)) {
+		return false
+	}
+	if f.TitlePrefix != "" && !strings.HasPrefix(title, strings.ToLower(f.TitlePrefix)) {
+		return false
+	}
+	return true
+}
+
 // SearchPages uses Notion's official search endpoint to find wiki pages matching the query.
 // This implementation supports pagination to retrieve all pages.
 func (nc *NotionClient) SearchPages(query string) ([]docs.Page, error) {
+	return nc.SearchPagesWithFilter(query, SearchFilter{})
+}
+
+// SearchPagesWithFilter is SearchPages with the results narrowed by filter. The sort is passed to Notion;
+// since Notion's query matching is fuzzy, the title conditions are checked on the results.
+func (nc *NotionClient) SearchPagesWithFilter(query string, filter SearchFilter) ([]docs.Page, error) {
+	switch filter.SortByEdited {
+	case "", SortAscending, SortDescending:
+	default:
+		return nil, fmt.Errorf("invalid sort direction %q", filter.SortByEdited)
+	}
 	var pages []docs.Page
 	var startCursor interface{} = nil
 
@@ -360,6 +462,12 @@ func (nc *NotionClient) SearchPages(query string) ([]docs.Page, error) {
 				"property": "object",
 			},
 		}
+		if filter.SortByEdited != "" {
+			payload["sort"] = map[string]interface{}{
+				"direction": string(filter.SortByEdited),
+				"timestamp": "last_edited_time",
+			}
+		}
 		if startCursor != nil {
 			payload["start_cursor"] = startCursor
 		}
@@ -374,14 +482,14 @@ func (nc *NotionClient) SearchPages(query string) ([]docs.Page, error) {
 		req.Header.Add("Authorization", "Bearer "+nc.Token)
 		req.Header.Add("Notion-Version", nc.APIVersion)
 		req.Header.Add("Content-Type", "application/json")
-		resp, err := nc.HTTPClient.Do(req)
+		resp, err := nc.do(req)
 		if err != nil {
 			return nil, fmt.Errorf("failed to perform search request: %w", err)
 		}
 		defer resp.Body.Close()
 		if resp.StatusCode != http.StatusOK {
 			body, _ := ioutil.ReadAll(resp.Body)
-			return nil, fmt.Errorf("search request failed, status: %d, body: %s", resp.StatusCode, string(body))
+			return nil, fmt.Errorf("search request failed: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
 		}
 
 		var searchResult struct {
@@ -392,15 +500,10 @@ func (nc *NotionClient) SearchPages(query string) ([]docs.Page, error) {
 					PageID string `json:"page_id,omitempty"`
 				} `json:"parent"`
 				Properties struct {
-					Title struct {
-						Title []struct {
-							Text struct {
-								Content string `json:"content"`
-							} `json:"text"`
-						} `json:"title"`
-					} `json:"title"`
+					Title titleProperty `json:"title"`
 				} `json:"properties"`
-				URL string `json:"url"`
+				URL            string    `json:"url"`
+				LastEditedTime time.Time `json:"last_edited_time"`
 			} `json:"results"`
 			HasMore    bool   `json:"has_more"`
 			NextCursor string `json:"next_cursor"`
@@ -409,12 +512,13 @@ func (nc *NotionClient) SearchPages(query string) ([]docs.Page, error) {
 			return nil, fmt.Errorf("failed to decode search results: %w", err)
 		}
 		for _, res := range searchResult.Results {
-			if len(res.Properties.Title.Title) > 0 {
+			if len(res.Properties.Title.Title) > 0 && filter.matches(res.Properties.Title.text()) {
 				page := docs.Page{
-					ID:       res.ID,
-					Title:    res.Properties.Title.Title[0].Text.Content,
-					URL:      res.URL,
-					ParentID: res.Parent.PageID,
+					ID:         res.ID,
+					Title:      res.Properties.Title.text(),
+					URL:        res.URL,
+					ParentID:   res.Parent.PageID,
+					LastEdited: res.LastEditedTime,
 				}
 				pages = append(pages, page)
 			}
@@ -427,22 +531,94 @@ func (nc *NotionClient) SearchPages(query string) ([]docs.Page, error) {
 	return pages, nil
 }
 
+// SearchPagesUnder is SearchPages restricted to descendants of rootID.
+// A page belongs to the subtree when following its parent chain through the visible pages reaches rootID,
+// so pages of other workspaces or unrelated parents the integration can see are left out.
+func (nc *NotionClient) SearchPagesUnder(rootID, query string) ([]docs.Page, error) {
+	allPages, err := nc.SearchPages("")
+	if err != nil {
+		return nil, err
+	}
+	matches := allPages
+	if query != "" {
+		if matches, err = nc.SearchPages(query); err != nil {
+			return nil, err
+		}
+	}
+
+	parents := make(map[string]string, len(allPages))
+	for _, p := range allPages {
+		parents[normalizeID(p.ID)] = normalizeID(p.ParentID)
+	}
+	root := normalizeID(rootID)
+	var scoped []docs.Page
+	for _, p := range matches {
+		if isDescendant(parents, normalizeID(p.ParentID), root) {
+			scoped = append(scoped, p)
+		}
+	}
+	return scoped, nil
+}
+
+// isDescendant walks the parent chain from id and reports whether it reaches root.
+func isDescendant(parents map[string]string, id, root string) bool {
+	seen := make(map[string]bool)
+	for id != "" && !seen[id] {
+		if id == root {
+			return true
+		}
+		seen[id] = true
+		id = parents[id]
+	}
+	return false
+}
+
+// normalizeID strips the dashes Notion may or may not include in page IDs.
+func normalizeID(id string) string {
+	return strings.ReplaceAll(id, "-", "")
+}
+
+// sameID reports whether two Notion IDs name the same page.
+func sameID(a, b string) bool {
+	return normalizeID(a) == normalizeID(b)
+}
+
 // PrintTree returns a string representation of the page hierarchy in a tree-like format.
 // It builds a mapping of parentID -> children and then recursively assembles the tree string.
+// When the root page cannot be read, the pages found under it are shown below a header saying so;
+// if none were found either, the error explains that the root is not accessible.
 func (nc *NotionClient) PrintTree() (string, error) {
 	pages, err := nc.ListPages()
-	if err != nil {
+	rootReadable := true
+	if errors.Is(err, ErrRootUnreadable) {
+		found, searchErr := nc.SearchPagesUnder(nc.ParentPage, "")
+		if searchErr != nil || len(found) == 0 {
+			return "", rootAccessError(nc.ParentPage, err)
+		}
+		pages, rootReadable = found, false
+	} else if err != nil {
 		return "", fmt.Errorf("failed to list pages: %w", err)
 	}
 
-	// Build a map of parentID to its children.
+	// Build a map of parentID to its children. Without the root page, pages whose parent was not found
+	// hang off the root so none are lost.
+	known := make(map[string]bool, len(pages))
+	for _, p := range pages {
+		known[normalizeID(p.ID)] = true
+	}
+	rootID := normalizeID(nc.ParentPage)
 	childrenMap := make(map[string][]docs.Page)
 	var root docs.Page
 	for _, p := range pages {
-		if p.ID == nc.ParentPage {
+		if sameID(p.ID, nc.ParentPage) {
 			root = p
+			continue
+		}
+		parentID := normalizeID(p.ParentID)
+		if !rootReadable && !known[parentID] {
+			parentID = rootID
 		}
-		childrenMap[p.ParentID] = append(childrenMap[p.ParentID], p)
+		childrenMap[parentID] = append(childrenMap[parentID], p)
 	}
 
 	var builder strings.Builder
@@ -465,58 +641,37 @@ func (nc *NotionClient) PrintTree() (string, error) {
 			} else {
 				newPrefix += "│   "
 			}
-			buildTree(child.ID, newPrefix)
+			buildTree(normalizeID(child.ID), newPrefix)
 		}
 	}
 
 	// Build tree starting from the root.
-	builder.WriteString(fmt.Sprintf("%s (ID: %s, URL: %s)\n", root.Title, root.ID, root.URL))
-	buildTree(root.ID, "")
+	if rootReadable {
+		builder.WriteString(fmt.Sprintf("%s (ID: %s, URL: %s)\n", root.Title, root.ID, root.URL))
+	} else {
+		builder.WriteString(fmt.Sprintf("[root page %s could not be read; showing the pages found under it]\n", nc.ParentPage))
+	}
+	buildTree(rootID, "")
 	return builder.String(), nil
 }
 
+// rootAccessError explains a failure to read the root page, pointing at sharing for permission errors.
+func rootAccessError(rootID string, err error) error {
+	if errors.Is(err, apierr.ErrUnauthorized) || errors.Is(err, apierr.ErrNotFound) {
+		return fmt.Errorf("root page %s is not accessible; check that it exists and is shared with the integration: %w", rootID, err)
+	}
+	return fmt.Errorf("failed to list pages: %w", err)
+}
+
 // readBlockContent recursively fetches the content for a given block ID,
 // including any nested child blocks.
 func (nc *NotionClient) readBlockContent(blockID string) (string, error) {
 	var contentBuilder strings.Builder
-	url := fmt.Sprintf("%s/blocks/%s/children", nc.BaseURL, blockID)
-	req, err := http.NewRequest("GET", url, nil)
+	blocks, err := nc.listBlockChildren(blockID)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request for block children: %w", err)
+		return "", err
 	}
-	req.Header.Add("Authorization", "Bearer "+nc.Token)
-	req.Header.Add("Notion-Version", nc.APIVersion)
-	resp, err := nc.HTTPClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to get block children: %w", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		body, _ := ioutil.ReadAll(resp.Body)
-		return "", fmt.Errorf("failed to get block children, status: %d, body: %s", resp.StatusCode, string(body))
-	}
-
-	var blocksResult struct {
-		Results []struct {
-			ID          string `json:"id"`
-			Type        string `json:"type"`
-			HasChildren bool   `json:"has_children"`
-			Paragraph   struct {
-				RichText []struct {
-					Text struct {
-						Content string `json:"content"`
-					} `json:"text"`
-				} `json:"rich_text"`
-			} `json:"paragraph"`
-		} `json:"results"`
-		HasMore    bool   `json:"has_more"`
-		NextCursor string `json:"next_cursor"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&blocksResult); err != nil {
-		return "", fmt.Errorf("failed to decode block children: %w", err)
-	}
-
-	for _, block := range blocksResult.Results {
+	for _, block := range blocks {
 		if block.Type == "paragraph" {
 			for _, rt := range block.Paragraph.RichText {
 				contentBuilder.WriteString(rt.Text.Content)
@@ -535,138 +690,151 @@ func (nc *NotionClient) readBlockContent(blockID string) (string, error) {
 	return contentBuilder.String(), nil
 }
 
-// readBlockContentRecursively fetches the content for a given block ID,
-// including all nested children, handling bullet list items,
-// and avoids duplicate processing using the processed and addedContent maps.
-// It also retries on transient errors (e.g., 502 Bad Gateway) up to maxRetries.
-func (nc *NotionClient) readBlockContentRecursively(blockID string, processed map[string]bool, addedContent map[string]bool) (string, error) {
-	var contentBuilder strings.Builder
-	var startCursor *string = nil
-
-	const maxRetries = 3
-	baseDelay := time.Second
+// ReadNestedContent returns the text of the blocks under blockID, descending at most maxDepth levels
+// (DefaultMaxDepth when maxDepth <= 0). Only the block types in BlockTypes are rendered.
+func (nc *NotionClient) ReadNestedContent(blockID string, maxDepth int) (string, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
+	}
+	return nc.readBlockContentRecursively(blockID, maxDepth, make(map[string]bool))
+}
 
-	for {
-		// Build the URL with pagination if needed.
-		url := fmt.Sprintf("%s/blocks/%s/children", nc.BaseURL, blockID)
-		if startCursor != nil {
-			url = fmt.Sprintf("%s?start_cursor=%s", url, *startCursor)
+// allowedBlockType reports whether blocks of type t are rendered by ReadNestedContent.
+func (nc *NotionClient) allowedBlockType(t string) bool {
+	types := nc.BlockTypes
+	if len(types) == 0 {
+		types = defaultBlockTypes
+	}
+	for _, allowed := range types {
+		if allowed == t {
+			return true
 		}
+	}
+	return false
+}
 
-		var body []byte
-		var respStatus int
-		var err error
-
-		// Retry loop for transient errors.
-		retryCount := 0
-	retryRequest:
-		req, err := http.NewRequest("GET", url, nil)
-		if err != nil {
-			return "", fmt.Errorf("failed to create request for block children: %w", err)
+// readBlockContentRecursively fetches the content for a given block ID,
+// including nested children up to maxDepth levels, handling bullet list items.
+// The processed map guards against reading a block twice; lines with the same text in
+// different blocks are all kept.
+// Transient errors (e.g., 502 Bad Gateway) are retried according to Retry.
+func (nc *NotionClient) readBlockContentRecursively(blockID string, maxDepth int, processed map[string]bool) (string, error) {
+	var contentBuilder strings.Builder
+	blocks, err := nc.listBlockChildren(blockID)
+	if err != nil {
+		return "", err
+	}
+	addLine := func(line string) {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			contentBuilder.WriteString(line)
+			contentBuilder.WriteString("\n")
 		}
-		req.Header.Add("Authorization", "Bearer "+nc.Token)
-		req.Header.Add("Notion-Version", nc.APIVersion)
-
-		resp, err := nc.HTTPClient.Do(req)
-		if err != nil {
-			return "", fmt.Errorf("failed to get block children: %w", err)
+	}
+	for _, block := range blocks {
+		// Skip if already processed.
+		if processed[block.ID] {
+			continue
 		}
-		body, err = ioutil.ReadAll(resp.Body)
-		resp.Body.Close()
-		respStatus = resp.StatusCode
-
-		if respStatus != http.StatusOK {
-			// If 502 and we haven't retried maxRetries times, wait and retry.
-			if respStatus == http.StatusBadGateway && retryCount < maxRetries {
-				retryCount++
-				time.Sleep(baseDelay * time.Duration(retryCount))
-				goto retryRequest
+		processed[block.ID] = true
+
+		// Process content based on block type, skipping types that are not allowed.
+		switch {
+		case !nc.allowedBlockType(block.Type):
+		case block.Type == "paragraph":
+			for _, rt := range block.Paragraph.RichText {
+				addLine(rt.Text.Content)
+			}
+		case block.Type == "bulleted_list_item":
+			for _, rt := range block.BulletedListItem.RichText {
+				addLine("- " + rt.Text.Content)
 			}
-			return "", fmt.Errorf("failed to get block children, status: %d, body: %s", respStatus, string(body))
 		}
 
-		var blocksResult struct {
-			Results []struct {
-				ID          string `json:"id"`
-				Type        string `json:"type"`
-				HasChildren bool   `json:"has_children"`
-				// For paragraph blocks.
-				Paragraph struct {
-					RichText []struct {
-						Text struct {
-							Content string `json:"content"`
-						} `json:"text"`
-					} `json:"rich_text"`
-				} `json:"paragraph"`
-				// For bullet list items.
-				BulletedListItem struct {
-					RichText []struct {
-						Text struct {
-							Content string `json:"content"`
-						} `json:"text"`
-					} `json:"rich_text"`
-				} `json:"bulleted_list_item"`
-			} `json:"results"`
-			HasMore    bool   `json:"has_more"`
-			NextCursor string `json:"next_cursor"`
-		}
-		if err := json.Unmarshal(body, &blocksResult); err != nil {
-			return "", fmt.Errorf("failed to decode block children: %w", err)
+		// Recursively fetch nested children if available and the depth limit allows.
+		if block.HasChildren && maxDepth > 1 {
+			childContent, err := nc.readBlockContentRecursively(block.ID, maxDepth-1, processed)
+			if err != nil {
+				return "", fmt.Errorf("failed to read nested block content: %w", err)
+			}
+			contentBuilder.WriteString(childContent)
 		}
+	}
+	return contentBuilder.String(), nil
+}
 
-		for _, block := range blocksResult.Results {
-			// Skip if already processed.
-			if processed[block.ID] {
-				continue
-			}
-			processed[block.ID] = true
-
-			// Function to add a line if not already added.
-			addLine := func(line string) {
-				line = strings.TrimSpace(line)
-				if line != "" && !addedContent[line] {
-					contentBuilder.WriteString(line)
-					contentBuilder.WriteString("\n")
-					addedContent[line] = true
-				}
-			}
+// collectBlockContent traverses the children of a given block ID and collects their text content
+// in the order encountered.
+// Blocks of type "child_page" are skipped to avoid duplication (their content will be read separately).
+func (nc *NotionClient) collectBlockContent(blockID string, collected *[]string, processed map[string]bool) error {
+	blocks, err := nc.listBlockChildren(blockID)
+	if err != nil {
+		return err
+	}
+	for _, block := range blocks {
+		if processed[block.ID] {
+			continue
+		}
+		processed[block.ID] = true
 
-			// Process content based on block type.
-			switch block.Type {
-			case "paragraph":
-				for _, rt := range block.Paragraph.RichText {
-					addLine(rt.Text.Content)
-				}
-			case "bulleted_list_item":
-				for _, rt := range block.BulletedListItem.RichText {
-					addLine("- " + rt.Text.Content)
-				}
+		switch block.Type {
+		case "paragraph":
+			if line := block.Paragraph.RichText.join(" "); line != "" {
+				*collected = append(*collected, line)
 			}
+		case "bulleted_list_item":
+			*collected = append(*collected, "- "+block.BulletedListItem.RichText.join(" "))
+		}
 
-			// Recursively fetch nested children if available.
-			if block.HasChildren {
-				childContent, err := nc.readBlockContentRecursively(block.ID, processed, addedContent)
-				if err != nil {
-					return "", fmt.Errorf("failed to read nested block content: %w", err)
-				}
-				for _, line := range strings.Split(childContent, "\n") {
-					addLine(line)
-				}
+		// Only traverse children if the block is not a child_page.
+		if block.HasChildren && block.Type != "child_page" {
+			if err := nc.collectBlockContent(block.ID, collected, processed); err != nil {
+				return err
 			}
 		}
+	}
+	return nil
+}
 
-		if !blocksResult.HasMore {
-			break
-		}
-		startCursor = &blocksResult.NextCursor
+// richText is the rich_text array of a text block.
+type richText []struct {
+	Text struct {
+		Content string `json:"content"`
+	} `json:"text"`
+}
+
+// join concatenates the text of the parts, separated by sep.
+func (r richText) join(sep string) string {
+	parts := make([]string, len(r))
+	for i, rt := range r {
+		parts[i] = rt.Text.Content
 	}
-	return contentBuilder.String(), nil
+	return strings.Join(parts, sep)
 }
 
-// collectBlockContent traverses the children of a given block ID (using pagination)
-// and collects their text content in the order encountered.
-// Blocks of type "child_page" are skipped to avoid duplication (their content will be read separately).
-func (nc *NotionClient) collectBlockContent(blockID string, collected *[]string, processed map[string]bool) error {
+// block is a child block as returned by the block children endpoint, decoded into the fields
+// the client reads. raw holds the whole block object for callers that copy blocks.
+type block struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	HasChildren bool   `json:"has_children"`
+	Paragraph   struct {
+		RichText richText `json:"rich_text"`
+	} `json:"paragraph"`
+	BulletedListItem struct {
+		RichText richText `json:"rich_text"`
+	} `json:"bulleted_list_item"`
+	ChildPage struct {
+		Title string `json:"title"`
+	} `json:"child_page"`
+
+	raw map[string]interface{}
+}
+
+// listBlockChildren returns every child block of blockID, following pagination.
+// Transient errors (e.g., 502 Bad Gateway) are retried according to Retry.
+func (nc *NotionClient) listBlockChildren(blockID string) ([]block, error) {
+	var blocks []block
 	var startCursor *string = nil
 	for {
 		url := fmt.Sprintf("%s/blocks/%s/children", nc.BaseURL, blockID)
@@ -675,104 +843,65 @@ func (nc *NotionClient) collectBlockContent(blockID string, collected *[]string,
 		}
 		req, err := http.NewRequest("GET", url, nil)
 		if err != nil {
-			return fmt.Errorf("failed to create request for block children: %w", err)
+			return nil, fmt.Errorf("failed to create request for block children: %w", err)
 		}
 		req.Header.Add("Authorization", "Bearer "+nc.Token)
 		req.Header.Add("Notion-Version", nc.APIVersion)
-
-		resp, err := nc.HTTPClient.Do(req)
+		resp, err := nc.do(req)
 		if err != nil {
-			return fmt.Errorf("failed to get block children: %w", err)
+			return nil, fmt.Errorf("failed to get block children: %w", err)
 		}
 		body, err := ioutil.ReadAll(resp.Body)
 		resp.Body.Close()
 		if err != nil {
-			return fmt.Errorf("failed to read response body: %w", err)
+			return nil, fmt.Errorf("failed to read response body: %w", err)
 		}
 		if resp.StatusCode != http.StatusOK {
-			return fmt.Errorf("failed to get block children, status: %d, body: %s", resp.StatusCode, string(body))
+			return nil, fmt.Errorf("failed to get block children: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
 		}
 
 		var blocksResult struct {
-			Results []struct {
-				ID          string `json:"id"`
-				Type        string `json:"type"`
-				HasChildren bool   `json:"has_children"`
-				// For paragraph blocks.
-				Paragraph struct {
-					RichText []struct {
-						Text struct {
-							Content string `json:"content"`
-						} `json:"text"`
-					} `json:"rich_text"`
-				} `json:"paragraph"`
-				// For bullet list items.
-				BulletedListItem struct {
-					RichText []struct {
-						Text struct {
-							Content string `json:"content"`
-						} `json:"text"`
-					} `json:"rich_text"`
-				} `json:"bulleted_list_item"`
-				// For child pages.
-				ChildPage struct {
-					Title string `json:"title"`
-				} `json:"child_page"`
-			} `json:"results"`
-			HasMore    bool   `json:"has_more"`
-			NextCursor string `json:"next_cursor"`
+			Results    []json.RawMessage `json:"results"`
+			HasMore    bool              `json:"has_more"`
+			NextCursor string            `json:"next_cursor"`
 		}
 		if err := json.Unmarshal(body, &blocksResult); err != nil {
-			return fmt.Errorf("failed to decode block children: %w", err)
+			return nil, fmt.Errorf("failed to decode block children: %w", err)
 		}
-
-		for _, block := range blocksResult.Results {
-			if processed[block.ID] {
-				continue
+		for _, result := range blocksResult.Results {
+			var b block
+			if err := json.Unmarshal(result, &b); err != nil {
+				return nil, fmt.Errorf("failed to decode block: %w", err)
 			}
-			processed[block.ID] = true
-
-			switch block.Type {
-			case "paragraph":
-				var parts []string
-				for _, rt := range block.Paragraph.RichText {
-					parts = append(parts, rt.Text.Content)
-				}
-				line := strings.Join(parts, " ")
-				if line != "" {
-					*collected = append(*collected, line)
-				}
-			case "bulleted_list_item":
-				var parts []string
-				for _, rt := range block.BulletedListItem.RichText {
-					parts = append(parts, rt.Text.Content)
-				}
-				line := "- " + strings.Join(parts, " ")
-				if line != "" {
-					*collected = append(*collected, line)
-				}
-			case "child_page":
-				// Skip traversing child pages to avoid duplication.
-				// Optionally, you could append a placeholder like the child page title:
-				// if block.ChildPage.Title != "" {
-				//     *collected = append(*collected, fmt.Sprintf("[Child Page: %s]", block.ChildPage.Title))
-				// }
-			default:
-				// For any other block type, you could decide how to handle it.
-			}
-
-			// Only traverse children if the block is not a child_page.
-			if block.HasChildren && block.Type != "child_page" {
-				if err := nc.collectBlockContent(block.ID, collected, processed); err != nil {
-					return err
-				}
+			if err := json.Unmarshal(result, &b.raw); err != nil {
+				return nil, fmt.Errorf("failed to decode block: %w", err)
 			}
+			blocks = append(blocks, b)
 		}
 
 		if !blocksResult.HasMore {
-			break
+			return blocks, nil
 		}
 		startCursor = &blocksResult.NextCursor
 	}
+}
+
+// Ping checks the token and connectivity by fetching the parent page.
+func (nc *NotionClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", nc.BaseURL+"/pages/"+nc.ParentPage, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+nc.Token)
+	req.Header.Add("Notion-Version", nc.APIVersion)
+	resp, err := nc.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Notion: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("Notion health check failed: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
+	}
 	return nil
 }
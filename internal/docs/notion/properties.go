@@ -0,0 +1,161 @@
+package notion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+)
+
+// GetPageProperties returns the properties of a page keyed by property name, as decoded from the API.
+// Each value is the raw property object, e.g. {"type": "select", "select": {"name": "Done", ...}};
+// the helpers SelectValue, StatusValue, MultiSelectValues, DateValue, CheckboxValue, NumberValue and
+// RichTextValue read the common kinds.
+func (nc *NotionClient) GetPageProperties(pageID string) (map[string]interface{}, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/pages/%s", nc.BaseURL, pageID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create read request: %w", err)
+	}
+	req.Header.Add("Authorization", "Bearer "+nc.Token)
+	req.Header.Add("Notion-Version", nc.APIVersion)
+	resp, err := nc.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to read page: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
+	}
+	var result struct {
+		Properties map[string]interface{} `json:"properties"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode page properties: %w", err)
+	}
+	if result.Properties == nil {
+		result.Properties = make(map[string]interface{})
+	}
+	return result.Properties, nil
+}
+
+// propertyValue returns the value of the named property when it has the given type.
+func propertyValue(props map[string]interface{}, name, kind string) (interface{}, bool) {
+	prop, ok := props[name].(map[string]interface{})
+	if !ok || prop["type"] != kind {
+		return nil, false
+	}
+	value, ok := prop[kind]
+	return value, ok && value != nil
+}
+
+// optionName returns the name of a select or status option.
+func optionName(option interface{}) (string, bool) {
+	m, ok := option.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	name, ok := m["name"].(string)
+	return name, ok
+}
+
+// SelectValue returns the option chosen in a select property. ok is false when the property is
+// missing, of another type or empty.
+func SelectValue(props map[string]interface{}, name string) (string, bool) {
+	value, ok := propertyValue(props, name, "select")
+	if !ok {
+		return "", false
+	}
+	return optionName(value)
+}
+
+// StatusValue returns the option chosen in a status property.
+func StatusValue(props map[string]interface{}, name string) (string, bool) {
+	value, ok := propertyValue(props, name, "status")
+	if !ok {
+		return "", false
+	}
+	return optionName(value)
+}
+
+// MultiSelectValues returns the options chosen in a multi-select property, in their order on the page.
+func MultiSelectValues(props map[string]interface{}, name string) ([]string, bool) {
+	value, ok := propertyValue(props, name, "multi_select")
+	if !ok {
+		return nil, false
+	}
+	options, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	names := make([]string, 0, len(options))
+	for _, o := range options {
+		if n, ok := optionName(o); ok {
+			names = append(names, n)
+		}
+	}
+	return names, true
+}
+
+// DateValue returns the start of a date property. Dates without a time are returned at midnight UTC.
+func DateValue(props map[string]interface{}, name string) (time.Time, bool) {
+	value, ok := propertyValue(props, name, "date")
+	if !ok {
+		return time.Time{}, false
+	}
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return time.Time{}, false
+	}
+	start, _ := m["start"].(string)
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, start); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// CheckboxValue returns the state of a checkbox property.
+func CheckboxValue(props map[string]interface{}, name string) (bool, bool) {
+	value, ok := propertyValue(props, name, "checkbox")
+	if !ok {
+		return false, false
+	}
+	checked, ok := value.(bool)
+	return checked, ok
+}
+
+// NumberValue returns the value of a number property.
+func NumberValue(props map[string]interface{}, name string) (float64, bool) {
+	value, ok := propertyValue(props, name, "number")
+	if !ok {
+		return 0, false
+	}
+	n, ok := value.(float64)
+	return n, ok
+}
+
+// RichTextValue returns the plain text of a rich-text property.
+func RichTextValue(props map[string]interface{}, name string) (string, bool) {
+	value, ok := propertyValue(props, name, "rich_text")
+	if !ok {
+		return "", false
+	}
+	parts, ok := value.([]interface{})
+	if !ok {
+		return "", false
+	}
+	var b strings.Builder
+	for _, p := range parts {
+		if m, ok := p.(map[string]interface{}); ok {
+			text, _ := m["plain_text"].(string)
+			b.WriteString(text)
+		}
+	}
+	return b.String(), true
+}
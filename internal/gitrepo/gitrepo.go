@@ -2,15 +2,21 @@ package gitrepo
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/egobogo/aiagents/internal/apierr"
 	"github.com/go-git/go-git/v5"                         // go-git library
+	"github.com/go-git/go-git/v5/plumbing"                // for commit hashes
 	"github.com/go-git/go-git/v5/plumbing/object"         // for commit signatures
+	"github.com/go-git/go-git/v5/plumbing/storer"         // for stopping log iteration
+	"github.com/go-git/go-git/v5/plumbing/transport"      // for transport error values
 	"github.com/go-git/go-git/v5/plumbing/transport/http" // for basic auth
 )
 
@@ -21,6 +27,40 @@ type GitClient struct {
 	Repo     *git.Repository
 }
 
+// codeExtensions lists the file extensions treated as code (and docs) when walking the repository.
+var codeExtensions = []string{".go", ".py", ".js", ".ts", ".java", ".rb", ".cs", ".cpp", ".c", ".md"}
+
+// isCodeFile reports whether the file name has one of the codeExtensions.
+func isCodeFile(name string) bool {
+	ext := filepath.Ext(name)
+	for _, allowed := range codeExtensions {
+		if strings.EqualFold(ext, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// skippedDirs lists the directories left out when walking the repository for code files.
+var skippedDirs = []string{".git", "vendor"}
+
+// isSkippedDir reports whether a directory with the given name is left out of code file walks.
+func isSkippedDir(name string) bool {
+	return slices.Contains(skippedDirs, name)
+}
+
+// ErrPathOutsideRepo is returned when a relative path would resolve outside the repository.
+var ErrPathOutsideRepo = errors.New("path outside repository")
+
+// CommitInfo summarizes a commit of the repository history.
+type CommitInfo struct {
+	SHA     string
+	Author  string
+	Email   string
+	Message string
+	Time    time.Time
+}
+
 // RepoFile represents a single file within the repository in JSON form.
 type RepoFile struct {
 	Path    string `json:"path"`
@@ -60,11 +100,65 @@ func NewGitClient(repoURL, repoPath string) (*GitClient, error) {
 }
 
 // WriteFile writes content to a file relative to the repository path.
+// Paths that are absolute or climb out of the repository with ".." return ErrPathOutsideRepo.
 func (g *GitClient) WriteFile(fileName string, content []byte) error {
-	fullPath := filepath.Join(g.RepoPath, fileName)
+	fullPath, err := g.repoFilePath(fileName)
+	if err != nil {
+		return err
+	}
 	return os.WriteFile(fullPath, content, 0644)
 }
 
+// GetFileContent returns the content of a single file, given relative to the repository path.
+// Paths that are absolute or climb out of the repository with ".." return ErrPathOutsideRepo.
+func (g *GitClient) GetFileContent(relPath string) ([]byte, error) {
+	fullPath, err := g.repoFilePath(relPath)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", relPath, err)
+	}
+	return content, nil
+}
+
+// ListDir returns the entries of a directory, given relative to the repository path; "" or "." lists the root.
+// The .git directory is left out. Paths escaping the repository return ErrPathOutsideRepo.
+func (g *GitClient) ListDir(relPath string) ([]os.FileInfo, error) {
+	fullPath, err := g.repoFilePath(relPath)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", relPath, err)
+	}
+	infos := make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Name() == ".git" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", filepath.Join(relPath, entry.Name()), err)
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// repoFilePath joins a relative path onto the repository path, rejecting paths that leave the repository.
+func (g *GitClient) repoFilePath(relPath string) (string, error) {
+	if relPath == "" {
+		relPath = "."
+	}
+	if !filepath.IsLocal(relPath) {
+		return "", fmt.Errorf("%q: %w", relPath, ErrPathOutsideRepo)
+	}
+	return filepath.Join(g.RepoPath, relPath), nil
+}
+
 // CommitChanges stages all changes in the repository and commits them with the provided commit message and author info.
 func (g *GitClient) CommitChanges(commitMessage, authorName, authorEmail string) error {
 	worktree, err := g.Repo.Worktree()
@@ -93,6 +187,8 @@ func (g *GitClient) CommitChanges(commitMessage, authorName, authorEmail string)
 }
 
 // PushChanges pushes commits to the remote repository using basic authentication.
+// Pushing with nothing new returns an error wrapping apierr.ErrAlreadyUpToDate, and a push the
+// remote rejected because its branch moved ahead returns one wrapping ErrPushRejected.
 func (g *GitClient) PushChanges(username, token string) error {
 	err := g.Repo.Push(&git.PushOptions{
 		Auth: &http.BasicAuth{
@@ -101,7 +197,7 @@ func (g *GitClient) PushChanges(username, token string) error {
 		},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to push changes: %w", err)
+		return fmt.Errorf("failed to push changes: %w", gitErr(err))
 	}
 	return nil
 }
@@ -109,42 +205,119 @@ func (g *GitClient) PushChanges(username, token string) error {
 // GatherRepoInfo walks the repository path and gathers code file information.
 // It returns a JSON string of the repository snapshot, a schema describing its structure, and an error.
 func (g *GitClient) GatherRepoInfo() (string, interface{}, error) {
-	// Define types for our repo snapshot.
-	type RepoFile struct {
-		Path    string `json:"path"`
-		Content string `json:"content"`
+	files, err := g.snapshotFiles()
+	if err != nil {
+		return "", nil, err
 	}
-	type RepoSnapshot struct {
-		Files []RepoFile `json:"files"`
+	snapshot := RepoSnapshot{Files: files}
+
+	// Marshal the snapshot into a formatted JSON string.
+	repoJSONBytes, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal repo snapshot: %w", err)
 	}
 
-	snapshot := RepoSnapshot{}
+	// Define the schema describing the structure of the repo JSON.
+	schema := map[string]interface{}{
+		"files": []map[string]string{
+			{
+				"path":    "string",
+				"content": "string",
+			},
+		},
+	}
+
+	return string(repoJSONBytes), schema, nil
+}
+
+// GatherRepoInfoChunks gathers the same files as GatherRepoInfo but splits them into snapshots whose
+// compact JSON encoding is at most maxBytes, so each can be passed to the model on its own.
+// Files are never split: a file too large for maxBytes gets a snapshot of its own that exceeds the limit.
+func (g *GitClient) GatherRepoInfoChunks(maxBytes int) ([]RepoSnapshot, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("invalid chunk size %d", maxBytes)
+	}
+	files, err := g.snapshotFiles()
+	if err != nil {
+		return nil, err
+	}
+	return chunkFiles(files, maxBytes)
+}
+
+// GatherFileChunks is GatherRepoInfoChunks for the given files only, e.g. those returned by ChangedFiles.
+// Paths may be absolute or relative to the repository.
+func (g *GitClient) GatherFileChunks(paths []string, maxBytes int) ([]RepoSnapshot, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("invalid chunk size %d", maxBytes)
+	}
+	files := make([]RepoFile, 0, len(paths))
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(g.RepoPath, path)
+		}
+		relativePath, err := filepath.Rel(g.RepoPath, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", relativePath, err)
+		}
+		files = append(files, RepoFile{Path: relativePath, Content: string(content)})
+	}
+	return chunkFiles(files, maxBytes)
+}
+
+// chunkFiles splits files into snapshots whose compact JSON encoding is at most maxBytes.
+func chunkFiles(files []RepoFile, maxBytes int) ([]RepoSnapshot, error) {
+	// emptySize is the length of {"files":[]}; each further file adds its encoding and a comma.
+	emptySize := len(`{"files":[]}`)
+	var chunks []RepoSnapshot
+	var current RepoSnapshot
+	size := emptySize
+	for _, f := range files {
+		encoded, err := json.Marshal(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", f.Path, err)
+		}
+		added := len(encoded)
+		if len(current.Files) > 0 {
+			added++
+		}
+		if len(current.Files) > 0 && size+added > maxBytes {
+			chunks = append(chunks, current)
+			current = RepoSnapshot{}
+			size = emptySize
+			added = len(encoded)
+		}
+		current.Files = append(current.Files, f)
+		size += added
+	}
+	if len(current.Files) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks, nil
+}
 
-	// Walk the repository folder.
+// snapshotFiles walks the repository, skipping the skippedDirs, and reads every source file in walk order.
+func (g *GitClient) snapshotFiles() ([]RepoFile, error) {
+	var files []RepoFile
 	err := filepath.Walk(g.RepoPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		// Skip .git folder.
-		if info.IsDir() && info.Name() == ".git" {
+		// Skip .git and vendor directories.
+		if info.IsDir() && isSkippedDir(info.Name()) {
 			return filepath.SkipDir
 		}
 		// Filter: only process code files.
-		if !info.IsDir() && (strings.HasSuffix(info.Name(), ".go") ||
-			strings.HasSuffix(info.Name(), ".py") ||
-			strings.HasSuffix(info.Name(), ".js") ||
-			strings.HasSuffix(info.Name(), ".ts") ||
-			strings.HasSuffix(info.Name(), ".java") ||
-			strings.HasSuffix(info.Name(), ".rb") ||
-			strings.HasSuffix(info.Name(), ".cs") ||
-			strings.HasSuffix(info.Name(), ".cpp") ||
-			strings.HasSuffix(info.Name(), ".c")) {
+		if !info.IsDir() && isCodeFile(info.Name()) {
 			relativePath, _ := filepath.Rel(g.RepoPath, path)
 			content, err := ioutil.ReadFile(path)
 			if err != nil {
 				return fmt.Errorf("failed to read file %s: %w", relativePath, err)
 			}
-			snapshot.Files = append(snapshot.Files, RepoFile{
+			files = append(files, RepoFile{
 				Path:    relativePath,
 				Content: string(content),
 			})
@@ -152,26 +325,9 @@ func (g *GitClient) GatherRepoInfo() (string, interface{}, error) {
 		return nil
 	})
 	if err != nil {
-		return "", nil, fmt.Errorf("error walking repo path: %w", err)
-	}
-
-	// Marshal the snapshot into a formatted JSON string.
-	repoJSONBytes, err := json.MarshalIndent(snapshot, "", "  ")
-	if err != nil {
-		return "", nil, fmt.Errorf("failed to marshal repo snapshot: %w", err)
-	}
-
-	// Define the schema describing the structure of the repo JSON.
-	schema := map[string]interface{}{
-		"files": []map[string]string{
-			{
-				"path":    "string",
-				"content": "string",
-			},
-		},
+		return nil, fmt.Errorf("error walking repo path: %w", err)
 	}
-
-	return string(repoJSONBytes), schema, nil
+	return files, nil
 }
 
 // PullChanges pulls the latest changes from the remote repository.
@@ -187,20 +343,34 @@ func (g *GitClient) PullChanges(username, token string) error {
 			Password: token,
 		},
 	})
-	// If there are no changes to pull, go-git returns an error message "already up-to-date"
-	if err != nil && err.Error() == "already up-to-date" {
+	// Having nothing to pull is not a failure.
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf("failed to pull changes: %w", err)
+		return fmt.Errorf("failed to pull changes: %w", gitErr(err))
 	}
 	return nil
 }
 
+// gitErr tags go-git errors with the matching apierr sentinel so callers can use errors.Is.
+func gitErr(err error) error {
+	switch {
+	case errors.Is(err, git.NoErrAlreadyUpToDate):
+		return fmt.Errorf("%w: %w", apierr.ErrAlreadyUpToDate, err)
+	case errors.Is(err, transport.ErrAuthenticationRequired), errors.Is(err, transport.ErrAuthorizationFailed):
+		return fmt.Errorf("%w: %w", apierr.ErrUnauthorized, err)
+	case errors.Is(err, transport.ErrRepositoryNotFound):
+		return fmt.Errorf("%w: %w", apierr.ErrNotFound, err)
+	case isPushRejected(err):
+		return fmt.Errorf("%w: %w", ErrPushRejected, err)
+	}
+	return err
+}
+
 // ListCodeFiles returns a slice of paths for all code files in the repository.
 // Allowed extensions can be adjusted as needed.
 func (g *GitClient) ListCodeFiles() ([]string, error) {
-	allowedExtensions := []string{".go", ".py", ".js", ".ts", ".java", ".rb", ".cs", ".cpp", ".c", ".md"}
 	var files []string
 	err := filepath.Walk(g.RepoPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -208,17 +378,13 @@ func (g *GitClient) ListCodeFiles() ([]string, error) {
 		}
 		// Skip .git and vendor directories.
 		if info.IsDir() {
-			if info.Name() == ".git" || info.Name() == "vendor" {
+			if isSkippedDir(info.Name()) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
-		ext := filepath.Ext(info.Name())
-		for _, allowed := range allowedExtensions {
-			if strings.EqualFold(ext, allowed) {
-				files = append(files, path)
-				break
-			}
+		if isCodeFile(info.Name()) {
+			files = append(files, path)
 		}
 		return nil
 	})
@@ -228,7 +394,6 @@ func (g *GitClient) ListCodeFiles() ([]string, error) {
 // PrintTree returns a string representation of the repository's file tree,
 // including only directories and code files.
 func (g *GitClient) PrintTree() (string, error) {
-	allowedExtensions := []string{".go", ".py", ".js", ".ts", ".java", ".rb", ".cs", ".cpp", ".c", ".md"}
 	var treeLines []string
 
 	err := filepath.Walk(g.RepoPath, func(path string, info os.FileInfo, err error) error {
@@ -237,7 +402,7 @@ func (g *GitClient) PrintTree() (string, error) {
 		}
 
 		// Skip vendor and .git directories
-		if info.IsDir() && (info.Name() == ".git" || info.Name() == "vendor") {
+		if info.IsDir() && isSkippedDir(info.Name()) {
 			return filepath.SkipDir
 		}
 
@@ -248,18 +413,8 @@ func (g *GitClient) PrintTree() (string, error) {
 		}
 
 		// If it's a file, only include if it has an allowed extension.
-		if !info.IsDir() {
-			ext := filepath.Ext(info.Name())
-			allowed := false
-			for _, a := range allowedExtensions {
-				if strings.EqualFold(ext, a) {
-					allowed = true
-					break
-				}
-			}
-			if !allowed {
-				return nil
-			}
+		if !info.IsDir() && !isCodeFile(info.Name()) {
+			return nil
 		}
 
 		// Compute indentation based on depth (number of path separators).
@@ -274,3 +429,86 @@ func (g *GitClient) PrintTree() (string, error) {
 
 	return strings.Join(treeLines, "\n"), nil
 }
+
+// HeadCommit returns the SHA of the commit currently checked out.
+func (g *GitClient) HeadCommit() (string, error) {
+	ref, err := g.Repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	return ref.Hash().String(), nil
+}
+
+// Log returns up to n commits reachable from HEAD, newest first. A non-positive n returns the whole history.
+func (g *GitClient) Log(n int) ([]CommitInfo, error) {
+	ref, err := g.Repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	iter, err := g.Repo.Log(&git.LogOptions{From: ref.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	defer iter.Close()
+
+	var commits []CommitInfo
+	err = iter.ForEach(func(c *object.Commit) error {
+		if n > 0 && len(commits) >= n {
+			return storer.ErrStop
+		}
+		commits = append(commits, CommitInfo{
+			SHA:     c.Hash.String(),
+			Author:  c.Author.Name,
+			Email:   c.Author.Email,
+			Message: strings.TrimSpace(c.Message),
+			Time:    c.Author.When,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log: %w", err)
+	}
+	return commits, nil
+}
+
+// ChangedFiles returns the absolute paths of code files added or modified between two commits.
+// Deleted files are not returned. If fromSHA is empty, every code file in the repository is returned.
+func (g *GitClient) ChangedFiles(fromSHA, toSHA string) ([]string, error) {
+	if fromSHA == "" {
+		return g.ListCodeFiles()
+	}
+	fromTree, err := g.commitTree(fromSHA)
+	if err != nil {
+		return nil, err
+	}
+	toTree, err := g.commitTree(toSHA)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(fromTree, toTree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff commits %s..%s: %w", fromSHA, toSHA, err)
+	}
+	var files []string
+	for _, change := range changes {
+		name := change.To.Name
+		if name == "" || !isCodeFile(name) {
+			continue
+		}
+		files = append(files, filepath.Join(g.RepoPath, name))
+	}
+	return files, nil
+}
+
+// commitTree resolves a commit SHA to its tree.
+func (g *GitClient) commitTree(sha string) (*object.Tree, error) {
+	commit, err := g.Repo.CommitObject(plumbing.NewHash(sha))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", sha, err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree of commit %s: %w", sha, err)
+	}
+	return tree, nil
+}
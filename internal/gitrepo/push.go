@@ -0,0 +1,252 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// ErrPushRejected is wrapped by PushChanges errors when the remote refused the push because its
+// branch moved ahead of the local one.
+var ErrPushRejected = errors.New("push rejected")
+
+// ErrPushConflict is returned when local commits and new remote commits change the same files,
+// so the local work cannot be replayed on top of the remote without a manual merge.
+var ErrPushConflict = errors.New("push conflict")
+
+// DefaultPushAttempts is how many pushes PushWithRetry makes when given a non-positive count.
+const DefaultPushAttempts = 3
+
+// pushRetryBackoff is the wait before the first retry; later retries wait proportionally longer.
+const pushRetryBackoff = 500 * time.Millisecond
+
+// PushWithRetry pushes like PushChanges, but when the remote rejects the push because another
+// client pushed first, it replays the local commits on top of the remote branch and tries again,
+// up to attempts pushes in total. Overlapping changes stop the retries with an error wrapping ErrPushConflict.
+func (g *GitClient) PushWithRetry(username, token string, attempts int) error {
+	if attempts <= 0 {
+		attempts = DefaultPushAttempts
+	}
+	for attempt := 1; ; attempt++ {
+		err := g.PushChanges(username, token)
+		if err == nil || !errors.Is(err, ErrPushRejected) || attempt >= attempts {
+			return err
+		}
+		if err := g.rebaseOnRemote(username, token); err != nil {
+			return fmt.Errorf("failed to rebase before retrying push: %w", err)
+		}
+		time.Sleep(pushRetryBackoff * time.Duration(attempt))
+	}
+}
+
+// isPushRejected reports whether a push failed because the remote branch moved ahead.
+// go-git reports rejected pushes with an unwrapped "non-fast-forward update" error, so only the
+// pull-side ErrNonFastForwardUpdate can be matched by type.
+func isPushRejected(err error) bool {
+	return errors.Is(err, git.ErrNonFastForwardUpdate) || strings.Contains(err.Error(), git.ErrNonFastForwardUpdate.Error())
+}
+
+// rebaseOnRemote fetches origin and replays the commits of the current branch that are not on
+// the remote branch on top of it. It refuses to run with uncommitted changes or when the
+// replayed commits touch files the remote also changed. If a replay fails, the branch is reset
+// back to the commit it pointed at before.
+func (g *GitClient) rebaseOnRemote(username, token string) error {
+	err := g.Repo.Fetch(&git.FetchOptions{
+		RemoteName: "origin",
+		Auth:       &http.BasicAuth{Username: username, Password: token},
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return fmt.Errorf("failed to fetch: %w", gitErr(err))
+	}
+
+	head, err := g.Repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	if !head.Name().IsBranch() {
+		return fmt.Errorf("HEAD is not on a branch")
+	}
+	remoteRef, err := g.Repo.Reference(plumbing.NewRemoteReferenceName("origin", head.Name().Short()), true)
+	if err != nil {
+		return fmt.Errorf("failed to find remote branch: %w", err)
+	}
+	local, err := g.Repo.CommitObject(head.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to read local commit: %w", err)
+	}
+	remote, err := g.Repo.CommitObject(remoteRef.Hash())
+	if err != nil {
+		return fmt.Errorf("failed to read remote commit: %w", err)
+	}
+	bases, err := local.MergeBase(remote)
+	if err != nil {
+		return fmt.Errorf("failed to find merge base: %w", err)
+	}
+	if len(bases) == 0 {
+		return fmt.Errorf("local and remote branches share no history")
+	}
+	base := bases[0]
+	if base.Hash == remote.Hash {
+		// The remote has nothing new; the next push fast-forwards.
+		return nil
+	}
+
+	worktree, err := g.Repo.Worktree()
+	if err != nil {
+		return fmt.Errorf("failed to get worktree: %w", err)
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return fmt.Errorf("failed to read worktree status: %w", err)
+	}
+	if !status.IsClean() {
+		return fmt.Errorf("worktree has uncommitted changes")
+	}
+
+	ownCommits, err := commitsSince(local, base.Hash)
+	if err != nil {
+		return err
+	}
+	ownFiles := make(map[string]bool)
+	for _, c := range ownCommits {
+		changes, err := commitChanges(c)
+		if err != nil {
+			return err
+		}
+		for _, ch := range changes {
+			ownFiles[ch.From.Name] = true
+			ownFiles[ch.To.Name] = true
+		}
+	}
+	remoteChanges, err := treeChanges(base, remote)
+	if err != nil {
+		return err
+	}
+	var conflicts []string
+	for _, ch := range remoteChanges {
+		for _, name := range []string{ch.From.Name, ch.To.Name} {
+			if name != "" && ownFiles[name] {
+				conflicts = append(conflicts, name)
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return fmt.Errorf("%w: local and remote both changed %s", ErrPushConflict, strings.Join(conflicts, ", "))
+	}
+
+	original := head.Hash()
+	if err := worktree.Reset(&git.ResetOptions{Commit: remote.Hash, Mode: git.HardReset}); err != nil {
+		return fmt.Errorf("failed to reset to remote branch: %w", err)
+	}
+	for _, c := range ownCommits {
+		if err := g.replayCommit(worktree, c); err != nil {
+			err = fmt.Errorf("failed to replay commit %s: %w", c.Hash, err)
+			if resetErr := worktree.Reset(&git.ResetOptions{Commit: original, Mode: git.HardReset}); resetErr != nil {
+				return errors.Join(err, fmt.Errorf("failed to restore %s: %w", original, resetErr))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// commitsSince returns the commits from stop (exclusive) to tip, oldest first, following first parents.
+func commitsSince(tip *object.Commit, stop plumbing.Hash) ([]*object.Commit, error) {
+	var commits []*object.Commit
+	for c := tip; c.Hash != stop; {
+		if c.NumParents() != 1 {
+			return nil, fmt.Errorf("cannot replay commit %s with %d parents", c.Hash, c.NumParents())
+		}
+		commits = append(commits, c)
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read parent of %s: %w", c.Hash, err)
+		}
+		c = parent
+	}
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, nil
+}
+
+// commitChanges returns the file changes a single-parent commit introduced.
+func commitChanges(c *object.Commit) (object.Changes, error) {
+	parent, err := c.Parent(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parent of %s: %w", c.Hash, err)
+	}
+	return treeChanges(parent, c)
+}
+
+// treeChanges returns the file changes between two commits.
+func treeChanges(from, to *object.Commit) (object.Changes, error) {
+	fromTree, err := from.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree of %s: %w", from.Hash, err)
+	}
+	toTree, err := to.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree of %s: %w", to.Hash, err)
+	}
+	changes, err := fromTree.Diff(toTree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff %s and %s: %w", from.Hash, to.Hash, err)
+	}
+	return changes, nil
+}
+
+// replayCommit applies the file changes of c to the worktree and commits them with c's message and author.
+func (g *GitClient) replayCommit(worktree *git.Worktree, c *object.Commit) error {
+	changes, err := commitChanges(c)
+	if err != nil {
+		return err
+	}
+	for _, ch := range changes {
+		if ch.From.Name != "" && ch.From.Name != ch.To.Name {
+			if _, err := worktree.Remove(ch.From.Name); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", ch.From.Name, err)
+			}
+		}
+		if ch.To.Name == "" {
+			continue
+		}
+		file, err := c.File(ch.To.Name)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", ch.To.Name, err)
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", ch.To.Name, err)
+		}
+		mode, err := file.Mode.ToOSFileMode()
+		if err != nil {
+			mode = 0644
+		}
+		fullPath := filepath.Join(g.RepoPath, ch.To.Name)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", ch.To.Name, err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), mode.Perm()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", ch.To.Name, err)
+		}
+		if _, err := worktree.Add(ch.To.Name); err != nil {
+			return fmt.Errorf("failed to stage %s: %w", ch.To.Name, err)
+		}
+	}
+	author, committer := c.Author, c.Committer
+	if _, err := worktree.Commit(c.Message, &git.CommitOptions{Author: &author, Committer: &committer}); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+// Package httputil holds the HTTP helpers shared by the external service clients.
+package httputil
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Do retries a request. The zero value sends the request once.
+type RetryPolicy struct {
+	// MaxRetries is how many times a request is retried after the first attempt.
+	MaxRetries int
+	// BaseDelay is the wait before the first retry; each later retry waits twice as long.
+	BaseDelay time.Duration
+	// MaxDelay caps a single wait, including one requested through Retry-After. Zero means no cap.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is the policy the clients are created with.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+// Retryable reports whether a response with the given status code is worth retrying:
+// rate limiting (429) and temporary server failures (500, 502, 503, 504).
+func Retryable(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// Do sends req with client, retrying transport errors and retryable responses according to policy.
+// A 429 waits for the duration in its Retry-After header when present; other retries back off
+// exponentially. Waiting stops as soon as ctx is done, returning ctx.Err().
+// When the retries run out, the last response is returned as is so the caller can report its status.
+// A request whose body cannot be replayed (req.GetBody is nil) is sent only once.
+func Do(ctx context.Context, client *http.Client, req *http.Request, policy RetryPolicy) (*http.Response, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		policy.MaxRetries = 0
+	}
+	for attempt := 0; ; attempt++ {
+		attemptReq := req.WithContext(ctx)
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := client.Do(attemptReq)
+		if ctx.Err() != nil {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return nil, ctx.Err()
+		}
+		if attempt >= policy.MaxRetries || (err == nil && !Retryable(resp.StatusCode)) {
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt)
+		if err == nil {
+			if after, ok := retryAfter(resp); ok {
+				wait = policy.cap(after)
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns the wait before retry number attempt+1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	return p.cap(p.BaseDelay << attempt)
+}
+
+// cap limits d to MaxDelay when one is set.
+func (p RetryPolicy) cap(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && (d > p.MaxDelay || d < 0) {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// retryAfter reads the Retry-After header of a 429 response, given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
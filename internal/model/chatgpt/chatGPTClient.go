@@ -2,7 +2,9 @@ package chatgpt
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -13,16 +15,32 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/budget"
+	"github.com/egobogo/aiagents/internal/httputil"
 	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/filesapi"
 	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+	"github.com/egobogo/aiagents/internal/ratelimit"
+	"github.com/egobogo/aiagents/internal/respcache"
 )
 
 // ChatGPTClient implements the ModelClient interface using the OpenAI Chat API.
 type ChatGPTClient struct {
-	APIKey        string
-	Model         string
-	Temperature   float64
-	VectorStorage *vectorstorage.Client // optional vector storage client
+	APIKey         string
+	Model          string
+	FallbackModels []string // models tried in order when the primary model is unavailable
+	EmbeddingModel string   // model used by Embeddings; DefaultEmbeddingModel when empty
+	Temperature    float64
+	VectorStorage  *vectorstorage.Client // optional vector storage client
+	HTTPClient     *http.Client
+	Budget         *budget.BudgetGuard  // optional spend meter, may be shared with other clients
+	Limiter        *ratelimit.Limiter   // optional request rate cap, may be shared with other clients
+	Cache          respcache.Cache      // optional response cache; requests with NoCache set bypass it
+	Retry          httputil.RetryPolicy // retries of rate-limited and failing requests; zero sends each request once
+	Interceptors   []ChatInterceptor    // optional middleware around every chat request; see Use
+	// ReasoningEffort is sent to reasoning models when a request sets no Reasoning; empty sends none.
+	ReasoningEffort string
 }
 
 // NewChatGPTClient creates a new ChatGPTClient.
@@ -31,13 +49,69 @@ func NewChatGPTClient(apiKey, model string, vsClient *vectorstorage.Client) *Cha
 		model = "gpt-4o-mini"
 	}
 	return &ChatGPTClient{
-		APIKey:        apiKey,
-		Model:         model,
-		Temperature:   0.7,
-		VectorStorage: vsClient,
+		APIKey:         apiKey,
+		Model:          model,
+		EmbeddingModel: DefaultEmbeddingModel,
+		Temperature:    0.7,
+		VectorStorage:  vsClient,
+		HTTPClient:     &http.Client{},
+		Retry:          httputil.DefaultRetryPolicy,
 	}
 }
 
+// modelUnavailableError reports that the requested model could not serve the request
+// (retired, unknown, overloaded or failing on the server side).
+type modelUnavailableError struct {
+	Model      string
+	StatusCode int
+	Body       string
+}
+
+func (e *modelUnavailableError) Error() string {
+	return fmt.Sprintf("model %s unavailable, status: %d, response: %s", e.Model, e.StatusCode, e.Body)
+}
+
+// Unwrap returns the apierr sentinel matching the status code, if any.
+func (e *modelUnavailableError) Unwrap() error {
+	return apierr.FromStatus(e.StatusCode)
+}
+
+// isModelUnavailable reports whether a response status means another model may succeed.
+func isModelUnavailable(statusCode int) bool {
+	return statusCode == http.StatusNotFound || statusCode >= http.StatusInternalServerError
+}
+
+// httpClient returns the configured HTTP client, falling back to a default one,
+// throttled by the client's Limiter when one is set.
+func (c *ChatGPTClient) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return c.Limiter.Wrap(&http.Client{})
+	}
+	return c.Limiter.Wrap(c.HTTPClient)
+}
+
+// do sends req through the HTTP client, retrying transient failures according to Retry.
+func (c *ChatGPTClient) do(req *http.Request) (*http.Response, error) {
+	return httputil.Do(req.Context(), c.httpClient(), req, c.Retry)
+}
+
+// modelChain returns the primary model followed by the fallback models, without duplicates.
+func (c *ChatGPTClient) modelChain(primary string) []string {
+	if primary == "" {
+		primary = c.Model
+	}
+	chain := []string{primary}
+	seen := map[string]bool{primary: true}
+	for _, m := range c.FallbackModels {
+		if m == "" || seen[m] {
+			continue
+		}
+		seen[m] = true
+		chain = append(chain, m)
+	}
+	return chain
+}
+
 // PollUploadedFile polls the file endpoint until the file is available.
 func (c *ChatGPTClient) pollUploadedFile(fileID string) (model.File, error) {
 	timeout := time.Now().Add(60 * time.Second)
@@ -82,34 +156,97 @@ func (c *ChatGPTClient) Chat(prompt string) (string, error) {
 	return c.ChatAdvanced(reqBody)
 }
 
+// ChatAdvanced sends a ChatRequest and returns the text of the first message output.
+// If the requested model is unavailable, the request is retried with each of the FallbackModels in order.
+// With a Cache set, an identical earlier request is answered from the cache unless request.NoCache is set.
 func (c *ChatGPTClient) ChatAdvanced(request model.ChatRequest) (string, error) {
-	bodyBytes, err := json.Marshal(request)
+	if c.Cache == nil || request.NoCache {
+		result, err := c.chatWithFallback(request)
+		return result.Text, err
+	}
+	if request.Model == "" {
+		request.Model = c.Model
+	}
+	key, err := respcache.Key(request)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal ChatRequest: %w", err)
+		return "", err
 	}
-
-	url := "https://api.openai.com/v1/responses"
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyBytes))
+	if text, ok := c.Cache.Get(key); ok {
+		log.Printf("Chat response served from cache")
+		return text, nil
+	}
+	result, err := c.chatWithFallback(request)
 	if err != nil {
-		return "", fmt.Errorf("failed to create HTTP request: %w", err)
+		return "", err
 	}
+	c.Cache.Set(key, result.Text)
+	return result.Text, nil
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+// ChatAdvancedWithCitations sends a ChatRequest like ChatAdvanced and also returns the url_citation
+// annotations of the answer, such as those produced by the web search tool. Responses are never cached.
+func (c *ChatGPTClient) ChatAdvancedWithCitations(request model.ChatRequest) (string, []model.Citation, error) {
+	result, err := c.chatWithFallback(request)
+	if err != nil {
+		return "", nil, err
+	}
+	return result.Text, result.Citations, nil
+}
 
-	writeDebugLog(fmt.Sprintf("API Request:\ncurl %s \\\n  -H \"Content-Type: application/json\" \\\n  -H \"Authorization: Bearer %s\" \\\n  -d '%s'",
-		url, c.APIKey, string(bodyBytes)))
+// chatResult is the answer of a single chat request.
+type chatResult struct {
+	Text      string
+	Citations []model.Citation
+	FileIDs   []string // files found by file_search or cited with file_citation annotations
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+// chatWithFallback sends the request to the primary model, then to each fallback model while they are unavailable.
+func (c *ChatGPTClient) chatWithFallback(request model.ChatRequest) (chatResult, error) {
+	if c.Budget != nil {
+		if err := c.Budget.Check(); err != nil {
+			return chatResult{}, err
+		}
+	}
+	reasoning, err := c.reasoningOptions(request.Reasoning)
 	if err != nil {
-		return "", fmt.Errorf("failed to send HTTP request: %w", err)
+		return chatResult{}, err
+	}
+	var lastErr error
+	for _, m := range c.modelChain(request.Model) {
+		request.Model = m
+		request.Reasoning = nil
+		if SupportsReasoning(m) {
+			request.Reasoning = reasoning
+		}
+		result, err := c.sendChatRequest(request)
+		if err == nil {
+			log.Printf("Chat response served by model %s", m)
+			return result, nil
+		}
+		var unavailable *modelUnavailableError
+		if !errors.As(err, &unavailable) {
+			return chatResult{}, err
+		}
+		log.Printf("Model %s unavailable (status %d), trying next fallback", m, unavailable.StatusCode)
+		lastErr = err
 	}
-	defer resp.Body.Close()
+	return chatResult{}, fmt.Errorf("all models failed: %w", lastErr)
+}
 
-	respBytes, err := ioutil.ReadAll(resp.Body)
+// sendChatRequest performs a single call to the responses endpoint with the model set on the request,
+// passing it through the Interceptors.
+func (c *ChatGPTClient) sendChatRequest(request model.ChatRequest) (chatResult, error) {
+	raw, err := c.chatSender()(request)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return chatResult{}, err
+	}
+	respBytes := raw.Body
+
+	if isModelUnavailable(raw.StatusCode) {
+		return chatResult{}, &modelUnavailableError{Model: request.Model, StatusCode: raw.StatusCode, Body: string(respBytes)}
+	}
+	if raw.StatusCode != http.StatusOK {
+		return chatResult{}, fmt.Errorf("chat request failed: %w", apierr.NewStatusError(raw.StatusCode, string(respBytes)))
 	}
 
 	// Pretty-print the raw JSON response for debugging.
@@ -125,32 +262,148 @@ func (c *ChatGPTClient) ChatAdvanced(request model.ChatRequest) (string, error)
 		Output []struct {
 			Type    string `json:"type"`
 			Content []struct {
-				Text string `json:"text"`
+				Text        string `json:"text"`
+				Annotations []struct {
+					Type   string `json:"type"`
+					URL    string `json:"url"`
+					Title  string `json:"title"`
+					FileID string `json:"file_id"`
+				} `json:"annotations"`
 			} `json:"content"`
+			Results []struct {
+				FileID string `json:"file_id"`
+			} `json:"results"`
 		} `json:"output"`
+		Usage struct {
+			InputTokens  int `json:"input_tokens"`
+			OutputTokens int `json:"output_tokens"`
+			TotalTokens  int `json:"total_tokens"`
+		} `json:"usage"`
 	}
 
 	if err := json.Unmarshal(respBytes, &respData); err != nil {
-		return "", fmt.Errorf("failed to decode response: %w", err)
+		return chatResult{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if c.Budget != nil {
+		c.Budget.Record(request.Model, respData.Usage.TotalTokens)
+	}
+
+	// Collect the files returned by file_search calls; they are only listed when the request includes
+	// "file_search_call.results".
+	var result chatResult
+	seenFiles := make(map[string]bool)
+	addFile := func(id string) {
+		if id != "" && !seenFiles[id] {
+			seenFiles[id] = true
+			result.FileIDs = append(result.FileIDs, id)
+		}
+	}
+	for _, out := range respData.Output {
+		if out.Type != "file_search_call" {
+			continue
+		}
+		for _, r := range out.Results {
+			addFile(r.FileID)
+		}
 	}
 
-	// Iterate over the output blocks and return the text from the first block of type "message".
+	// Iterate over the output blocks and return the text from the first block of type "message",
+	// together with the distinct URLs and files it cites.
 	for _, out := range respData.Output {
-		if out.Type == "message" && len(out.Content) > 0 {
-			return out.Content[0].Text, nil
+		if out.Type != "message" || len(out.Content) == 0 {
+			continue
 		}
+		result.Text = out.Content[0].Text
+		seen := make(map[string]bool)
+		for _, a := range out.Content[0].Annotations {
+			switch a.Type {
+			case "url_citation":
+				if a.URL == "" || seen[a.URL] {
+					continue
+				}
+				seen[a.URL] = true
+				result.Citations = append(result.Citations, model.Citation{URL: a.URL, Title: a.Title})
+			case "file_citation":
+				addFile(a.FileID)
+			}
+		}
+		return result, nil
+	}
+
+	return chatResult{}, fmt.Errorf("no message output returned in response")
+}
+
+// postChat sends request to the responses endpoint and returns the undecoded response.
+func (c *ChatGPTClient) postChat(request model.ChatRequest) (RawChatResponse, error) {
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return RawChatResponse{}, fmt.Errorf("failed to marshal ChatRequest: %w", err)
 	}
 
-	return "", fmt.Errorf("no message output returned in response")
+	url := "https://api.openai.com/v1/responses"
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return RawChatResponse{}, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+
+	writeDebugLog(fmt.Sprintf("API Request:\ncurl %s \\\n  -H \"Content-Type: application/json\" \\\n  -H \"Authorization: Bearer %s\" \\\n  -d '%s'",
+		url, c.APIKey, string(bodyBytes)))
+
+	resp, err := c.do(req)
+	if err != nil {
+		return RawChatResponse{}, fmt.Errorf("failed to send HTTP request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return RawChatResponse{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return RawChatResponse{StatusCode: resp.StatusCode, Body: respBytes}, nil
 }
 
 // ChatAdvancedParsed sends a ChatRequest and unmarshals the response into target.
+// The first JSON object or array in the response is used, so markdown fences and prose around
+// it are ignored. Empty, truncated or otherwise invalid JSON yields an error quoting the response.
 func (c *ChatGPTClient) ChatAdvancedParsed(request model.ChatRequest, target interface{}) error {
 	raw, err := c.ChatAdvanced(request)
 	if err != nil {
 		return err
 	}
-	return json.Unmarshal([]byte(raw), target)
+	data, ok := extractJSON(raw)
+	if !ok {
+		return jsonError(raw, errors.New("no complete JSON value found"))
+	}
+	if err := json.Unmarshal([]byte(data), target); err != nil {
+		return jsonError(raw, err)
+	}
+	return nil
+}
+
+// ChatAdvancedParsedWithSources sends a ChatRequest like ChatAdvancedParsed and also returns the IDs of the
+// files the answer drew on: those returned by file_search calls and those cited by file_citation annotations.
+// Responses are never cached.
+func (c *ChatGPTClient) ChatAdvancedParsedWithSources(request model.ChatRequest, target interface{}) ([]string, error) {
+	result, err := c.chatWithFallback(request)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := extractJSON(result.Text)
+	if !ok {
+		return nil, jsonError(result.Text, errors.New("no complete JSON value found"))
+	}
+	if err := json.Unmarshal([]byte(data), target); err != nil {
+		return nil, jsonError(result.Text, err)
+	}
+	return result.FileIDs, nil
+}
+
+// SetFallbackModels sets the models tried, in order, when the primary model is unavailable.
+func (c *ChatGPTClient) SetFallbackModels(models ...string) {
+	c.FallbackModels = models
 }
 
 // SetModel sets the model.
@@ -175,6 +428,11 @@ func (c *ChatGPTClient) GetModel() string {
 
 // UploadFile uploads a file using the files API endpoint.
 func (c *ChatGPTClient) UploadFile(filePath string, purpose string) (model.File, error) {
+	if c.Budget != nil {
+		if err := c.Budget.Check(); err != nil {
+			return model.File{}, err
+		}
+	}
 	file, err := os.Open(filePath)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to open file: %w", err)
@@ -203,8 +461,7 @@ func (c *ChatGPTClient) UploadFile(filePath string, purpose string) (model.File,
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
 
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -218,6 +475,9 @@ func (c *ChatGPTClient) UploadFile(filePath string, purpose string) (model.File,
 	if err := json.Unmarshal(respBytes, &fileObj); err != nil {
 		return model.File{}, fmt.Errorf("failed to unmarshal file object: %w", err)
 	}
+	if c.Budget != nil {
+		c.Budget.Record(budget.UploadRateKey, budget.EstimateTokens(fileObj.Bytes))
+	}
 	// Poll until the file is available.
 	processedFile, err := c.pollUploadedFile(fileObj.ID)
 	if err != nil {
@@ -235,8 +495,7 @@ func (c *ChatGPTClient) GetFile(fileID string) (model.File, error) {
 	}
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to send GET request: %w", err)
 	}
@@ -255,44 +514,83 @@ func (c *ChatGPTClient) GetFile(fileID string) (model.File, error) {
 }
 
 // DeleteAllFiles deletes all files uploaded via the files API. This is useful for cleanup during tests.
+// Every file is attempted even when some deletions fail; the failures are returned joined together.
 func (c *ChatGPTClient) DeleteAllFiles() error {
-	url := "https://api.openai.com/v1/files"
-	req, err := http.NewRequest("GET", url, nil)
+	files, err := c.files().ListFiles(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to create list files request: %w", err)
+		return err
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	var errs []error
+	for _, file := range files {
+		if err := c.files().DeleteFile(context.Background(), file.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to delete %d of %d files: %w", len(errs), len(files), errors.Join(errs...))
+	}
+	return nil
+}
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to list files: %w", err)
+// files returns the files API client sharing this client's key, HTTP client and retry policy.
+func (c *ChatGPTClient) files() filesapi.Client {
+	return filesapi.Client{APIKey: c.APIKey, Do: c.do}
+}
+
+// Ping checks the API key and connectivity by listing the available models.
+func (c *ChatGPTClient) Ping(ctx context.Context) error {
+	if _, err := c.ListModels(ctx); err != nil {
+		return fmt.Errorf("OpenAI health check failed: %w", err)
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	respBytes, err := ioutil.ReadAll(resp.Body)
+// ListModels returns the IDs of the models available to the API key.
+func (c *ChatGPTClient) ListModels(ctx context.Context) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.openai.com/v1/models", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	resp, err := c.do(req)
 	if err != nil {
-		return fmt.Errorf("failed to read list files response: %w", err)
+		return nil, fmt.Errorf("failed to reach OpenAI: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list models: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
 	}
-	var listResponse struct {
-		Data []model.File `json:"data"`
+	var result struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
 	}
-	if err := json.Unmarshal(respBytes, &listResponse); err != nil {
-		return fmt.Errorf("failed to unmarshal list response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode models: %w", err)
 	}
+	models := make([]string, 0, len(result.Data))
+	for _, m := range result.Data {
+		models = append(models, m.ID)
+	}
+	return models, nil
+}
 
-	for _, file := range listResponse.Data {
-		delURL := fmt.Sprintf("https://api.openai.com/v1/files/%s", file.ID)
-		delReq, err := http.NewRequest("DELETE", delURL, nil)
-		if err != nil {
-			return fmt.Errorf("failed to create delete request for file %s: %w", file.ID, err)
-		}
-		delReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
-		delResp, err := client.Do(delReq)
-		if err != nil {
-			return fmt.Errorf("failed to delete file %s: %w", file.ID, err)
+// ValidateModel checks that the configured model and every fallback model are available to the API key.
+// A missing model is reported with an error wrapping apierr.ErrNotFound.
+func (c *ChatGPTClient) ValidateModel() error {
+	models, err := c.ListModels(context.Background())
+	if err != nil {
+		return err
+	}
+	available := make(map[string]bool, len(models))
+	for _, m := range models {
+		available[m] = true
+	}
+	for _, m := range c.modelChain(c.Model) {
+		if !available[m] {
+			return fmt.Errorf("model %q is not available to this account: %w", m, apierr.ErrNotFound)
 		}
-		delResp.Body.Close()
 	}
 	return nil
 }
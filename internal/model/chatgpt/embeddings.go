@@ -0,0 +1,88 @@
+package chatgpt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+)
+
+// DefaultEmbeddingModel is the model Embeddings uses when EmbeddingModel is not set.
+const DefaultEmbeddingModel = "text-embedding-ada-002"
+
+// embeddingsURL is the OpenAI endpoint for batch embeddings.
+const embeddingsURL = "https://api.openai.com/v1/embeddings"
+
+// Embeddings embeds all texts in a single request with EmbeddingModel and returns the vectors in the
+// order of texts. The request shares the client's key, HTTP client, rate limiter, retry policy and budget.
+func (c *ChatGPTClient) Embeddings(ctx context.Context, texts []string) ([][]float64, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	if c.Budget != nil {
+		if err := c.Budget.Check(); err != nil {
+			return nil, err
+		}
+	}
+	embeddingModel := c.EmbeddingModel
+	if embeddingModel == "" {
+		embeddingModel = DefaultEmbeddingModel
+	}
+	bodyBytes, err := json.Marshal(struct {
+		Model string   `json:"model"`
+		Input []string `json:"input"`
+	}{Model: embeddingModel, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal embeddings request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", embeddingsURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send embeddings request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("embeddings request failed: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
+	}
+
+	var respData struct {
+		Data []struct {
+			Embedding []float64 `json:"embedding"`
+			Index     int       `json:"index"`
+		} `json:"data"`
+		Usage struct {
+			TotalTokens int `json:"total_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&respData); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings response: %w", err)
+	}
+	if c.Budget != nil {
+		c.Budget.Record(embeddingModel, respData.Usage.TotalTokens)
+	}
+	if len(respData.Data) != len(texts) {
+		return nil, fmt.Errorf("expected %d embeddings, got %d", len(texts), len(respData.Data))
+	}
+
+	// Results carry the index of their input; place them accordingly.
+	embeddings := make([][]float64, len(texts))
+	for _, d := range respData.Data {
+		if d.Index < 0 || d.Index >= len(texts) || embeddings[d.Index] != nil {
+			return nil, fmt.Errorf("unexpected embedding index %d", d.Index)
+		}
+		embeddings[d.Index] = d.Embedding
+	}
+	return embeddings, nil
+}
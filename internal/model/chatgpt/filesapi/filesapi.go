@@ -0,0 +1,84 @@
+// Package filesapi lists and deletes files uploaded to the OpenAI files API. It is shared by the
+// chat client and the vector storage client, which both manage uploaded files.
+package filesapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// filesURL is the files API endpoint.
+const filesURL = "https://api.openai.com/v1/files"
+
+// Client calls the files API with an API key, sending requests through Do so the caller's HTTP
+// client and retry policy apply.
+type Client struct {
+	APIKey string
+	Do     func(req *http.Request) (*http.Response, error)
+}
+
+// ListFiles returns every uploaded file, following pagination.
+func (c Client) ListFiles(ctx context.Context) ([]model.File, error) {
+	var files []model.File
+	after := ""
+	for {
+		url := filesURL + "?limit=10000"
+		if after != "" {
+			url += "&after=" + after
+		}
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create list files request: %w", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+		resp, err := c.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list uploaded files: %w", err)
+		}
+		respBytes, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read list files response: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to list uploaded files: %w", apierr.NewStatusError(resp.StatusCode, string(respBytes)))
+		}
+		var listResponse struct {
+			Data    []model.File `json:"data"`
+			HasMore bool         `json:"has_more"`
+		}
+		if err := json.Unmarshal(respBytes, &listResponse); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal list files response: %w", err)
+		}
+		files = append(files, listResponse.Data...)
+		if !listResponse.HasMore || len(listResponse.Data) == 0 {
+			return files, nil
+		}
+		after = listResponse.Data[len(listResponse.Data)-1].ID
+	}
+}
+
+// DeleteFile deletes an uploaded file, which also removes it from every vector store.
+func (c Client) DeleteFile(ctx context.Context, fileID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/%s", filesURL, fileID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create delete request for file %s: %w", fileID, err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	resp, err := c.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete file %s: %w", fileID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		respBytes, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete file %s: %w", fileID, apierr.NewStatusError(resp.StatusCode, string(respBytes)))
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package chatgpt
+
+import "github.com/egobogo/aiagents/internal/model"
+
+// RawChatResponse is the undecoded reply of the responses endpoint.
+type RawChatResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// ChatSender sends a chat request and returns the raw response.
+type ChatSender func(request model.ChatRequest) (RawChatResponse, error)
+
+// ChatInterceptor wraps the sending of every chat request, including each fallback attempt.
+// It may inspect or change the request before calling next, inspect or replace the raw response
+// after it, or answer without calling next at all, e.g. to replay recorded fixtures.
+type ChatInterceptor func(request model.ChatRequest, next ChatSender) (RawChatResponse, error)
+
+// Use appends interceptors to the client's chain. The first interceptor added is the outermost:
+// it sees the request first and the response last.
+func (c *ChatGPTClient) Use(interceptors ...ChatInterceptor) {
+	c.Interceptors = append(c.Interceptors, interceptors...)
+}
+
+// chatSender returns the function that sends a chat request through the Interceptors.
+func (c *ChatGPTClient) chatSender() ChatSender {
+	send := ChatSender(c.postChat)
+	for i := len(c.Interceptors) - 1; i >= 0; i-- {
+		interceptor, next := c.Interceptors[i], send
+		send = func(request model.ChatRequest) (RawChatResponse, error) {
+			return interceptor(request, next)
+		}
+	}
+	return send
+}
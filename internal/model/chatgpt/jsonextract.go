@@ -0,0 +1,69 @@
+package chatgpt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxErrorSnippet caps how much of an unparsable response is quoted in an error.
+const maxErrorSnippet = 200
+
+// extractJSON returns the first balanced JSON object or array in text, skipping markdown
+// fences and any prose around it. ok is false when no complete value is found, e.g. when the
+// response was truncated.
+func extractJSON(text string) (string, bool) {
+	start := strings.IndexAny(text, "{[")
+	if start < 0 {
+		return "", false
+	}
+	var stack []byte
+	inString, escaped := false, false
+	for i := start; i < len(text); i++ {
+		c := text[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{':
+			stack = append(stack, '}')
+		case '[':
+			stack = append(stack, ']')
+		case '}', ']':
+			if len(stack) == 0 || stack[len(stack)-1] != c {
+				return "", false
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return text[start : i+1], true
+			}
+		}
+	}
+	return "", false
+}
+
+// snippet shortens text for inclusion in an error message.
+func snippet(text string) string {
+	text = strings.TrimSpace(text)
+	if len(text) > maxErrorSnippet {
+		return text[:maxErrorSnippet] + "..."
+	}
+	return text
+}
+
+// jsonError describes a response that could not be decoded.
+func jsonError(raw string, err error) error {
+	if strings.TrimSpace(raw) == "" {
+		return fmt.Errorf("model returned an empty response, expected JSON")
+	}
+	return fmt.Errorf("failed to decode model response as JSON: %w (response: %q)", err, snippet(raw))
+}
@@ -25,3 +25,12 @@ var ExpensiveCoding = ModelInfo{
 	Strengths:          "Excels in complex reasoning and coding, ideal for advanced technical tasks.",
 	DefaultTemperature: 0.8,
 }
+
+// BudgetRates converts the per-1M token prices of the given models into the $/1K rates used by budget.BudgetGuard.
+func BudgetRates(models ...ModelInfo) map[string]float64 {
+	rates := make(map[string]float64, len(models))
+	for _, m := range models {
+		rates[m.Name] = m.PricePerToken / 1000
+	}
+	return rates
+}
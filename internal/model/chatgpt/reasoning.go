@@ -0,0 +1,40 @@
+package chatgpt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// reasoningModelPrefixes are the model families that accept the reasoning parameter.
+var reasoningModelPrefixes = []string{"o1", "o3", "o4", "gpt-5"}
+
+// reasoningEfforts are the accepted values of ReasoningOptions.Effort.
+var reasoningEfforts = map[string]bool{"low": true, "medium": true, "high": true}
+
+// SupportsReasoning reports whether the model accepts reasoning options, e.g. "o3-mini" or "o1-2024-12-17".
+func SupportsReasoning(modelName string) bool {
+	for _, prefix := range reasoningModelPrefixes {
+		if modelName == prefix || strings.HasPrefix(modelName, prefix+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// reasoningOptions returns the reasoning options to send to reasoning models: those of the request, or the
+// client's ReasoningEffort when the request sets none. An unknown effort is an error.
+func (c *ChatGPTClient) reasoningOptions(requested *model.ReasoningOptions) (*model.ReasoningOptions, error) {
+	opts := requested
+	if opts == nil && c.ReasoningEffort != "" {
+		opts = &model.ReasoningOptions{Effort: c.ReasoningEffort}
+	}
+	if opts == nil {
+		return nil, nil
+	}
+	if opts.Effort != "" && !reasoningEfforts[opts.Effort] {
+		return nil, fmt.Errorf("invalid reasoning effort %q: use low, medium or high", opts.Effort)
+	}
+	return opts, nil
+}
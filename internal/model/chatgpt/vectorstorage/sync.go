@@ -0,0 +1,239 @@
+package vectorstorage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// Uploader uploads a local file to the files API. model.ModelClient satisfies it.
+type Uploader interface {
+	UploadFile(filePath string, purpose string) (model.File, error)
+}
+
+// UploadIndex is a persisted map from file path to the content hash and ID of its uploaded file.
+// It lets repeated syncs skip files that are unchanged and already attached, and find the upload an
+// edited file supersedes.
+type UploadIndex struct {
+	mu   sync.Mutex
+	path string
+	// Root is the directory paths are recorded relative to, normally the repository root.
+	// Empty records paths as given.
+	Root  string                 `json:"-"`
+	Files map[string]UploadEntry `json:"files"`
+}
+
+// UploadEntry is the upload recorded for one file path.
+type UploadEntry struct {
+	Hash   string `json:"hash"`
+	FileID string `json:"file_id"`
+}
+
+// LoadUploadIndex reads the index stored at path. A missing file yields an empty index.
+func LoadUploadIndex(path string) (*UploadIndex, error) {
+	index := &UploadIndex{path: path, Files: make(map[string]UploadEntry)}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upload index: %w", err)
+	}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal upload index: %w", err)
+	}
+	if index.Files == nil {
+		index.Files = make(map[string]UploadEntry)
+	}
+	return index, nil
+}
+
+// key returns the path a file is recorded under: relative to Root when it is set.
+func (i *UploadIndex) key(filePath string) string {
+	if i.Root == "" {
+		return filePath
+	}
+	if rel, err := filepath.Rel(i.Root, filePath); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return filePath
+}
+
+// Lookup returns the upload recorded for a file path.
+func (i *UploadIndex) Lookup(filePath string) (UploadEntry, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	entry, ok := i.Files[i.key(filePath)]
+	return entry, ok
+}
+
+// Set records the content hash and uploaded file ID for a file path.
+func (i *UploadIndex) Set(filePath, hash, fileID string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.Files[i.key(filePath)] = UploadEntry{Hash: hash, FileID: fileID}
+}
+
+// Save writes the index back to its path.
+func (i *UploadIndex) Save() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(i, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal upload index: %w", err)
+	}
+	if err := ioutil.WriteFile(i.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write upload index: %w", err)
+	}
+	return nil
+}
+
+// HashFile returns the hex-encoded SHA-256 of a file's content.
+func HashFile(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file %s: %w", path, err)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// SyncFiles makes sure every file in paths is uploaded and attached to the vector store.
+// Files whose recorded content hash is unchanged and whose file is still attached to the store
+// are reused without uploading again. When a file changed, the new version is attached and the
+// superseded upload is detached from the store and deleted. Up to UploadConcurrency files are uploaded and attached at once;
+// every request still waits for the client's and the uploader's rate limiters.
+// The attachments of the files that synced are returned in the order of paths, together with the
+// per-file errors joined. The index is saved before returning.
+func (c *Client) SyncFiles(vectorStoreID string, paths []string, uploader Uploader, index *UploadIndex) ([]model.FileAttachment, error) {
+	existing, err := c.ListFiles(vectorStoreID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list vector store files: %w", err)
+	}
+	s := &fileSync{
+		client:        c,
+		vectorStoreID: vectorStoreID,
+		uploader:      uploader,
+		index:         index,
+		attached:      make(map[string]bool, len(existing)),
+	}
+	for _, f := range existing {
+		s.attached[f.ID] = true
+	}
+
+	workers := c.UploadConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+	results := make([]*model.FileAttachment, len(paths))
+	errs := make([]error, len(paths))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				attachment, err := s.syncFile(paths[i])
+				errs[i] = err
+				if attachment.FileID != "" {
+					results[i] = &attachment
+				}
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var attachments []model.FileAttachment
+	for _, a := range results {
+		if a != nil {
+			attachments = append(attachments, *a)
+		}
+	}
+	if err := index.Save(); err != nil {
+		errs = append(errs, err)
+	}
+	return attachments, errors.Join(errs...)
+}
+
+// fileSync holds the state shared by the workers of one SyncFiles call.
+type fileSync struct {
+	client        *Client
+	vectorStoreID string
+	uploader      Uploader
+	index         *UploadIndex
+
+	mu       sync.Mutex
+	attached map[string]bool // IDs of the files attached to the store
+}
+
+// isAttached reports whether the file is attached to the store.
+func (s *fileSync) isAttached(fileID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attached[fileID]
+}
+
+// syncFile uploads and attaches a single file unless an unchanged copy is already attached.
+// An attachment is returned whenever the file ended up attached, even if removing the superseded
+// upload failed; that failure is returned alongside it.
+func (s *fileSync) syncFile(filePath string) (model.FileAttachment, error) {
+	hash, err := HashFile(filePath)
+	if err != nil {
+		return model.FileAttachment{}, err
+	}
+	previous, known := s.index.Lookup(filePath)
+	if known && previous.Hash == hash && s.isAttached(previous.FileID) {
+		return model.FileAttachment{FileID: previous.FileID, VectorStoreID: s.vectorStoreID}, nil
+	}
+	uploaded, err := s.uploader.UploadFile(filePath, string(model.FilePurposeAssistants))
+	if err != nil {
+		return model.FileAttachment{}, fmt.Errorf("failed to upload file %s: %w", filePath, err)
+	}
+	if _, err := s.client.AttachFile(s.vectorStoreID, uploaded.ID); err != nil {
+		return model.FileAttachment{}, fmt.Errorf("failed to attach file %s to vector store: %w", filePath, err)
+	}
+	s.mu.Lock()
+	s.attached[uploaded.ID] = true
+	s.mu.Unlock()
+	s.index.Set(filePath, hash, uploaded.ID)
+	attachment := model.FileAttachment{FileID: uploaded.ID, VectorStoreID: s.vectorStoreID}
+
+	// Remove the superseded version so retrieval does not return old and new code side by side.
+	if known && previous.FileID != uploaded.ID && s.isAttached(previous.FileID) {
+		if err := s.removeFile(previous.FileID); err != nil {
+			return attachment, fmt.Errorf("failed to remove the previous upload of %s: %w", filePath, err)
+		}
+	}
+	return attachment, nil
+}
+
+// removeFile detaches a file from the store and deletes it from the files API.
+func (s *fileSync) removeFile(fileID string) error {
+	if _, err := s.client.DeleteFile(s.vectorStoreID, fileID); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.attached, fileID)
+	s.mu.Unlock()
+	return s.client.files().DeleteFile(context.Background(), fileID)
+}
@@ -2,34 +2,75 @@ package vectorstorage
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"sync"
 	"time"
 
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/httputil"
 	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/filesapi"
+	"github.com/egobogo/aiagents/internal/ratelimit"
 )
 
+// Client manages OpenAI vector stores and the files attached to them.
 type Client struct {
-	APIKey string
+	APIKey     string
+	HTTPClient *http.Client
+	Limiter    *ratelimit.Limiter   // optional request rate cap, may be shared with other clients
+	Retry      httputil.RetryPolicy // retries of rate-limited and failing requests; zero sends each request once
+	// UploadConcurrency is how many files SyncFiles uploads and attaches at once; values below 1 mean one.
+	UploadConcurrency int
 }
 
+// DefaultUploadConcurrency is the UploadConcurrency of clients created with NewClient.
+const DefaultUploadConcurrency = 4
+
+// NewClient creates a new vector storage Client.
 func NewClient(apiKey string) *Client {
 	return &Client{
-		APIKey: apiKey,
+		APIKey:     apiKey,
+		HTTPClient: &http.Client{},
+		Retry:      httputil.DefaultRetryPolicy,
+
+		UploadConcurrency: DefaultUploadConcurrency,
+	}
+}
+
+// httpClient returns the configured HTTP client, falling back to a default one,
+// throttled by the client's Limiter when one is set.
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return c.Limiter.Wrap(&http.Client{})
 	}
+	return c.Limiter.Wrap(c.HTTPClient)
+}
+
+// do sends req through the HTTP client, retrying transient failures according to Retry.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	return httputil.Do(req.Context(), c.httpClient(), req, c.Retry)
 }
 
 // CreateStorage creates a new vector store with the given name.
 func (c *Client) CreateStorage(name string) (model.VectorStore, error) {
+	return c.CreateStorageContext(context.Background(), name)
+}
+
+// CreateStorageContext is CreateStorage with a context that cancels the request.
+func (c *Client) CreateStorageContext(ctx context.Context, name string) (model.VectorStore, error) {
 	payload := map[string]string{"name": name}
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		return model.VectorStore{}, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 	url := "https://api.openai.com/v1/vector_stores"
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payloadBytes))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payloadBytes))
 	if err != nil {
 		return model.VectorStore{}, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -37,8 +78,7 @@ func (c *Client) CreateStorage(name string) (model.VectorStore, error) {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
 	req.Header.Set("OpenAI-Beta", "assistants=v2")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return model.VectorStore{}, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -47,6 +87,9 @@ func (c *Client) CreateStorage(name string) (model.VectorStore, error) {
 	if err != nil {
 		return model.VectorStore{}, fmt.Errorf("failed to read response: %w", err)
 	}
+	if resp.StatusCode != http.StatusOK {
+		return model.VectorStore{}, fmt.Errorf("failed to create vector store: %w", apierr.NewStatusError(resp.StatusCode, string(respBytes)))
+	}
 	var vs model.VectorStore
 	if err := json.Unmarshal(respBytes, &vs); err != nil {
 		return model.VectorStore{}, fmt.Errorf("failed to unmarshal vector store: %w", err)
@@ -54,38 +97,87 @@ func (c *Client) CreateStorage(name string) (model.VectorStore, error) {
 	return vs, nil
 }
 
+// storageLocks serializes FindOrCreateStorage per store name across all clients in the process.
+var storageLocks sync.Map // name -> *sync.Mutex
+
+// FindOrCreateStorage returns the vector store with the given name, creating it if none exists.
+// Concurrent callers for the same name are serialized, so the store is created only once and
+// every caller receives it.
+func (c *Client) FindOrCreateStorage(name string) (model.VectorStore, error) {
+	return c.FindOrCreateStorageContext(context.Background(), name)
+}
+
+// FindOrCreateStorageContext is FindOrCreateStorage with a context that cancels its requests.
+func (c *Client) FindOrCreateStorageContext(ctx context.Context, name string) (model.VectorStore, error) {
+	lock, _ := storageLocks.LoadOrStore(name, &sync.Mutex{})
+	mu := lock.(*sync.Mutex)
+	mu.Lock()
+	defer mu.Unlock()
+
+	storages, err := c.ListStoragesContext(ctx)
+	if err != nil {
+		return model.VectorStore{}, fmt.Errorf("failed to list vector stores: %w", err)
+	}
+	for _, vs := range storages {
+		if vs.Name == name {
+			return vs, nil
+		}
+	}
+	vs, err := c.CreateStorageContext(ctx, name)
+	if err != nil {
+		return model.VectorStore{}, fmt.Errorf("failed to create vector store: %w", err)
+	}
+	return vs, nil
+}
+
 // DeleteStorage deletes a vector store identified by its ID.
 func (c *Client) DeleteStorage(vectorStoreID string) error {
+	return c.DeleteStorageContext(context.Background(), vectorStoreID)
+}
+
+// DeleteStorageContext is DeleteStorage with a context that cancels the request.
+func (c *Client) DeleteStorageContext(ctx context.Context, vectorStoreID string) error {
 	url := fmt.Sprintf("https://api.openai.com/v1/vector_stores/%s", vectorStoreID)
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create DELETE request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
 	req.Header.Set("OpenAI-Beta", "assistants=v2")
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to send DELETE request: %w", err)
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
 		respBytes, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("failed to delete vector store, status: %d, response: %s", resp.StatusCode, string(respBytes))
+		return fmt.Errorf("failed to delete vector store: %w", apierr.NewStatusError(resp.StatusCode, string(respBytes)))
 	}
 	return nil
 }
 
+// attachPollInterval and attachPollTimeout control how AttachFile waits for the file to be listed.
+const (
+	attachPollInterval = 2 * time.Second
+	attachPollTimeout  = 60 * time.Second
+)
+
 // AttachFile attaches an already uploaded file (by file ID) to a vector store.
 func (c *Client) AttachFile(vectorStoreID, fileID string) (model.File, error) {
+	return c.AttachFileContext(context.Background(), vectorStoreID, fileID)
+}
+
+// AttachFileContext is AttachFile with a context. Cancelling it stops the request and the wait for the
+// file to be listed, and the error then wraps ctx.Err().
+func (c *Client) AttachFileContext(ctx context.Context, vectorStoreID, fileID string) (model.File, error) {
 	payload := map[string]string{"file_id": fileID}
 	payloadBytes, err := json.Marshal(payload)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to marshal payload: %w", err)
 	}
 	url := fmt.Sprintf("https://api.openai.com/v1/vector_stores/%s/files", vectorStoreID)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(payloadBytes))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payloadBytes))
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -93,8 +185,7 @@ func (c *Client) AttachFile(vectorStoreID, fileID string) (model.File, error) {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
 	req.Header.Set("OpenAI-Beta", "assistants=v2")
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to send request: %w", err)
 	}
@@ -104,24 +195,27 @@ func (c *Client) AttachFile(vectorStoreID, fileID string) (model.File, error) {
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to read response: %w", err)
 	}
+	if resp.StatusCode != http.StatusOK {
+		return model.File{}, fmt.Errorf("failed to attach file %s: %w", fileID, apierr.NewStatusError(resp.StatusCode, string(respBytes)))
+	}
 	var fileObj model.File
 	if err := json.Unmarshal(respBytes, &fileObj); err != nil {
 		return model.File{}, fmt.Errorf("failed to unmarshal file object: %w", err)
 	}
 
 	// Poll until the file appears in the vector store's file list.
-	timeout := time.Now().Add(60 * time.Second)
+	timeout := time.Now().Add(attachPollTimeout)
 	for {
-		files, err := c.ListFiles(vectorStoreID)
-		if err != nil {
-			return model.File{}, fmt.Errorf("failed to list files: %w", err)
-		}
 		found := false
-		for _, f := range files {
+		err := c.IterateFilesContext(ctx, vectorStoreID, func(f model.File) error {
 			if f.ID == fileID {
 				found = true
-				break
+				return ErrStopIteration
 			}
+			return nil
+		})
+		if err != nil {
+			return model.File{}, fmt.Errorf("failed to list files: %w", err)
 		}
 		if found {
 			break
@@ -129,90 +223,229 @@ func (c *Client) AttachFile(vectorStoreID, fileID string) (model.File, error) {
 		if time.Now().After(timeout) {
 			return model.File{}, fmt.Errorf("timeout waiting for file %s to be attached", fileID)
 		}
-		time.Sleep(2 * time.Second)
+		select {
+		case <-ctx.Done():
+			return model.File{}, fmt.Errorf("stopped waiting for file %s to be attached: %w", fileID, ctx.Err())
+		case <-time.After(attachPollInterval):
+		}
 	}
 
 	return fileObj, nil
 }
 
-// ListStorages returns all vector stores.
+// ListStorages returns all vector stores, following pagination until every page is read.
 func (c *Client) ListStorages() ([]model.VectorStore, error) {
-	url := "https://api.openai.com/v1/vector_stores"
-	req, err := http.NewRequest("GET", url, nil)
+	return c.ListStoragesContext(context.Background())
+}
+
+// ListStoragesContext is ListStorages with a context that cancels its requests.
+func (c *Client) ListStoragesContext(ctx context.Context) ([]model.VectorStore, error) {
+	var storages []model.VectorStore
+	after := ""
+	for {
+		url := "https://api.openai.com/v1/vector_stores?limit=100"
+		if after != "" {
+			url += "&after=" + after
+		}
+		var listResponse struct {
+			Object  string              `json:"object"`
+			Data    []model.VectorStore `json:"data"`
+			FirstID string              `json:"first_id"`
+			LastID  string              `json:"last_id"`
+			HasMore bool                `json:"has_more"`
+		}
+		if err := c.getJSON(ctx, url, &listResponse); err != nil {
+			return nil, fmt.Errorf("failed to list vector stores: %w", err)
+		}
+		storages = append(storages, listResponse.Data...)
+		if !listResponse.HasMore || listResponse.LastID == "" {
+			break
+		}
+		after = listResponse.LastID
+	}
+	return storages, nil
+}
+
+// ListFiles returns all files attached to the specified vector store, following pagination.
+func (c *Client) ListFiles(vectorStoreID string) ([]model.File, error) {
+	return c.ListFilesContext(context.Background(), vectorStoreID)
+}
+
+// ListFilesContext is ListFiles with a context that cancels its requests.
+func (c *Client) ListFilesContext(ctx context.Context, vectorStoreID string) ([]model.File, error) {
+	var files []model.File
+	err := c.IterateFilesContext(ctx, vectorStoreID, func(f model.File) error {
+		files = append(files, f)
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create GET request: %w", err)
+		return nil, err
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("OpenAI-Beta", "assistants=v2")
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	return files, nil
+}
+
+// ErrStopIteration can be returned by the callback of IterateFiles to stop early without an error.
+var ErrStopIteration = errors.New("stop iteration")
+
+// IterateFiles calls fn for every file attached to the specified vector store, page by page. Each page is
+// decoded from the response stream as fn consumes it, so the whole list is never held in memory.
+// Returning ErrStopIteration from fn stops the iteration and IterateFiles returns nil; other errors are
+// returned as they are.
+func (c *Client) IterateFiles(vectorStoreID string, fn func(model.File) error) error {
+	return c.IterateFilesContext(context.Background(), vectorStoreID, fn)
+}
+
+// IterateFilesContext is IterateFiles with a context that cancels its requests.
+func (c *Client) IterateFilesContext(ctx context.Context, vectorStoreID string, fn func(model.File) error) error {
+	after := ""
+	for {
+		url := fmt.Sprintf("https://api.openai.com/v1/vector_stores/%s/files?limit=100", vectorStoreID)
+		if after != "" {
+			url += "&after=" + after
+		}
+		body, err := c.get(ctx, url)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+		page, err := decodeFilePage(body, fn)
+		body.Close()
+		if errors.Is(err, ErrStopIteration) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !page.hasMore || page.lastID == "" {
+			return nil
+		}
+		after = page.lastID
+	}
+}
+
+// filePage holds the pagination fields of a page of vector store files.
+type filePage struct {
+	lastID  string
+	hasMore bool
+}
+
+// decodeFilePage streams one page of a file list from r, calling fn for each entry of "data".
+// When the page has no last_id, the ID of its last file is used as the cursor.
+func decodeFilePage(r io.Reader, fn func(model.File) error) (filePage, error) {
+	var page filePage
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return page, err
+	}
+	lastFile := ""
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return page, fmt.Errorf("failed to decode file list: %w", err)
+		}
+		key, _ := tok.(string)
+		switch key {
+		case "data":
+			if err := expectDelim(dec, '['); err != nil {
+				return page, err
+			}
+			for dec.More() {
+				var f model.File
+				if err := dec.Decode(&f); err != nil {
+					return page, fmt.Errorf("failed to decode file: %w", err)
+				}
+				lastFile = f.ID
+				if err := fn(f); err != nil {
+					return page, err
+				}
+			}
+			if err := expectDelim(dec, ']'); err != nil {
+				return page, err
+			}
+		case "last_id":
+			err = dec.Decode(&page.lastID)
+		case "has_more":
+			err = dec.Decode(&page.hasMore)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return page, fmt.Errorf("failed to decode file list field %q: %w", key, err)
+		}
+	}
+	if page.lastID == "" {
+		page.lastID = lastFile
+	}
+	return page, nil
+}
+
+// expectDelim reads the next token from dec and checks that it is the delimiter want.
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
 	if err != nil {
-		return nil, fmt.Errorf("failed to send GET request: %w", err)
+		return fmt.Errorf("failed to decode file list: %w", err)
 	}
-	defer resp.Body.Close()
-	respBytes, err := ioutil.ReadAll(resp.Body)
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("failed to decode file list: expected %q, got %v", want, tok)
+	}
+	return nil
+}
+
+// getJSON sends an authenticated GET request and unmarshals the response body into target.
+func (c *Client) getJSON(ctx context.Context, url string, target interface{}) error {
+	body, err := c.get(ctx, url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return err
 	}
-	var listResponse struct {
-		Object  string              `json:"object"`
-		Data    []model.VectorStore `json:"data"`
-		FirstID string              `json:"first_id"`
-		LastID  string              `json:"last_id"`
-		HasMore bool                `json:"has_more"`
+	defer body.Close()
+	respBytes, err := ioutil.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
 	}
-	if err := json.Unmarshal(respBytes, &listResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal list response: %w", err)
+	if err := json.Unmarshal(respBytes, target); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-	return listResponse.Data, nil
+	return nil
 }
 
-// ListFiles returns all files attached to the specified vector store.
-func (c *Client) ListFiles(vectorStoreID string) ([]model.File, error) {
-	url := fmt.Sprintf("https://api.openai.com/v1/vector_stores/%s/files", vectorStoreID)
-	req, err := http.NewRequest("GET", url, nil)
+// get sends an authenticated GET request and returns the body of a successful response.
+// The caller must close it.
+func (c *Client) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GET request: %w", err)
 	}
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("OpenAI-Beta", "assistants=v2")
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to send GET request: %w", err)
 	}
-	defer resp.Body.Close()
-	respBytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-	var listResponse struct {
-		Object  string       `json:"object"`
-		Data    []model.File `json:"data"`
-		FirstID string       `json:"first_id"`
-		LastID  string       `json:"last_id"`
-		HasMore bool         `json:"has_more"`
-	}
-	if err := json.Unmarshal(respBytes, &listResponse); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal list files response: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		respBytes, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unexpected response: %w", apierr.NewStatusError(resp.StatusCode, string(respBytes)))
 	}
-	return listResponse.Data, nil
+	return resp.Body, nil
 }
 
 // DeleteFile deletes a file from a vector store.
 func (c *Client) DeleteFile(vectorStoreID, fileID string) (model.File, error) {
+	return c.DeleteFileContext(context.Background(), vectorStoreID, fileID)
+}
+
+// DeleteFileContext is DeleteFile with a context that cancels the request.
+func (c *Client) DeleteFileContext(ctx context.Context, vectorStoreID, fileID string) (model.File, error) {
 	url := fmt.Sprintf("https://api.openai.com/v1/vector_stores/%s/files/%s", vectorStoreID, fileID)
-	req, err := http.NewRequest("DELETE", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to create DELETE request: %w", err)
 	}
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("OpenAI-Beta", "assistants=v2")
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to send DELETE request: %w", err)
 	}
@@ -221,9 +454,87 @@ func (c *Client) DeleteFile(vectorStoreID, fileID string) (model.File, error) {
 	if err != nil {
 		return model.File{}, fmt.Errorf("failed to read response: %w", err)
 	}
+	if resp.StatusCode != http.StatusOK {
+		return model.File{}, fmt.Errorf("failed to delete file %s from vector store: %w", fileID, apierr.NewStatusError(resp.StatusCode, string(respBytes)))
+	}
 	var fileObj model.File
 	if err := json.Unmarshal(respBytes, &fileObj); err != nil {
 		return model.File{}, fmt.Errorf("failed to unmarshal delete response: %w", err)
 	}
 	return fileObj, nil
 }
+
+// DeleteAllStorages deletes every vector store visible to the API key.
+// It keeps going after a failed deletion and reports every failure joined together.
+func (c *Client) DeleteAllStorages() error {
+	storages, err := c.ListStorages()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, vs := range storages {
+		if err := c.DeleteStorage(vs.ID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete vector store %s: %w", vs.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CleanupOrphanFiles deletes uploaded files (files API) that are not attached to any vector store.
+// Every orphan is attempted even when some deletions fail; the failures are returned joined together.
+func (c *Client) CleanupOrphanFiles() error {
+	storages, err := c.ListStorages()
+	if err != nil {
+		return err
+	}
+	attached := make(map[string]bool)
+	for _, vs := range storages {
+		files, err := c.ListFiles(vs.ID)
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			attached[f.ID] = true
+		}
+	}
+
+	uploaded, err := c.files().ListFiles(context.Background())
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, f := range uploaded {
+		if attached[f.ID] {
+			continue
+		}
+		if err := c.files().DeleteFile(context.Background(), f.ID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// files returns the files API client sharing this client's key, HTTP client and retry policy.
+func (c *Client) files() filesapi.Client {
+	return filesapi.Client{APIKey: c.APIKey, Do: c.do}
+}
+
+// Ping checks the API key and connectivity by listing a single vector store.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.openai.com/v1/vector_stores?limit=1", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.APIKey))
+	req.Header.Set("OpenAI-Beta", "assistants=v2")
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach OpenAI: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("vector storage health check failed: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
+	}
+	return nil
+}
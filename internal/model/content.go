@@ -0,0 +1,163 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Content is the content of a message in either of the forms the Responses API accepts: plain text, or a
+// list of structured parts such as {"type": "input_text", "text": "..."}. When Parts is nil, Text is used.
+type Content struct {
+	Text  string
+	Parts []map[string]string
+}
+
+// MarshalJSON encodes the content as a JSON string, or as an array of parts when Parts is set.
+func (c Content) MarshalJSON() ([]byte, error) {
+	if c.Parts != nil {
+		return json.Marshal(c.Parts)
+	}
+	return json.Marshal(c.Text)
+}
+
+// UnmarshalJSON accepts a JSON string, an array of parts with string fields, or null.
+func (c *Content) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	content, err := ContentOf(raw)
+	if err != nil {
+		return err
+	}
+	*c = content
+	return nil
+}
+
+// PlainText returns the text itself, or the text fields of the parts joined by newlines.
+func (c Content) PlainText() string {
+	if c.Parts == nil {
+		return c.Text
+	}
+	var texts []string
+	for _, part := range c.Parts {
+		if text, ok := part["text"]; ok {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// value returns the content as the plain Go value Message.Content holds: a string or []map[string]string.
+func (c Content) value() interface{} {
+	if c.Parts != nil {
+		return c.Parts
+	}
+	return c.Text
+}
+
+// ContentOf normalizes a Message.Content value: a string, structured parts as []map[string]string,
+// []map[string]interface{} or []interface{} (as decoded from JSON), a Content, or nil.
+func ContentOf(v interface{}) (Content, error) {
+	switch c := v.(type) {
+	case nil:
+		return Content{}, nil
+	case string:
+		return Content{Text: c}, nil
+	case Content:
+		return c, nil
+	case *Content:
+		if c == nil {
+			return Content{}, nil
+		}
+		return *c, nil
+	case []map[string]string:
+		return Content{Parts: c}, nil
+	case []map[string]interface{}:
+		parts := make([]map[string]string, 0, len(c))
+		for _, raw := range c {
+			part, err := stringPart(raw)
+			if err != nil {
+				return Content{}, err
+			}
+			parts = append(parts, part)
+		}
+		return Content{Parts: parts}, nil
+	case []interface{}:
+		parts := make([]map[string]string, 0, len(c))
+		for _, item := range c {
+			raw, ok := item.(map[string]interface{})
+			if !ok {
+				return Content{}, fmt.Errorf("unsupported content part type %T", item)
+			}
+			part, err := stringPart(raw)
+			if err != nil {
+				return Content{}, err
+			}
+			parts = append(parts, part)
+		}
+		return Content{Parts: parts}, nil
+	}
+	return Content{}, fmt.Errorf("unsupported message content type %T", v)
+}
+
+// stringPart converts a decoded content part whose fields are all strings.
+func stringPart(raw map[string]interface{}) (map[string]string, error) {
+	part := make(map[string]string, len(raw))
+	for key, value := range raw {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("content part field %q is %T, not a string", key, value)
+		}
+		part[key] = s
+	}
+	return part, nil
+}
+
+// NewTextMessage creates a message with plain text content.
+func NewTextMessage(role, text string) Message {
+	return Message{Role: role, Content: text}
+}
+
+// NewStructuredMessage creates a message whose content is the given parts.
+func NewStructuredMessage(role string, parts ...map[string]string) Message {
+	if parts == nil {
+		parts = []map[string]string{}
+	}
+	return Message{Role: role, Content: parts}
+}
+
+// Text returns the text of the message, whichever form its content has; unsupported content yields "".
+func (m Message) Text() string {
+	content, err := ContentOf(m.Content)
+	if err != nil {
+		return ""
+	}
+	return content.PlainText()
+}
+
+// MarshalJSON encodes the message with its content normalized, so every supported form of Content
+// produces the same JSON. Content of another type is encoded as is.
+func (m Message) MarshalJSON() ([]byte, error) {
+	type plain Message
+	if content, err := ContentOf(m.Content); err == nil {
+		m.Content = content.value()
+	}
+	return json.Marshal(plain(m))
+}
+
+// UnmarshalJSON decodes a message whose content is a string or an array of parts. Content then holds a
+// string or []map[string]string, the forms used when building requests.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		Role    string  `json:"role"`
+		Content Content `json:"content"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	m.Role = decoded.Role
+	m.Content = decoded.Content.value()
+	return nil
+}
@@ -1,6 +1,7 @@
 package model
 
 // Message represents a single message in a conversation.
+// Content is a string or structured parts ([]map[string]string); see Content and ContentOf.
 type Message struct {
 	Role    string      `json:"role"`
 	Content interface{} `json:"content"`
@@ -70,6 +71,24 @@ type WebSearch struct {
 	ContextSize  SearchContextSize      `json:"search_context_size,omitempty"` // e.g., "low", "medium", or "high"
 }
 
+// Citation is a web source the model cited in its answer.
+type Citation struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// SourceReportingClient is implemented by model clients that report which files an answer drew on.
+type SourceReportingClient interface {
+	// ChatAdvancedParsedWithSources works like ChatAdvancedParsed and also returns the distinct IDs of the
+	// files retrieved or cited for the answer, in order of first appearance.
+	ChatAdvancedParsedWithSources(request ChatRequest, target interface{}) ([]string, error)
+}
+
+// ReasoningOptions configures how much a reasoning model thinks before answering.
+type ReasoningOptions struct {
+	Effort string `json:"effort,omitempty"` // "low", "medium" or "high"
+}
+
 // ChatRequest represents the payload sent to the OpenAI API.
 // Note: the official Responses API uses "input" (not "messages") to pass the conversation.
 type ChatRequest struct {
@@ -78,6 +97,10 @@ type ChatRequest struct {
 	Temperature float64       `json:"temperature,omitempty"`
 	Text        *TextFormat   `json:"text,omitempty"`
 	Tools       []interface{} `json:"tools,omitempty"`
+	// Reasoning tunes reasoning models such as the o-series; clients leave it out for other models.
+	Reasoning *ReasoningOptions `json:"reasoning,omitempty"`
+	// NoCache makes clients with a response cache skip it, for calls whose answer should not be reused.
+	NoCache bool `json:"-"`
 }
 
 // ModelClient is an abstract, model-agnostic interface for interacting with a language model.
@@ -0,0 +1,7 @@
+package notify
+
+// Notifier sends short messages about agent activity to a chat channel.
+type Notifier interface {
+	// Notify posts message to channel. An empty channel means the notifier's default.
+	Notify(channel, message string) error
+}
@@ -0,0 +1,60 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/httputil"
+)
+
+// SlackNotifier implements notify.Notifier by posting to a Slack incoming webhook.
+type SlackNotifier struct {
+	WebhookURL string
+	Username   string // Optional display name for the messages.
+	HTTPClient *http.Client
+	Retry      httputil.RetryPolicy // retries of rate-limited and failing requests; zero sends each request once
+}
+
+// NewSlackNotifier creates a notifier for the given incoming webhook URL.
+func NewSlackNotifier(webhookURL string) *SlackNotifier {
+	return &SlackNotifier{
+		WebhookURL: webhookURL,
+		HTTPClient: &http.Client{},
+		Retry:      httputil.DefaultRetryPolicy,
+	}
+}
+
+// webhookPayload is the JSON body accepted by Slack incoming webhooks.
+type webhookPayload struct {
+	Text     string `json:"text"`
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// Notify posts message to the webhook. A non-empty channel overrides the webhook's default
+// channel where the webhook allows it.
+func (s *SlackNotifier) Notify(channel, message string) error {
+	data, err := json.Marshal(webhookPayload{Text: message, Channel: channel, Username: s.Username})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slack payload: %w", err)
+	}
+	req, err := http.NewRequest("POST", s.WebhookURL, bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("failed to create slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httputil.Do(req.Context(), s.HTTPClient, req, s.Retry)
+	if err != nil {
+		return fmt.Errorf("failed to post to slack: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to post to slack: %w", apierr.NewStatusError(resp.StatusCode, string(body)))
+	}
+	return nil
+}
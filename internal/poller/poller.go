@@ -0,0 +1,171 @@
+package poller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// TicketSource returns the tickets an agent should work on. agent.BaseAgent satisfies it.
+type TicketSource interface {
+	FindMyTickets() ([]board.Card, error)
+}
+
+// ChangedTicketSource is a TicketSource that can also return only the tickets changed since a given time.
+type ChangedTicketSource interface {
+	TicketSource
+	FindMyTicketsUpdatedSince(since time.Time) ([]board.Card, error)
+}
+
+// TicketHandler processes a single ticket.
+type TicketHandler func(ctx context.Context, ticket board.Card) error
+
+// Poller repeatedly fetches tickets and hands each one to the handler.
+// A failing or panicking ticket never stops the remaining tickets, and consecutive
+// poll failures back off exponentially up to MaxInterval.
+type Poller struct {
+	Source  TicketSource
+	Handler TicketHandler
+	// BaseInterval is the wait between successful polls.
+	BaseInterval time.Duration
+	// MaxInterval caps the wait after repeated poll failures.
+	MaxInterval time.Duration
+	// OnlyChanged makes every poll after the first fetch only the tickets changed since the
+	// previous successful poll. It requires Source to implement ChangedTicketSource.
+	// Tickets whose handler failed, or that were not reached before cancellation, are retried
+	// on the next poll even if they did not change again.
+	OnlyChanged bool
+
+	consecutiveFailures int
+	lastPoll            time.Time
+	// retry holds the tickets of the previous poll that were not handled successfully.
+	retry []board.Card
+}
+
+// New creates a Poller with a 30s base interval and a 10 minute backoff cap.
+func New(source TicketSource, handler TicketHandler) *Poller {
+	return &Poller{
+		Source:       source,
+		Handler:      handler,
+		BaseInterval: 30 * time.Second,
+		MaxInterval:  10 * time.Minute,
+	}
+}
+
+// ProcessOnce polls once and processes every ticket found.
+// It returns an error only when the tickets could not be fetched; per-ticket failures
+// (including panics) are logged and processing moves on to the next ticket.
+func (p *Poller) ProcessOnce(ctx context.Context) error {
+	pollStart := time.Now()
+	tickets, err := p.fetch()
+	if err != nil {
+		p.consecutiveFailures++
+		return fmt.Errorf("failed to fetch tickets: %w", err)
+	}
+	p.consecutiveFailures = 0
+	p.lastPoll = pollStart
+
+	var failed []board.Card
+	for i, ticket := range tickets {
+		if ctx.Err() != nil {
+			failed = append(failed, tickets[i:]...)
+			break
+		}
+		if err := p.handleSafely(ctx, ticket); err != nil {
+			log.Printf("Error processing ticket %q: %v", ticket.GetName(), err)
+			failed = append(failed, ticket)
+		}
+	}
+	p.retry = failed
+	return nil
+}
+
+// fetch returns the tickets to process: all of them, or with OnlyChanged those changed since the last
+// poll plus the ones left to retry from it.
+func (p *Poller) fetch() ([]board.Card, error) {
+	if changed, ok := p.Source.(ChangedTicketSource); ok && p.OnlyChanged && !p.lastPoll.IsZero() {
+		tickets, err := changed.FindMyTicketsUpdatedSince(p.lastPoll)
+		if err != nil {
+			return nil, err
+		}
+		return mergeTickets(p.retry, tickets), nil
+	}
+	return p.Source.FindMyTickets()
+}
+
+// mergeTickets appends the fetched tickets to the retried ones, skipping tickets already retried.
+// A fetched ticket replaces the retried copy so the handler sees its latest state.
+func mergeTickets(retry, fetched []board.Card) []board.Card {
+	if len(retry) == 0 {
+		return fetched
+	}
+	fresh := make(map[string]board.Card, len(fetched))
+	for _, t := range fetched {
+		fresh[t.GetURL()] = t
+	}
+	merged := make([]board.Card, 0, len(retry)+len(fetched))
+	for _, t := range retry {
+		if f, ok := fresh[t.GetURL()]; ok {
+			t = f
+			delete(fresh, t.GetURL())
+		}
+		merged = append(merged, t)
+	}
+	for _, t := range fetched {
+		if _, ok := fresh[t.GetURL()]; ok {
+			merged = append(merged, t)
+		}
+	}
+	return merged
+}
+
+// handleSafely runs the handler for one ticket, converting a panic into an error.
+func (p *Poller) handleSafely(ctx context.Context, ticket board.Card) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while handling ticket: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return p.Handler(ctx, ticket)
+}
+
+// NextInterval returns how long to wait before the next poll: the base interval after a
+// successful poll, doubled for each consecutive failure and capped at MaxInterval.
+func (p *Poller) NextInterval() time.Duration {
+	interval := p.BaseInterval
+	for i := 0; i < p.consecutiveFailures; i++ {
+		interval *= 2
+		if p.MaxInterval > 0 && interval >= p.MaxInterval {
+			return p.MaxInterval
+		}
+	}
+	return interval
+}
+
+// Run polls until ctx is cancelled, waiting interval between successful polls (backing off on failures).
+// Cancellation never interrupts a ticket mid-flight: the current ticket finishes, no further tickets
+// are started and no new poll is made.
+func (p *Poller) Run(ctx context.Context, interval time.Duration) error {
+	if interval > 0 {
+		p.BaseInterval = interval
+	}
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := p.ProcessOnce(ctx); err != nil {
+			log.Printf("Poll failed (%d consecutive): %v", p.consecutiveFailures, err)
+		}
+		timer := time.NewTimer(p.NextInterval())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
@@ -7,17 +7,25 @@ import (
 
 	"github.com/egobogo/aiagents/internal/config"
 	model "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/promptbuilder"
+	"github.com/egobogo/aiagents/internal/roles"
 	"github.com/invopop/jsonschema"
 )
 
 // FormatSchemaForModel uses the invopop/jsonschema Reflector to generate a JSON schema
 // from a given Go value. It disables automatic schema IDs by setting Anonymous to true.
+// A value with a JSONSchemaExtend method may adjust the schema generated for it.
 func FormatSchemaForModel(schema interface{}) (interface{}, error) {
 	r := &jsonschema.Reflector{
 		Anonymous:      true, // disable automatic schema IDs
 		DoNotReference: true, // do not generate $ref values
 	}
 	s := r.Reflect(schema)
+	// The reflector extends the schema of a zero value of the type. Let the value itself extend it too,
+	// for parts that depend on the request, such as the choices offered in it.
+	if ext, ok := schema.(interface{ JSONSchemaExtend(*jsonschema.Schema) }); ok {
+		ext.JSONSchemaExtend(s)
+	}
 	data, err := json.Marshal(s)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal schema: %w", err)
@@ -59,6 +67,8 @@ func WrapSchemaForArray(elementSchema interface{}) map[string]interface{} {
 // ChatGPTPromptBuilder implements the PromptBuilder interface for ChatGPT.
 type ChatGPTPromptBuilder struct{}
 
+var _ promptbuilder.PromptBuilder = (*ChatGPTPromptBuilder)(nil)
+
 // New returns a new instance of ChatGPTPromptBuilder.
 func New() *ChatGPTPromptBuilder {
 	return &ChatGPTPromptBuilder{}
@@ -67,55 +77,42 @@ func New() *ChatGPTPromptBuilder {
 // Build constructs a ChatRequest by assembling messages and output formatting.
 // If desiredOutput is provided, it generates a JSON Schema using reflection.
 // For slice types, it wraps the schema in an object with property "result".
+// It fails with promptbuilder.ErrPromptTooLarge when the prompt would not fit the model's context window.
 func (b *ChatGPTPromptBuilder) Build(role, mode, state, userInput string, desiredOutput interface{}, temperature float64, modelName string) (model.ChatRequest, error) {
-	// Retrieve the role instruction from configuration.
-	roleInstruction, err := config.GetRoleInstruction(role)
+	// Retrieve the role from configuration, or its built-in default.
+	roleConfig, err := roles.Get(role)
 	if err != nil {
 		return model.ChatRequest{}, fmt.Errorf("failed to get role instruction for %s: %w", role, err)
 	}
+	roleInstruction := roleConfig.Prompt
 
-	projectGoal := "Project: Create AI agent agile project team."
+	projectGoal := config.GetProjectGoal()
 	// Retrieve the mode-specific prompt from configuration or global mode.
-	modePrompt, err := config.GetRoleMode(role, mode)
+	modePrompt, err := roles.ModePrompt(roleConfig, mode)
 	if err != nil {
 		return model.ChatRequest{}, fmt.Errorf("failed to get mode prompt for %s in mode %s: %w", role, mode, err)
 	}
 
 	// Create messages with properly structured content.
-	systemMsg := model.Message{
-		Role: "system",
-		Content: []map[string]string{
-			{
-				"type": "input_text",
-				"text": fmt.Sprintf("The project you are working on:%s\nYour role in the project is:%s\n", projectGoal, roleInstruction),
-			},
-		},
-	}
+	systemMsg := model.NewStructuredMessage("system", map[string]string{
+		"type": "input_text",
+		"text": fmt.Sprintf("The project you are working on:%s\nYour role in the project is:%s\n", projectGoal, roleInstruction),
+	})
 
-	developerMsg := model.Message{
-		Role: "assistant",
-		Content: []map[string]string{
-			{
-				"type": "output_text",
-				"text": modePrompt,
-			},
-		},
-	}
+	developerMsg := model.NewStructuredMessage("assistant", map[string]string{
+		"type": "output_text",
+		"text": modePrompt,
+	})
 
-	userMsg := model.Message{
-		Role: "user",
-		Content: []map[string]string{
-			{
-				"type": "input_text",
-				"text": fmt.Sprintf("The things that you currently know are:\n%s\nInput is:\n%s", state, userInput),
-			},
-		},
-	}
+	userMsg := model.NewStructuredMessage("user", map[string]string{
+		"type": "input_text",
+		"text": fmt.Sprintf("The things that you currently know are:\n%s\nInput is:\n%s", state, userInput),
+	})
 
 	chatReq := model.ChatRequest{
 		Model:       modelName,
 		Input:       []model.Message{systemMsg, developerMsg, userMsg},
-		Temperature: 0.8,
+		Temperature: temperature,
 	}
 
 	if desiredOutput != nil {
@@ -158,9 +155,13 @@ func (b *ChatGPTPromptBuilder) Build(role, mode, state, userInput string, desire
 			},
 		}
 	}
+	if err := promptbuilder.CheckContextWindow(chatReq); err != nil {
+		return model.ChatRequest{}, err
+	}
 	return chatReq, nil
 }
 
+// AddFile attaches a file_search tool block over the given vector stores to the ChatRequest.
 func (b *ChatGPTPromptBuilder) AddFile(chatReq *model.ChatRequest, vectorStoreIDs []string) error {
 	if chatReq == nil {
 		return fmt.Errorf("chat request is nil")
@@ -181,5 +182,39 @@ func (b *ChatGPTPromptBuilder) AddWeb(chatReq *model.ChatRequest, webTool model.
 	}
 	// Append the webTool struct directly to the Tools field.
 	chatReq.Tools = append(chatReq.Tools, webTool)
+	// Web results change over time, so the answer must not be served from a response cache.
+	chatReq.NoCache = true
+	return nil
+}
+
+// AddImage appends an input_image block to the last user message of the ChatRequest.
+// If the request has no user message yet, a new one holding only the image is added.
+func (b *ChatGPTPromptBuilder) AddImage(chatReq *model.ChatRequest, imageURL string) error {
+	if chatReq == nil {
+		return fmt.Errorf("chat request is nil")
+	}
+	if imageURL == "" {
+		return fmt.Errorf("image URL is empty")
+	}
+	imageBlock := map[string]string{
+		"type":      "input_image",
+		"image_url": imageURL,
+	}
+	for i := len(chatReq.Input) - 1; i >= 0; i-- {
+		msg := &chatReq.Input[i]
+		if msg.Role != "user" {
+			continue
+		}
+		content, err := model.ContentOf(msg.Content)
+		if err != nil {
+			return fmt.Errorf("unsupported user message content: %w", err)
+		}
+		if content.Parts == nil {
+			content.Parts = []map[string]string{{"type": "input_text", "text": content.Text}}
+		}
+		msg.Content = append(content.Parts, imageBlock)
+		return nil
+	}
+	chatReq.Input = append(chatReq.Input, model.NewStructuredMessage("user", imageBlock))
 	return nil
 }
@@ -0,0 +1,39 @@
+package notoolspromptbuilder
+
+import (
+	"fmt"
+
+	model "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/promptbuilder"
+	"github.com/egobogo/aiagents/internal/promptbuilder/chatgptpromptbuilder"
+)
+
+// NoToolsPromptBuilder implements the PromptBuilder interface for models that do not support tools.
+// It builds the same messages and output format as ChatGPTPromptBuilder but rejects every tool attachment.
+type NoToolsPromptBuilder struct {
+	*chatgptpromptbuilder.ChatGPTPromptBuilder
+}
+
+var _ promptbuilder.PromptBuilder = (*NoToolsPromptBuilder)(nil)
+
+// New returns a new instance of NoToolsPromptBuilder.
+func New() *NoToolsPromptBuilder {
+	return &NoToolsPromptBuilder{
+		ChatGPTPromptBuilder: chatgptpromptbuilder.New(),
+	}
+}
+
+// AddFile always fails because file search is a tool.
+func (b *NoToolsPromptBuilder) AddFile(chatReq *model.ChatRequest, vectorStoreIDs []string) error {
+	return fmt.Errorf("cannot attach file search over %v: %w", vectorStoreIDs, promptbuilder.ErrToolsNotSupported)
+}
+
+// AddWeb always fails because web search is a tool.
+func (b *NoToolsPromptBuilder) AddWeb(chatReq *model.ChatRequest, webTool model.WebSearch) error {
+	return fmt.Errorf("cannot attach web search: %w", promptbuilder.ErrToolsNotSupported)
+}
+
+// AddImage always fails because image input is not available for text-only models.
+func (b *NoToolsPromptBuilder) AddImage(chatReq *model.ChatRequest, imageURL string) error {
+	return fmt.Errorf("cannot attach image %s: %w", imageURL, promptbuilder.ErrToolsNotSupported)
+}
@@ -1,10 +1,21 @@
 package promptbuilder
 
-import modelClient "github.com/egobogo/aiagents/internal/model"
+import (
+	"fmt"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+)
+
+// ErrToolsNotSupported is returned by builders whose target model cannot use tool blocks.
+var ErrToolsNotSupported = fmt.Errorf("prompt builder does not support tools")
 
 // PromptBuilder defines an interface for constructing a complete ChatRequest.
 type PromptBuilder interface {
 	Build(role, mode, state, userInput string, desiredOutput interface{}, temperature float64, modelName string) (modelClient.ChatRequest, error)
+	// AddFile attaches a file search tool over the given vector stores.
 	AddFile(chatReq *modelClient.ChatRequest, vectorStoreIDs []string) error
+	// AddWeb attaches a web search tool.
 	AddWeb(chatReq *modelClient.ChatRequest, webTool modelClient.WebSearch) error
+	// AddImage attaches an image (by URL or data URL) to the last user message.
+	AddImage(chatReq *modelClient.ChatRequest, imageURL string) error
 }
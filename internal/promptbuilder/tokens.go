@@ -0,0 +1,78 @@
+package promptbuilder
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/budget"
+	modelClient "github.com/egobogo/aiagents/internal/model"
+)
+
+// ErrPromptTooLarge is returned when a request's estimated prompt tokens exceed the model's context window.
+var ErrPromptTooLarge = fmt.Errorf("prompt exceeds model context window")
+
+// DefaultContextWindow is the context window assumed for models missing from ContextWindows.
+const DefaultContextWindow = 128000
+
+// ContextWindows holds the context window, in tokens, of known models. Dated snapshots such as
+// "gpt-4o-2024-08-06" use the entry of their longest matching prefix.
+var ContextWindows = map[string]int{
+	"gpt-4o":        128000,
+	"gpt-4o-mini":   128000,
+	"gpt-4-turbo":   128000,
+	"gpt-4":         8192,
+	"gpt-4.1":       1047576,
+	"gpt-4.1-mini":  1047576,
+	"gpt-4.1-nano":  1047576,
+	"gpt-3.5-turbo": 16385,
+	"o1":            200000,
+	"o3":            200000,
+	"o3-mini":       200000,
+	"o4-mini":       200000,
+}
+
+// ContextWindow returns the context window of a model, or DefaultContextWindow when it is unknown.
+func ContextWindow(model string) int {
+	if window, ok := ContextWindows[model]; ok {
+		return window
+	}
+	best, window := "", DefaultContextWindow
+	for name, w := range ContextWindows {
+		if strings.HasPrefix(model, name+"-") && len(name) > len(best) {
+			best, window = name, w
+		}
+	}
+	return window
+}
+
+// EstimateTokens approximates the prompt tokens of a request from the size of its messages and
+// output schema, at about four bytes per token. It slightly overestimates because JSON syntax is counted.
+func EstimateTokens(req modelClient.ChatRequest) (int, error) {
+	input, err := json.Marshal(req.Input)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode input: %w", err)
+	}
+	size := len(input)
+	if req.Text != nil {
+		format, err := json.Marshal(req.Text)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode output format: %w", err)
+		}
+		size += len(format)
+	}
+	return budget.EstimateTokens(size), nil
+}
+
+// CheckContextWindow returns an error wrapping ErrPromptTooLarge when the request's estimated
+// prompt tokens exceed the context window of its model.
+func CheckContextWindow(req modelClient.ChatRequest) error {
+	tokens, err := EstimateTokens(req)
+	if err != nil {
+		return err
+	}
+	if window := ContextWindow(req.Model); tokens > window {
+		return fmt.Errorf("about %d tokens for %s with a %d-token window: %w", tokens, req.Model, window, ErrPromptTooLarge)
+	}
+	return nil
+}
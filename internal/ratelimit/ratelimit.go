@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultRequestsPerMinute and DefaultBurst are used when NewLimiter is given non-positive values.
+const (
+	DefaultRequestsPerMinute = 300
+	DefaultBurst             = 10
+)
+
+// Limiter caps the rate of outgoing API requests with a token bucket.
+// A single Limiter can be shared between several clients so their combined rate stays under the cap;
+// requests over the rate wait for a token instead of failing.
+type Limiter struct {
+	limiter *rate.Limiter
+}
+
+// NewLimiter creates a Limiter allowing requestsPerMinute requests on average, with bursts of up to burst requests.
+func NewLimiter(requestsPerMinute, burst int) *Limiter {
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = DefaultRequestsPerMinute
+	}
+	if burst <= 0 {
+		burst = DefaultBurst
+	}
+	return &Limiter{limiter: rate.NewLimiter(rate.Limit(float64(requestsPerMinute)/60), burst)}
+}
+
+// Wrap returns a copy of client whose requests wait for the limiter before being sent.
+// A nil Limiter returns the client unchanged.
+func (l *Limiter) Wrap(client *http.Client) *http.Client {
+	if l == nil {
+		return client
+	}
+	wrapped := *client
+	wrapped.Transport = &transport{limiter: l.limiter, base: client.Transport}
+	return &wrapped
+}
+
+// transport is an http.RoundTripper that takes a token before every request.
+type transport struct {
+	limiter *rate.Limiter
+	base    http.RoundTripper
+}
+
+// RoundTrip waits for a token, honouring the request's context, then forwards the request.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, fmt.Errorf("rate limiter: %w", err)
+	}
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(req)
+}
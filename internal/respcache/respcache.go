@@ -0,0 +1,126 @@
+// Package respcache caches model responses so identical requests are only paid for once.
+package respcache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/model"
+)
+
+// DefaultEntries is the LRU capacity used when NewLRU is given a non-positive size.
+const DefaultEntries = 256
+
+// Cache stores model responses keyed by a hash of the request that produced them.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) (string, bool)
+	Set(key, response string)
+}
+
+// Key hashes everything in a request that affects the completion: model, input, temperature,
+// output format and tools.
+func Key(req model.ChatRequest) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode request for cache key: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// LRU is an in-memory Cache that evicts the least recently used response once full.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front is the most recently used
+	items    map[string]*list.Element
+}
+
+// lruEntry is the value stored in each list element.
+type lruEntry struct {
+	key, response string
+}
+
+// NewLRU creates an LRU holding up to entries responses.
+func NewLRU(entries int) *LRU {
+	if entries <= 0 {
+		entries = DefaultEntries
+	}
+	return &LRU{capacity: entries, order: list.New(), items: make(map[string]*list.Element)}
+}
+
+// Get returns the cached response for key and marks it as recently used.
+func (c *LRU) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry).response, true
+}
+
+// Set stores a response, evicting the least recently used one when the cache is full.
+func (c *LRU) Set(key, response string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[key]; ok {
+		el.Value.(*lruEntry).response = response
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[key] = c.order.PushFront(&lruEntry{key: key, response: response})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Disk is a Cache keeping one file per response in a directory, so cached responses survive restarts.
+type Disk struct {
+	Dir string
+}
+
+// NewDisk creates a Disk cache in dir, creating the directory if needed.
+func NewDisk(dir string) (*Disk, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &Disk{Dir: dir}, nil
+}
+
+// Get reads the cached response for key; unreadable entries count as misses.
+func (c *Disk) Get(key string) (string, bool) {
+	data, err := os.ReadFile(filepath.Join(c.Dir, key))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// Set writes the response through a temporary file so readers never see a partial entry.
+// Write failures only cost a future cache miss and are ignored.
+func (c *Disk) Set(key, response string) {
+	tmp, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.WriteString(response)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(c.Dir, key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,86 @@
+// Package roles resolves agent roles. The loaded configuration is the single source of truth;
+// the built-in defaults below are only used for roles and modes the configuration does not define.
+package roles
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// ErrUnknownRole is returned when a role is neither configured nor built in.
+var ErrUnknownRole = errors.New("unknown role")
+
+// RoleConfig describes a role: its instruction and the actions (modes) it performs.
+type RoleConfig = config.Role
+
+// defaults holds the built-in roles of the agents shipped with the project.
+var defaults = map[string]RoleConfig{
+	"EngineeringManager": {
+		Name:   "EngineeringManager",
+		Prompt: "You are the engineering manager. You break tickets down into technical tasks, keep the architecture consistent and answer developers' questions.",
+	},
+	"ProductManager": {
+		Name:   "ProductManager",
+		Prompt: "You are the product manager. You turn ideas into clear tickets with acceptance criteria and keep the backlog prioritized.",
+	},
+	"Developer": {
+		Name:   "Developer",
+		Prompt: "You are a software developer. You implement tickets with idiomatic, tested code that fits the existing repository.",
+	},
+	"QA": {
+		Name:   "QA",
+		Prompt: "You are the QA engineer. You verify that tickets meet their acceptance criteria and report failures precisely.",
+	},
+	"Designer": {
+		Name:   "Designer",
+		Prompt: "You are the product designer. You produce design specs that follow the project's brandbook.",
+	},
+}
+
+// Get returns the role with the given name: the configured role when the loaded configuration
+// defines it, otherwise the built-in default.
+func Get(name string) (RoleConfig, error) {
+	if cfg := config.GetLoadedConfig(); cfg != nil {
+		if role, ok := cfg.Roles[name]; ok {
+			return role, nil
+		}
+	}
+	if role, ok := defaults[name]; ok {
+		return role, nil
+	}
+	return RoleConfig{}, fmt.Errorf("role %q: %w", name, ErrUnknownRole)
+}
+
+// defaultModes holds the built-in prompts of modes the agents use without any configuration.
+var defaultModes = map[string]string{
+	"Decide": "Choose how the workflow continues. Reply with the option you pick, copied exactly, as chosenOption.",
+}
+
+// ModePrompt returns the prompt of a mode for the role: the prompt of the role's action in that mode
+// when it sets one, otherwise the global prompt of the mode from the loaded configuration, otherwise
+// the built-in default of the mode.
+func ModePrompt(role RoleConfig, mode string) (string, error) {
+	for _, act := range role.Actions {
+		if act.Mode == mode {
+			if act.Prompt != "" {
+				return act.Prompt, nil
+			}
+			break
+		}
+	}
+	cfg := config.GetLoadedConfig()
+	if cfg != nil {
+		if prompt, ok := cfg.GlobalModes[mode]; ok {
+			return prompt, nil
+		}
+	}
+	if prompt, ok := defaultModes[mode]; ok {
+		return prompt, nil
+	}
+	if cfg == nil {
+		return "", fmt.Errorf("mode %q for role %q: %w", mode, role.Name, config.ErrNotLoaded)
+	}
+	return "", fmt.Errorf("mode %q not found for role %q and no global mode available", mode, role.Name)
+}
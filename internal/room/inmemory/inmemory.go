@@ -2,6 +2,7 @@ package inmemory
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 
 	modelClient "github.com/egobogo/aiagents/internal/model"
@@ -12,6 +13,7 @@ import (
 type InMemoryRoom struct {
 	mu     sync.Mutex
 	agents map[string]participantWrapper
+	topics map[string]map[string]bool // topic -> subscribed agent names
 }
 
 type participantWrapper struct {
@@ -23,6 +25,7 @@ type participantWrapper struct {
 func NewInMemoryRoom() *InMemoryRoom {
 	return &InMemoryRoom{
 		agents: make(map[string]participantWrapper),
+		topics: make(map[string]map[string]bool),
 	}
 }
 
@@ -48,6 +51,9 @@ func (r *InMemoryRoom) LeaveRoom(agentName string) error {
 		return fmt.Errorf("agent %s not found", agentName)
 	}
 	delete(r.agents, agentName)
+	for _, subscribers := range r.topics {
+		delete(subscribers, agentName)
+	}
 	return nil
 }
 
@@ -76,10 +82,61 @@ func (r *InMemoryRoom) Ask(fromAgent, toAgent string, question []modelClient.Mes
 
 // Shout broadcasts a question (slice of messages) from one agent to all agents.
 func (r *InMemoryRoom) Shout(fromAgent string, question []modelClient.Message) (map[string][]modelClient.Message, error) {
+	return r.broadcast(question, func(name string, info room.AgentInfo) bool { return true }), nil
+}
+
+// ShoutToRole broadcasts a question to every agent whose role matches, ignoring case.
+func (r *InMemoryRoom) ShoutToRole(fromAgent, role string, question []modelClient.Message) (map[string][]modelClient.Message, error) {
+	return r.broadcast(question, func(name string, info room.AgentInfo) bool {
+		return strings.EqualFold(info.Role, role)
+	}), nil
+}
+
+// Subscribe adds a registered agent to a topic.
+func (r *InMemoryRoom) Subscribe(agentName, topic string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.agents[agentName]; !exists {
+		return fmt.Errorf("agent %s not found", agentName)
+	}
+	if r.topics[topic] == nil {
+		r.topics[topic] = make(map[string]bool)
+	}
+	r.topics[topic][agentName] = true
+	return nil
+}
+
+// Unsubscribe removes an agent from a topic.
+func (r *InMemoryRoom) Unsubscribe(agentName, topic string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.topics[topic][agentName] {
+		return fmt.Errorf("agent %s is not subscribed to %s", agentName, topic)
+	}
+	delete(r.topics[topic], agentName)
+	return nil
+}
+
+// ShoutToTopic broadcasts a question to every agent subscribed to the topic.
+func (r *InMemoryRoom) ShoutToTopic(fromAgent, topic string, question []modelClient.Message) (map[string][]modelClient.Message, error) {
+	r.mu.Lock()
+	subscribers := make(map[string]bool, len(r.topics[topic]))
+	for name := range r.topics[topic] {
+		subscribers[name] = true
+	}
+	r.mu.Unlock()
+	return r.broadcast(question, func(name string, info room.AgentInfo) bool { return subscribers[name] }), nil
+}
+
+// broadcast asks every agent selected by include and collects the answers.
+// A failing participant's entry holds a single "error" message.
+func (r *InMemoryRoom) broadcast(question []modelClient.Message, include func(name string, info room.AgentInfo) bool) map[string][]modelClient.Message {
 	r.mu.Lock()
 	agentsCopy := make(map[string]room.Participant)
 	for name, wrapper := range r.agents {
-		agentsCopy[name] = wrapper.participant
+		if include(name, wrapper.info) {
+			agentsCopy[name] = wrapper.participant
+		}
 	}
 	r.mu.Unlock()
 	responses := make(map[string][]modelClient.Message)
@@ -91,5 +148,5 @@ func (r *InMemoryRoom) Shout(fromAgent string, question []modelClient.Message) (
 			responses[name] = resp
 		}
 	}
-	return responses, nil
+	return responses
 }
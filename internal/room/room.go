@@ -26,4 +26,20 @@ type Room interface {
 	Ask(fromAgent, toAgent string, question []modelClient.Message) ([]modelClient.Message, error)
 	// Shout broadcasts a question (as a slice of messages) from one agent to all agents.
 	Shout(fromAgent string, question []modelClient.Message) (map[string][]modelClient.Message, error)
+	// ShoutToRole broadcasts a question to every agent with the given role.
+	ShoutToRole(fromAgent, role string, question []modelClient.Message) (map[string][]modelClient.Message, error)
+	// Subscribe adds an agent to a topic.
+	Subscribe(agentName, topic string) error
+	// Unsubscribe removes an agent from a topic.
+	Unsubscribe(agentName, topic string) error
+	// ShoutToTopic broadcasts a question to every agent subscribed to the topic.
+	ShoutToTopic(fromAgent, topic string, question []modelClient.Message) (map[string][]modelClient.Message, error)
+}
+
+// ParticipantFunc adapts a function to the Participant interface, e.g. an agent's AnswerMessages method.
+type ParticipantFunc func(question []modelClient.Message) ([]modelClient.Message, error)
+
+// Answer calls f(question).
+func (f ParticipantFunc) Answer(question []modelClient.Message) ([]modelClient.Message, error) {
+	return f(question)
 }
@@ -0,0 +1,109 @@
+package room
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+)
+
+// Exchange is one recorded question and answer between two agents.
+type Exchange struct {
+	Time     time.Time             `json:"time"`
+	From     string                `json:"from"`
+	To       string                `json:"to"`
+	Via      string                `json:"via"` // "ask", "shout", "role:<role>" or "topic:<topic>"
+	Question []modelClient.Message `json:"question"`
+	Answer   []modelClient.Message `json:"answer,omitempty"`
+	Error    string                `json:"error,omitempty"`
+}
+
+// TranscriptRoom decorates a Room and records every exchange that passes through it.
+// Each exchange is kept in memory and, if a writer is set, written to it as a JSON line.
+type TranscriptRoom struct {
+	Room
+
+	mu        sync.Mutex
+	w         io.Writer
+	exchanges []Exchange
+}
+
+// NewTranscriptRoom wraps inner. w may be nil to keep the transcript in memory only.
+func NewTranscriptRoom(inner Room, w io.Writer) *TranscriptRoom {
+	return &TranscriptRoom{Room: inner, w: w}
+}
+
+// Transcript returns a copy of the recorded exchanges in the order they completed.
+func (t *TranscriptRoom) Transcript() []Exchange {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Exchange(nil), t.exchanges...)
+}
+
+// Ask forwards the question and records the exchange.
+func (t *TranscriptRoom) Ask(fromAgent, toAgent string, question []modelClient.Message) ([]modelClient.Message, error) {
+	answer, err := t.Room.Ask(fromAgent, toAgent, question)
+	ex := Exchange{From: fromAgent, To: toAgent, Via: "ask", Question: question, Answer: answer}
+	if err != nil {
+		ex.Error = err.Error()
+	}
+	t.record(ex)
+	return answer, err
+}
+
+// Shout forwards the broadcast and records one exchange per responder.
+func (t *TranscriptRoom) Shout(fromAgent string, question []modelClient.Message) (map[string][]modelClient.Message, error) {
+	answers, err := t.Room.Shout(fromAgent, question)
+	t.recordBroadcast(fromAgent, "shout", question, answers)
+	return answers, err
+}
+
+// ShoutToRole forwards the broadcast and records one exchange per responder.
+func (t *TranscriptRoom) ShoutToRole(fromAgent, role string, question []modelClient.Message) (map[string][]modelClient.Message, error) {
+	answers, err := t.Room.ShoutToRole(fromAgent, role, question)
+	t.recordBroadcast(fromAgent, "role:"+role, question, answers)
+	return answers, err
+}
+
+// ShoutToTopic forwards the broadcast and records one exchange per responder.
+func (t *TranscriptRoom) ShoutToTopic(fromAgent, topic string, question []modelClient.Message) (map[string][]modelClient.Message, error) {
+	answers, err := t.Room.ShoutToTopic(fromAgent, topic, question)
+	t.recordBroadcast(fromAgent, "topic:"+topic, question, answers)
+	return answers, err
+}
+
+// recordBroadcast records the answers of a broadcast in a stable, name-sorted order.
+func (t *TranscriptRoom) recordBroadcast(fromAgent, via string, question []modelClient.Message, answers map[string][]modelClient.Message) {
+	names := make([]string, 0, len(answers))
+	for name := range answers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		t.record(Exchange{From: fromAgent, To: name, Via: via, Question: question, Answer: answers[name]})
+	}
+}
+
+// record timestamps an exchange, stores it and writes it to the writer, if any.
+// Write failures are logged so auditing never breaks a conversation.
+func (t *TranscriptRoom) record(ex Exchange) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ex.Time = time.Now()
+	t.exchanges = append(t.exchanges, ex)
+	if t.w == nil {
+		return
+	}
+	line, err := json.Marshal(ex)
+	if err != nil {
+		fmt.Printf("Warning: failed to encode room exchange: %v\n", err)
+		return
+	}
+	if _, err := t.w.Write(append(line, '\n')); err != nil {
+		fmt.Printf("Warning: failed to write room exchange: %v\n", err)
+	}
+}
@@ -0,0 +1,76 @@
+// Package testutil holds helpers shared by tests that need real local resources instead of live services.
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// SeedFile is the file committed to every GitRemote before it is cloned.
+const SeedFile = "README.md"
+
+// GitRemote is a local bare repository standing in for a hosted remote, with a working clone of it,
+// so push and pull can be tested offline.
+type GitRemote struct {
+	// BarePath is the bare repository; use it as the remote URL.
+	BarePath string
+	// ClonePath is a working clone of BarePath with "origin" pointing at it.
+	ClonePath string
+}
+
+// NewGitRemote creates a bare repository holding one commit of SeedFile and a working clone of it,
+// both in temporary directories removed when the test ends.
+func NewGitRemote(t testing.TB) *GitRemote {
+	t.Helper()
+	barePath := filepath.Join(t.TempDir(), "remote.git")
+	if _, err := git.PlainInit(barePath, true); err != nil {
+		t.Fatalf("failed to init bare repository: %v", err)
+	}
+
+	seedPath := t.TempDir()
+	seed, err := git.PlainInit(seedPath, false)
+	if err != nil {
+		t.Fatalf("failed to init seed repository: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(seedPath, SeedFile), []byte("# test repository\n"), 0644); err != nil {
+		t.Fatalf("failed to write seed file: %v", err)
+	}
+	worktree, err := seed.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get seed worktree: %v", err)
+	}
+	if _, err := worktree.Add(SeedFile); err != nil {
+		t.Fatalf("failed to stage seed file: %v", err)
+	}
+	if _, err := worktree.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "testutil", Email: "testutil@example.com", When: time.Now()},
+	}); err != nil {
+		t.Fatalf("failed to commit seed file: %v", err)
+	}
+	if _, err := seed.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{barePath}}); err != nil {
+		t.Fatalf("failed to add remote: %v", err)
+	}
+	if err := seed.Push(&git.PushOptions{}); err != nil {
+		t.Fatalf("failed to push seed commit: %v", err)
+	}
+
+	remote := &GitRemote{BarePath: barePath}
+	remote.ClonePath = remote.Clone(t)
+	return remote
+}
+
+// Clone makes another working clone of the bare repository and returns its path.
+func (r *GitRemote) Clone(t testing.TB) string {
+	t.Helper()
+	clonePath := filepath.Join(t.TempDir(), "clone")
+	if _, err := git.PlainClone(clonePath, false, &git.CloneOptions{URL: r.BarePath}); err != nil {
+		t.Fatalf("failed to clone %s: %v", r.BarePath, err)
+	}
+	return clonePath
+}
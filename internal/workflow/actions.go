@@ -0,0 +1,72 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// ErrUnknownAction is returned by Execute when the current step's action has no registered handler.
+var ErrUnknownAction = errors.New("unknown workflow action")
+
+// ActionContext is what an action handler receives when its step is executed.
+type ActionContext struct {
+	Step    config.Step      // The step being executed.
+	Manager *WorkflowManager // The workflow the step belongs to.
+	Agent   agent.Agent      // The agent acting on the ticket; may be nil.
+	Board   board.Board      // The board holding the ticket; may be nil.
+	Ticket  board.Card       // The ticket the workflow is running for; may be nil.
+}
+
+// ActionFunc performs the behavior behind a step's action.
+type ActionFunc func(ctx ActionContext) error
+
+var (
+	actionsMu sync.RWMutex
+	actions   = make(map[string]ActionFunc)
+)
+
+// RegisterAction makes fn the handler for steps whose action is name, replacing any previous handler.
+func RegisterAction(name string, fn func(ctx ActionContext) error) {
+	actionsMu.Lock()
+	defer actionsMu.Unlock()
+	actions[name] = fn
+}
+
+// lookupAction returns the handler registered for name.
+func lookupAction(name string) (ActionFunc, bool) {
+	actionsMu.RLock()
+	defer actionsMu.RUnlock()
+	fn, ok := actions[name]
+	return fn, ok
+}
+
+// Execute runs the registered handler for the current step's action, passing the manager's
+// Agent, Board and Ticket. A step without an action is a no-op.
+func (wm *WorkflowManager) Execute() error {
+	current, err := wm.CurrentStep()
+	if err != nil {
+		return err
+	}
+	if current.Action == "" {
+		return nil
+	}
+	fn, ok := lookupAction(current.Action)
+	if !ok {
+		return fmt.Errorf("step %q: %w %q", current.ID, ErrUnknownAction, current.Action)
+	}
+	if err := fn(ActionContext{
+		Step:    current,
+		Manager: wm,
+		Agent:   wm.Agent,
+		Board:   wm.Board,
+		Ticket:  wm.Ticket,
+	}); err != nil {
+		return fmt.Errorf("action %q of step %q failed: %w", current.Action, current.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+// CurrentColumn returns the board list name for the current step: the step's Column,
+// or its Name when no column is configured.
+func (wm *WorkflowManager) CurrentColumn() (string, error) {
+	step, err := wm.CurrentStep()
+	if err != nil {
+		return "", err
+	}
+	if step.Column != "" {
+		return step.Column, nil
+	}
+	if step.Name == "" {
+		return "", fmt.Errorf("step %q has neither a column nor a name", step.ID)
+	}
+	return step.Name, nil
+}
+
+// AdvanceCard moves card to the list mapped to the workflow's current step, creating the
+// list on b if it does not exist yet. Call it after NextStep to keep the board in sync.
+func AdvanceCard(b board.Board, card board.Card, wm *WorkflowManager) error {
+	column, err := wm.CurrentColumn()
+	if err != nil {
+		return err
+	}
+	if current, err := card.GetList(); err == nil && strings.EqualFold(current.GetName(), column) {
+		return nil
+	}
+
+	lists, err := b.GetLists()
+	if err != nil {
+		return fmt.Errorf("failed to get lists: %w", err)
+	}
+	found := false
+	for _, l := range lists {
+		if strings.EqualFold(l.GetName(), column) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		if _, err := b.CreateList(column); err != nil {
+			return fmt.Errorf("failed to create list %q: %w", column, err)
+		}
+	}
+	if err := card.Move(column); err != nil {
+		return fmt.Errorf("failed to move card %q to %q: %w", card.GetName(), column, err)
+	}
+	return nil
+}
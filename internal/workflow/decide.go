@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+// ErrNoDecision is returned by DecideNext when the model never picks a valid option and the step has no default.
+var ErrNoDecision = errors.New("no valid workflow decision")
+
+// maxDecisionAttempts is how many times the model is asked before falling back to the default branch.
+const maxDecisionAttempts = 2
+
+// Decision is the structured answer the model gives when choosing the next workflow step.
+type Decision struct {
+	ChosenOption string `json:"chosenOption"`
+}
+
+// DecideNext asks the agent's model to choose among the current step's next choices, advances the
+// workflow to the chosen step and returns its ID. A step with a single choice advances without asking,
+// and a step without choices returns ErrNoDecision. The decision is a single structured request in the
+// "Decide" mode; it neither creates thoughts nor changes the agent's memory.
+// An answer that matches no option is re-prompted once; after that the default branch is taken, if any.
+func DecideNext(wm *WorkflowManager, a *agent.BaseAgent) (string, error) {
+	current, err := wm.CurrentStep()
+	if err != nil {
+		return "", err
+	}
+	choices, err := wm.NextChoices()
+	if err != nil {
+		return "", err
+	}
+	switch len(choices) {
+	case 0:
+		return "", fmt.Errorf("step %q has no next steps: %w", current.ID, ErrNoDecision)
+	case 1:
+		return choices[0].NextStep, wm.NextStep(choices[0].NextStep)
+	}
+
+	var options strings.Builder
+	for _, c := range choices {
+		fmt.Fprintf(&options, "- %s (leads to: %s)\n", c.Option, c.Name)
+	}
+	stepContext := fmt.Sprintf("Workflow step: %s\n%s", current.Name, current.Description)
+	prompt := fmt.Sprintf("Choose how the workflow continues. Answer with exactly one of these options:\n%s", options.String())
+
+	for attempt := 0; attempt < maxDecisionAttempts; attempt++ {
+		decision, err := askDecision(a, stepContext, prompt)
+		if err != nil {
+			return "", err
+		}
+		if next, ok := matchChoice(choices, decision.ChosenOption); ok {
+			return next, wm.NextStep(next)
+		}
+		prompt = fmt.Sprintf("%q is not one of the options. Answer with exactly one of:\n%s", decision.ChosenOption, options.String())
+	}
+
+	for _, c := range choices {
+		if c.Default {
+			return c.NextStep, wm.NextStep(c.NextStep)
+		}
+	}
+	return "", fmt.Errorf("step %q: %w", current.ID, ErrNoDecision)
+}
+
+// askDecision sends one Decide request with the step as context and parses the model's choice.
+func askDecision(a *agent.BaseAgent, stepContext, prompt string) (Decision, error) {
+	chatReq, err := a.PromptBuilder.Build(
+		a.Role,
+		"Decide",
+		stepContext,
+		prompt,
+		Decision{},
+		config.GetModeTemperature("Decide", a.ModelClient.GetTemperature()),
+		config.GetModeModel("Decide", a.ModelClient.GetModel()),
+	)
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to build decision request: %w", err)
+	}
+	var decision Decision
+	if err := a.ModelClient.ChatAdvancedParsed(chatReq, &decision); err != nil {
+		return Decision{}, fmt.Errorf("failed to ask for a decision: %w", err)
+	}
+	return decision, nil
+}
+
+// matchChoice maps a chosen label, or a step ID, back to the next step.
+func matchChoice(choices []DecisionOption, chosen string) (string, bool) {
+	chosen = strings.TrimSpace(chosen)
+	for _, c := range choices {
+		if strings.EqualFold(c.Option, chosen) || c.NextStep == chosen {
+			return c.NextStep, true
+		}
+	}
+	return "", false
+}
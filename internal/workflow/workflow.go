@@ -6,6 +6,8 @@ import (
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
 	"github.com/egobogo/aiagents/internal/config"
 )
 
@@ -15,41 +17,153 @@ type DecisionOption struct {
 	NextStep string // The ID of the target step
 	Name     string // The target step's name
 	Action   string // The target step's action
+	Default  bool   // Whether NextStep falls back to this choice when asked for a step that is not offered
 }
 
-// WorkflowManager controls the workflow state.
+// WorkflowManager controls the workflow state. It only reads Config, which may be shared, for example
+// the value of config.GetLoadedConfig; the position lives in the manager.
 type WorkflowManager struct {
 	Config      *config.Config
-	currentStep string   // current step ID
+	currentStep string   // current step ID; starts at Config.WorkflowControl.CurrentStep
+	startStep   string   // step the workflow started at; Reset returns to it
+	history     []string // steps left by NextStep, most recent last; Back pops it
 	StepsOrder  []string // ordered list of step IDs
+
+	// Agent, Board and Ticket are handed to action handlers by Execute. They are optional.
+	Agent  agent.Agent
+	Board  board.Board
+	Ticket board.Card
+
+	index *stepIndex // steps and their normalized choices by step ID; built from Config
+}
+
+// stepIndex caches the lookups derived from the workflow configuration.
+type stepIndex struct {
+	steps      map[string]config.Step
+	choices    map[string][]DecisionOption
+	choiceErrs map[string]error
 }
 
 // NewWorkflowManager creates a new WorkflowManager using the loaded configuration.
 func NewWorkflowManager(cfg *config.Config) *WorkflowManager {
-	return &WorkflowManager{
+	wm := &WorkflowManager{
 		Config:      cfg,
 		currentStep: cfg.WorkflowControl.CurrentStep,
 		StepsOrder:  cfg.WorkflowControl.StepsOrder,
 	}
+	wm.startStep = startStep(cfg)
+	wm.index = newStepIndex(cfg)
+	return wm
+}
+
+// startStep returns the configured current step, or the first step of the workflow when none is set.
+func startStep(cfg *config.Config) string {
+	switch {
+	case cfg.WorkflowControl.CurrentStep != "":
+		return cfg.WorkflowControl.CurrentStep
+	case len(cfg.WorkflowControl.StepsOrder) > 0:
+		return cfg.WorkflowControl.StepsOrder[0]
+	case len(cfg.Workflow.Steps) > 0:
+		return cfg.Workflow.Steps[0].ID
+	}
+	return ""
+}
+
+// Reset returns the workflow to the step it started at and clears the history.
+func (wm *WorkflowManager) Reset() error {
+	if _, ok := wm.lookups().steps[wm.startStep]; !ok {
+		return fmt.Errorf("start step %q not found in workflow", wm.startStep)
+	}
+	wm.currentStep = wm.startStep
+	wm.history = nil
+	return nil
+}
+
+// Back returns to the step the last NextStep left.
+func (wm *WorkflowManager) Back() error {
+	if len(wm.history) == 0 {
+		return errors.New("no previous step to go back to")
+	}
+	prev := wm.history[len(wm.history)-1]
+	wm.history = wm.history[:len(wm.history)-1]
+	wm.currentStep = prev
+	return nil
+}
+
+// Clone returns a copy of the manager whose position and history move independently, e.g. to simulate a
+// branch. The Config, the Agent, the Board and the Ticket are shared.
+func (wm *WorkflowManager) Clone() *WorkflowManager {
+	clone := *wm
+	clone.StepsOrder = append([]string(nil), wm.StepsOrder...)
+	clone.history = append([]string(nil), wm.history...)
+	return &clone
+}
+
+// Reload replaces the workflow configuration, e.g. after the config file was loaded again, and rebuilds
+// the step lookups. The current step is kept.
+func (wm *WorkflowManager) Reload(cfg *config.Config) {
+	wm.Config = cfg
+	wm.StepsOrder = cfg.WorkflowControl.StepsOrder
+	wm.index = newStepIndex(cfg)
+}
+
+// newStepIndex indexes the steps of cfg and normalizes the next choices of each of them once.
+func newStepIndex(cfg *config.Config) *stepIndex {
+	idx := &stepIndex{
+		steps:      make(map[string]config.Step, len(cfg.Workflow.Steps)),
+		choices:    make(map[string][]DecisionOption, len(cfg.Workflow.Steps)),
+		choiceErrs: make(map[string]error),
+	}
+	for _, step := range cfg.Workflow.Steps {
+		// The first step with an ID wins, as with a linear search.
+		if _, ok := idx.steps[step.ID]; !ok {
+			idx.steps[step.ID] = step
+		}
+	}
+	for id, step := range idx.steps {
+		choices, err := buildChoices(step, idx.steps)
+		if err != nil {
+			idx.choiceErrs[id] = err
+			continue
+		}
+		idx.choices[id] = choices
+	}
+	return idx
+}
+
+// stepIndex returns the lookups for the current configuration, building them on first use.
+func (wm *WorkflowManager) lookups() *stepIndex {
+	if wm.index == nil {
+		wm.index = newStepIndex(wm.Config)
+	}
+	return wm.index
 }
 
 // CurrentStep returns the current workflow step.
 func (wm *WorkflowManager) CurrentStep() (config.Step, error) {
-	for _, step := range wm.Config.Workflow.Steps {
-		if step.ID == wm.currentStep {
-			return step, nil
-		}
+	if step, ok := wm.lookups().steps[wm.currentStep]; ok {
+		return step, nil
 	}
 	return config.Step{}, fmt.Errorf("current step %q not found", wm.currentStep)
 }
 
 // NextChoices returns a unified slice of DecisionOption for the current step.
-// It handles both decision branches (via Options or Next) and simple next steps.
+// The choices are normalized once per configuration; the returned slice is a copy.
 func (wm *WorkflowManager) NextChoices() ([]DecisionOption, error) {
 	current, err := wm.CurrentStep()
 	if err != nil {
 		return nil, err
 	}
+	idx := wm.lookups()
+	if err := idx.choiceErrs[current.ID]; err != nil {
+		return nil, err
+	}
+	return append([]DecisionOption(nil), idx.choices[current.ID]...), nil
+}
+
+// buildChoices normalizes the next choices of a step into a unified slice of DecisionOption.
+// It handles both decision branches (via Options or Next) and simple next steps.
+func buildChoices(current config.Step, steps map[string]config.Step) ([]DecisionOption, error) {
 	var choices []DecisionOption
 
 	// First, if the step has structured decision options (Options field), use those.
@@ -60,16 +174,14 @@ func (wm *WorkflowManager) NextChoices() ([]DecisionOption, error) {
 		}
 		for _, opt := range opts {
 			// Find the target step.
-			for _, step := range wm.Config.Workflow.Steps {
-				if step.ID == opt.NextStep {
-					choices = append(choices, DecisionOption{
-						Option:   opt.Option,
-						NextStep: opt.NextStep,
-						Name:     step.Name,
-						Action:   step.Action,
-					})
-					break
-				}
+			if step, ok := steps[opt.NextStep]; ok {
+				choices = append(choices, DecisionOption{
+					Option:   opt.Option,
+					NextStep: opt.NextStep,
+					Name:     step.Name,
+					Action:   step.Action,
+					Default:  opt.Default,
+				})
 			}
 		}
 	} else if current.Next != nil {
@@ -77,16 +189,13 @@ func (wm *WorkflowManager) NextChoices() ([]DecisionOption, error) {
 		switch v := current.Next.(type) {
 		case string:
 			// Single next step.
-			for _, step := range wm.Config.Workflow.Steps {
-				if step.ID == v {
-					choices = append(choices, DecisionOption{
-						Option:   "Continue", // default label
-						NextStep: v,
-						Name:     step.Name,
-						Action:   step.Action,
-					})
-					break
-				}
+			if step, ok := steps[v]; ok {
+				choices = append(choices, DecisionOption{
+					Option:   "Continue", // default label
+					NextStep: v,
+					Name:     step.Name,
+					Action:   step.Action,
+				})
 			}
 		case map[interface{}]interface{}:
 			decision, ok := v["decision"]
@@ -102,16 +211,14 @@ func (wm *WorkflowManager) NextChoices() ([]DecisionOption, error) {
 				if err != nil {
 					return nil, err
 				}
-				for _, step := range wm.Config.Workflow.Steps {
-					if step.ID == nextID {
-						choices = append(choices, DecisionOption{
-							Option:   optText,
-							NextStep: nextID,
-							Name:     step.Name,
-							Action:   step.Action,
-						})
-						break
-					}
+				if step, ok := steps[nextID]; ok {
+					choices = append(choices, DecisionOption{
+						Option:   optText,
+						NextStep: nextID,
+						Name:     step.Name,
+						Action:   step.Action,
+						Default:  isDefaultOption(rawOpt),
+					})
 				}
 			}
 		case []interface{}:
@@ -138,16 +245,14 @@ func (wm *WorkflowManager) NextChoices() ([]DecisionOption, error) {
 					if err != nil {
 						return nil, err
 					}
-					for _, step := range wm.Config.Workflow.Steps {
-						if step.ID == nextID {
-							choices = append(choices, DecisionOption{
-								Option:   optText,
-								NextStep: nextID,
-								Name:     step.Name,
-								Action:   step.Action,
-							})
-							break
-						}
+					if step, ok := steps[nextID]; ok {
+						choices = append(choices, DecisionOption{
+							Option:   optText,
+							NextStep: nextID,
+							Name:     step.Name,
+							Action:   step.Action,
+							Default:  isDefaultOption(rawOpt),
+						})
 					}
 				}
 			}
@@ -165,26 +270,54 @@ func (wm *WorkflowManager) NextChoices() ([]DecisionOption, error) {
 }
 
 // NextStep advances the workflow to the specified next step if it is valid.
+// When nextID is not among the choices but one of them is marked as default, the workflow moves there instead.
 func (wm *WorkflowManager) NextStep(nextID string) error {
 	choices, err := wm.NextChoices()
 	if err != nil {
 		return err
 	}
 	valid := false
+	fallback := ""
 	for _, c := range choices {
 		if c.NextStep == nextID {
 			valid = true
 			break
 		}
+		if c.Default && fallback == "" {
+			fallback = c.NextStep
+		}
+	}
+	if !valid && fallback != "" {
+		nextID, valid = fallback, true
 	}
 	if !valid {
 		return fmt.Errorf("step %q is not a valid next choice from current step %q", nextID, wm.currentStep)
 	}
+	wm.history = append(wm.history, wm.currentStep)
 	wm.currentStep = nextID
-	wm.Config.WorkflowControl.CurrentStep = nextID
 	return nil
 }
 
+// AutoAdvance moves to the next step when the current step has a single plain next step and no decision.
+// It reports whether the workflow advanced; callers can loop on it until a real choice is needed.
+func (wm *WorkflowManager) AutoAdvance() (bool, error) {
+	current, err := wm.CurrentStep()
+	if err != nil {
+		return false, err
+	}
+	if current.Options != nil {
+		return false, nil
+	}
+	nextID, ok := current.Next.(string)
+	if !ok {
+		return false, nil
+	}
+	if err := wm.NextStep(nextID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // getDecisionOptions normalizes the Options field of a step into a slice of DecisionOption.
 // This function assumes that the step has an Options field set.
 func getDecisionOptions(s config.Step) ([]DecisionOption, error) {
@@ -208,6 +341,7 @@ func getDecisionOptions(s config.Step) ([]DecisionOption, error) {
 			} else {
 				return nil, fmt.Errorf("missing or invalid 'nextStep' key in step %q", s.ID)
 			}
+			opt.Default = isDefaultOption(raw)
 			opts = append(opts, opt)
 			continue
 		}
@@ -223,6 +357,7 @@ func getDecisionOptions(s config.Step) ([]DecisionOption, error) {
 			} else {
 				return nil, fmt.Errorf("missing or invalid 'nextStep' key in step %q", s.ID)
 			}
+			opt.Default = isDefaultOption(raw)
 			opts = append(opts, opt)
 			continue
 		}
@@ -231,6 +366,27 @@ func getDecisionOptions(s config.Step) ([]DecisionOption, error) {
 	return opts, nil
 }
 
+// isDefaultOption reports whether a raw decision option is marked with `default: true`.
+func isDefaultOption(opt interface{}) bool {
+	switch m := opt.(type) {
+	case map[string]interface{}:
+		isDefault, _ := m["default"].(bool)
+		return isDefault
+	case map[interface{}]interface{}:
+		isDefault, _ := m["default"].(bool)
+		return isDefault
+	case *yaml.Node:
+		var decoded struct {
+			Default bool `yaml:"default"`
+		}
+		if err := m.Decode(&decoded); err != nil {
+			return false
+		}
+		return decoded.Default
+	}
+	return false
+}
+
 // extractNextDetails extracts the nextStep ID and option text from a raw option.
 // Returns (nextStep, optionText, error)
 func extractNextDetails(opt interface{}, stepID string) (string, string, error) {
@@ -279,12 +435,9 @@ func extractNextDetails(opt interface{}, stepID string) (string, string, error)
 
 // SetCurrentStep sets the current step to the given step ID if it exists.
 func (wm *WorkflowManager) SetCurrentStep(stepID string) error {
-	for _, step := range wm.Config.Workflow.Steps {
-		if step.ID == stepID {
-			wm.currentStep = stepID
-			wm.Config.WorkflowControl.CurrentStep = stepID
-			return nil
-		}
+	if _, ok := wm.lookups().steps[stepID]; ok {
+		wm.currentStep = stepID
+		return nil
 	}
 	return fmt.Errorf("step %q not found in workflow", stepID)
 }
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+)
+
+func TestWithTicketRunsOverlappingHandlersOnce(t *testing.T) {
+	a := &agent.BaseAgent{Name: "Manager"}
+	card := &fakeCard{name: "Login page"}
+
+	// The first goroutine to acquire the ticket blocks until the other one has been turned away.
+	var runs int32
+	proceed := make(chan struct{})
+	var wg sync.WaitGroup
+	handled := make([]bool, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			handled[i], _ = a.WithTicket(card, func() error {
+				atomic.AddInt32(&runs, 1)
+				<-proceed
+				return nil
+			})
+			if !handled[i] {
+				// The second goroutine gave up while the first still holds the ticket.
+				close(proceed)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if runs != 1 {
+		t.Fatalf("expected the handler body to run once, ran %d times", runs)
+	}
+	if handled[0] == handled[1] {
+		t.Fatalf("expected exactly one goroutine to handle the ticket, got %v", handled)
+	}
+	if a.CurrentTicketID != "" {
+		t.Fatalf("expected CurrentTicketID to be cleared after release, got %q", a.CurrentTicketID)
+	}
+	if ok, _ := a.WithTicket(card, func() error { return nil }); !ok {
+		t.Fatal("expected the released ticket to be processed again")
+	}
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+func TestBaseAgentTicketHelpers(t *testing.T) {
+	card := &fakeCard{name: "Login page", list: "Backlog", members: []string{"Manager"}}
+	other := &fakeCard{name: "Billing", list: "Backlog", members: []string{"Developer"}}
+	a := &agent.BaseAgent{Name: "Manager", BoardClient: &fakeBoard{cards: []*fakeCard{card, other}}}
+
+	tickets, err := a.GetAssignedTickets()
+	if err != nil {
+		t.Fatalf("GetAssignedTickets failed: %v", err)
+	}
+	if len(tickets) != 1 || tickets[0].GetName() != "Login page" {
+		t.Fatalf("unexpected assigned tickets: %v", tickets)
+	}
+
+	if err := a.ChangeTicketColumn(card, "In Progress"); err != nil {
+		t.Fatalf("ChangeTicketColumn failed: %v", err)
+	}
+	if card.list != "In Progress" {
+		t.Errorf("expected card in In Progress, got %q", card.list)
+	}
+
+	if err := a.ChangeTicketAssignee(card, "Manager", "Developer"); err != nil {
+		t.Fatalf("ChangeTicketAssignee failed: %v", err)
+	}
+	if len(card.members) != 1 || card.members[0] != "Developer" {
+		t.Errorf("expected only Developer assigned, got %v", card.members)
+	}
+
+	if err := a.AssignTicketToAgent(card, "QA"); err != nil {
+		t.Fatalf("AssignTicketToAgent failed: %v", err)
+	}
+	if len(card.members) != 2 {
+		t.Errorf("expected QA to be added, got %v", card.members)
+	}
+
+	if err := a.WriteComment(card, "Handed over to development."); err != nil {
+		t.Fatalf("WriteComment failed: %v", err)
+	}
+	if len(card.comments) != 1 || card.comments[0] != "Handed over to development." {
+		t.Errorf("unexpected comments: %v", card.comments)
+	}
+}
+
+func TestBaseAgentWriteToGit(t *testing.T) {
+	a := &agent.BaseAgent{}
+	if err := a.WriteToGit("main.go", []byte("package main\n")); err == nil {
+		t.Fatal("expected an error without a git client")
+	}
+
+	dir := t.TempDir()
+	a.GitClient = &gitrepo.GitClient{RepoPath: dir}
+	if err := a.WriteToGit("main.go", []byte("package main\n")); err != nil {
+		t.Fatalf("WriteToGit failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "main.go"))
+	if err != nil || string(data) != "package main\n" {
+		t.Fatalf("unexpected file content %q (err %v)", data, err)
+	}
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+	"github.com/egobogo/aiagents/internal/docs/notion"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+)
+
+func statusTransport(status int) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(status, `{"error":"failure"}`), nil
+	})}
+}
+
+func TestNotionErrorsAreTyped(t *testing.T) {
+	nc := notion.NewNotionClient("token", "root")
+	nc.HTTPClient = statusTransport(http.StatusNotFound)
+	if _, err := nc.ReadPage("missing"); !errors.Is(err, apierr.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestTrelloErrorsAreTyped(t *testing.T) {
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	tc.Client.Client = statusTransport(http.StatusNotFound)
+	if _, err := tc.GetCard("missing"); !errors.Is(err, apierr.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	tc.Client.Client = statusTransport(http.StatusUnauthorized)
+	if _, err := tc.GetLists(); !errors.Is(err, apierr.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestVectorStorageErrorsAreTyped(t *testing.T) {
+	vs := vectorstorage.NewClient("key")
+	vs.HTTPClient = statusTransport(http.StatusUnauthorized)
+	if err := vs.DeleteStorage("vs_1"); !errors.Is(err, apierr.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestModelErrorsAreTyped(t *testing.T) {
+	client := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", nil)
+	client.HTTPClient = statusTransport(http.StatusTooManyRequests)
+	client.Retry = fastRetry
+	if _, err := client.Chat("hello"); !errors.Is(err, apierr.ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+}
+
+func TestGitPushErrorsAreTyped(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	remotePath := t.TempDir()
+	if _, err := git.PlainInit(remotePath, true); err != nil {
+		t.Fatalf("failed to init remote: %v", err)
+	}
+	gc, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	if _, err := gc.Repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}}); err != nil {
+		t.Fatalf("failed to add remote: %v", err)
+	}
+	if err := gc.PushChanges("", ""); err != nil {
+		t.Fatalf("first push failed: %v", err)
+	}
+	if err := gc.PushChanges("", ""); !errors.Is(err, apierr.ErrAlreadyUpToDate) {
+		t.Fatalf("expected ErrAlreadyUpToDate, got %v", err)
+	}
+}
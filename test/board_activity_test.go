@@ -0,0 +1,225 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+	"github.com/egobogo/aiagents/internal/poller"
+)
+
+func TestTrelloGetCardsUpdatedSince(t *testing.T) {
+	since := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	actions := []struct {
+		card string
+		date time.Time
+	}{
+		{"stale", since.Add(-time.Hour)},
+		{"changed", since.Add(time.Minute)},
+		{"commented", since.Add(2 * time.Minute)},
+		{"changed", since.Add(3 * time.Minute)},
+		{"deleted", since.Add(4 * time.Minute)},
+	}
+
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		path := req.URL.Path
+		switch {
+		case strings.HasSuffix(path, "/boards/board1/actions"):
+			after, err := time.Parse(time.RFC3339Nano, req.URL.Query().Get("since"))
+			if err != nil {
+				t.Fatalf("bad since parameter: %v", err)
+			}
+			var out []map[string]interface{}
+			for _, a := range actions {
+				if a.date.After(after) {
+					out = append(out, map[string]interface{}{
+						"type": "updateCard", "date": a.date,
+						"data": map[string]interface{}{"card": map[string]string{"id": a.card}},
+					})
+				}
+			}
+			body, _ := json.Marshal(out)
+			return jsonResponse(http.StatusOK, string(body)), nil
+		case strings.HasSuffix(path, "/cards/deleted"):
+			return jsonResponse(http.StatusNotFound, `"The requested resource was not found."`), nil
+		case strings.Contains(path, "/cards/"):
+			id := path[strings.LastIndex(path, "/")+1:]
+			return jsonResponse(http.StatusOK, `{"id":"`+id+`","name":"`+id+`","idBoard":"board1","idList":"list1"}`), nil
+		case strings.HasSuffix(path, "/lists/list1"):
+			return jsonResponse(http.StatusOK, `{"id":"list1","name":"Doing"}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	cards, err := tc.GetCardsUpdatedSince(since)
+	if err != nil {
+		t.Fatalf("GetCardsUpdatedSince failed: %v", err)
+	}
+	var names []string
+	for _, c := range cards {
+		names = append(names, c.GetName())
+	}
+	if strings.Join(names, ",") != "changed,commented" {
+		t.Fatalf("expected changed,commented, got %v", names)
+	}
+}
+
+func TestTrelloGetCardsUpdatedSincePagesThroughActions(t *testing.T) {
+	since := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	// 1500 actions, newest first; only the oldest one touches the card "oldest".
+	type action struct{ id, card string }
+	var actions []action
+	for i := 1499; i >= 0; i-- {
+		card := fmt.Sprintf("busy%d", i%3)
+		if i == 0 {
+			card = "oldest"
+		}
+		actions = append(actions, action{id: fmt.Sprintf("a%04d", i), card: card})
+	}
+
+	var pages []string
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		path := req.URL.Path
+		switch {
+		case strings.HasSuffix(path, "/boards/board1/actions"):
+			before := req.URL.Query().Get("before")
+			limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+			pages = append(pages, before)
+			var out []map[string]interface{}
+			for _, a := range actions {
+				if before != "" && a.id >= before {
+					continue
+				}
+				if len(out) == limit {
+					break
+				}
+				out = append(out, map[string]interface{}{
+					"id": a.id, "type": "updateCard",
+					"data": map[string]interface{}{"card": map[string]string{"id": a.card}},
+				})
+			}
+			body, _ := json.Marshal(out)
+			return jsonResponse(http.StatusOK, string(body)), nil
+		case strings.Contains(path, "/cards/"):
+			id := path[strings.LastIndex(path, "/")+1:]
+			return jsonResponse(http.StatusOK, `{"id":"`+id+`","name":"`+id+`","idBoard":"board1","idList":"list1"}`), nil
+		case strings.HasSuffix(path, "/lists/list1"):
+			return jsonResponse(http.StatusOK, `{"id":"list1","name":"Doing"}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	cards, err := tc.GetCardsUpdatedSince(since)
+	if err != nil {
+		t.Fatalf("GetCardsUpdatedSince failed: %v", err)
+	}
+	if len(pages) != 2 || pages[0] != "" || pages[1] != "a0500" {
+		t.Fatalf("expected a second page before a0500, got pages %q", pages)
+	}
+	var names []string
+	for _, c := range cards {
+		names = append(names, c.GetName())
+	}
+	if strings.Join(names, ",") != "busy2,busy1,busy0,oldest" {
+		t.Fatalf("expected every touched card once, got %v", names)
+	}
+}
+
+// countingTicketSource counts how each poll fetched its tickets.
+type countingTicketSource struct {
+	*agent.BaseAgent
+	full, incremental int
+}
+
+func (s *countingTicketSource) FindMyTickets() ([]board.Card, error) {
+	s.full++
+	return s.BaseAgent.FindMyTickets()
+}
+
+func (s *countingTicketSource) FindMyTicketsUpdatedSince(since time.Time) ([]board.Card, error) {
+	s.incremental++
+	return s.BaseAgent.FindMyTicketsUpdatedSince(since)
+}
+
+func TestPollerOnlyChangedProcessesChangedTickets(t *testing.T) {
+	old := &fakeCard{name: "Old", members: []string{"Manager"}, updated: time.Now().Add(-time.Hour)}
+	fresh := &fakeCard{name: "Fresh", members: []string{"Manager"}}
+	others := &fakeCard{name: "Others", members: []string{"Developer"}}
+	source := &countingTicketSource{BaseAgent: &agent.BaseAgent{
+		Name:        "Manager",
+		BoardClient: &fakeBoard{cards: []*fakeCard{old, fresh, others}},
+	}}
+
+	var handled []string
+	p := poller.New(source, func(ctx context.Context, ticket board.Card) error {
+		handled = append(handled, ticket.GetName())
+		return nil
+	})
+	p.OnlyChanged = true
+
+	if err := p.ProcessOnce(context.Background()); err != nil {
+		t.Fatalf("first poll failed: %v", err)
+	}
+	fresh.updated = time.Now().Add(time.Minute)
+	others.updated = time.Now().Add(time.Minute)
+	handled = nil
+	if err := p.ProcessOnce(context.Background()); err != nil {
+		t.Fatalf("second poll failed: %v", err)
+	}
+
+	if source.full != 1 || source.incremental != 1 {
+		t.Errorf("expected one full and one incremental poll, got %d and %d", source.full, source.incremental)
+	}
+	if len(handled) != 1 || handled[0] != "Fresh" {
+		t.Fatalf("expected only Fresh on the second poll, got %v", handled)
+	}
+}
+
+func TestPollerOnlyChangedRetriesFailedTickets(t *testing.T) {
+	flaky := &fakeCard{name: "Flaky", members: []string{"Manager"}}
+	source := &countingTicketSource{BaseAgent: &agent.BaseAgent{
+		Name:        "Manager",
+		BoardClient: &fakeBoard{cards: []*fakeCard{flaky}},
+	}}
+
+	fail := true
+	var handled []string
+	p := poller.New(source, func(ctx context.Context, ticket board.Card) error {
+		handled = append(handled, ticket.GetName())
+		if fail {
+			return errors.New("model unavailable")
+		}
+		return nil
+	})
+	p.OnlyChanged = true
+
+	if err := p.ProcessOnce(context.Background()); err != nil {
+		t.Fatalf("first poll failed: %v", err)
+	}
+	// The card does not change again, but its failed handling is retried.
+	flaky.updated = time.Now().Add(-time.Hour)
+	fail = false
+	if err := p.ProcessOnce(context.Background()); err != nil {
+		t.Fatalf("second poll failed: %v", err)
+	}
+	if err := p.ProcessOnce(context.Background()); err != nil {
+		t.Fatalf("third poll failed: %v", err)
+	}
+
+	if strings.Join(handled, ",") != "Flaky,Flaky" {
+		t.Fatalf("expected the failed ticket to be retried once, got %v", handled)
+	}
+}
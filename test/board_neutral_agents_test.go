@@ -0,0 +1,81 @@
+package test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// TestAgentsWorkOnAnyBoardCard runs a developer's technical assignment and the manager's answer
+// against a board.Card that is not backed by Trello.
+func TestAgentsWorkOnAnyBoardCard(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gc, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	fake := &fakeCard{name: "Greeting helper", list: "In Progress", members: []string{"Developer", "EngineeringManager"}, comments: []string{"@manager which language?"}}
+	var ticket board.Card = fake
+
+	em := &agent.EngineeringManagerAgent{BaseAgent: &agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   &fakeModelClient{parsed: `{"answer":"English."}`},
+		BoardClient:   &fakeBoard{cards: []*fakeCard{fake}},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: &fakePromptBuilder{},
+	}}
+	dev := agent.NewDeveloperAgent(&agent.BaseAgent{
+		Name:          "Developer",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{parsed: generatedCodeResponse},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: &fakePromptBuilder{},
+		GitClient:     gc,
+	})
+
+	if err := em.HandleOpenClarifications(); err != nil {
+		t.Fatalf("HandleOpenClarifications failed: %v", err)
+	}
+	if _, err := dev.ImplementTicket(ticket); err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "internal", "greet", "greet.go")); err != nil {
+		t.Errorf("expected the assignment to be implemented: %v", err)
+	}
+	last := fake.comments[len(fake.comments)-1]
+	if !strings.HasPrefix(last, "Implementation: internal/greet/greet.go") {
+		t.Errorf("expected the implementation summary on the card, got %q", fake.comments)
+	}
+}
+
+// TestAgentPackagesDoNotImportTrello keeps ticket handling board-neutral: agents and workflows
+// only see board.Card and board.BoardClient.
+func TestAgentPackagesDoNotImportTrello(t *testing.T) {
+	for _, dir := range []string{"../internal/agent", "../internal/workflow"} {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			t.Fatalf("failed to list %s: %v", dir, err)
+		}
+		for _, file := range files {
+			parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ImportsOnly)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", file, err)
+			}
+			for _, imp := range parsed.Imports {
+				path, _ := strconv.Unquote(imp.Path.Value)
+				if strings.Contains(path, "trello") {
+					t.Errorf("%s imports %s", file, path)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+)
+
+func TestBoardSnapshotRoundTripsThroughFakeBoard(t *testing.T) {
+	source := &fakeBoard{
+		lists:   []string{"Backlog", "In Progress", "Done"},
+		members: []board.Member{{ID: "Reviewer", Name: "Reviewer"}},
+		cards: []*fakeCard{
+			{name: "Login page", description: "OAuth login", list: "In Progress", members: []string{"Developer"}, labels: []string{"frontend"}, comments: []string{"Started", "@manager which provider?"}},
+			{name: "Payments", list: "Backlog"},
+		},
+	}
+	snapshot, err := source.ExportBoard()
+	if err != nil {
+		t.Fatalf("ExportBoard failed: %v", err)
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded board.BoardSnapshot
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	reloaded, err := loadFakeBoard(decoded).ExportBoard()
+	if err != nil {
+		t.Fatalf("ExportBoard of the reloaded board failed: %v", err)
+	}
+	if !reflect.DeepEqual(snapshot, reloaded) {
+		t.Fatalf("snapshots differ:\n%+v\n%+v", snapshot, reloaded)
+	}
+	if len(reloaded.Members) != 2 {
+		t.Errorf("expected the card assignee and the extra member, got %+v", reloaded.Members)
+	}
+}
+
+func TestTrelloExportBoard(t *testing.T) {
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch path := req.URL.Path; {
+		case path == "/1/boards/board1":
+			return jsonResponse(http.StatusOK, `{"id":"board1","name":"Team board"}`), nil
+		case path == "/1/boards/board1/lists":
+			return jsonResponse(http.StatusOK, `[{"id":"l1","name":"To Do"},{"id":"l2","name":"Doing"}]`), nil
+		case path == "/1/boards/board1/members":
+			return jsonResponse(http.StatusOK, `[{"id":"m1","username":"dev","fullName":"Developer"}]`), nil
+		case path == "/1/boards/board1/cards" && req.URL.Query().Get("before") != "":
+			return jsonResponse(http.StatusOK, `[]`), nil
+		case path == "/1/boards/board1/cards":
+			return jsonResponse(http.StatusOK, `[
+				{"id":"c1","name":"Login page","desc":"OAuth login","idList":"l2","idMembers":["m1"],"labels":[{"name":"frontend","color":"blue"},{"name":"","color":"red"}]},
+				{"id":"c2","name":"Payments","idList":"l1"}
+			]`), nil
+		case path == "/1/cards/c1/actions":
+			return jsonResponse(http.StatusOK, `[{"id":"a2","data":{"text":"Second"},"memberCreator":{"id":"m1","fullName":"Developer"}},{"id":"a1","data":{"text":"First"}}]`), nil
+		case strings.HasPrefix(path, "/1/cards/") && strings.HasSuffix(path, "/actions"):
+			return jsonResponse(http.StatusOK, `[]`), nil
+		}
+		t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		return jsonResponse(http.StatusNotFound, `"not found"`), nil
+	})}
+
+	snapshot, err := tc.ExportBoard()
+	if err != nil {
+		t.Fatalf("ExportBoard failed: %v", err)
+	}
+	want := board.BoardSnapshot{
+		Name:    "Team board",
+		Lists:   []string{"To Do", "Doing"},
+		Members: []board.Member{{ID: "m1", Name: "Developer"}},
+		Cards: []board.CardSnapshot{
+			{
+				ID: "c1", Name: "Login page", Description: "OAuth login", List: "Doing",
+				Assignees: []string{"Developer"}, Labels: []string{"frontend", "red"},
+				Comments: []board.Comment{{Text: "First"}, {Text: "Second", Member: &board.Member{ID: "m1", Name: "Developer"}}},
+			},
+			{ID: "c2", Name: "Payments", List: "To Do"},
+		},
+	}
+	if !reflect.DeepEqual(snapshot, want) {
+		t.Fatalf("unexpected snapshot:\n%+v\nwant:\n%+v", snapshot, want)
+	}
+
+	// The snapshot seeds a fake board with the same cards.
+	fake := loadFakeBoard(snapshot)
+	cards, _ := fake.GetCardsFromList("Doing")
+	if len(cards) != 1 || cards[0].GetName() != "Login page" {
+		t.Errorf("expected the Doing card on the fake board, got %v", cards)
+	}
+}
@@ -0,0 +1,53 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/budget"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+func TestBudgetGuardTripsAfterCeiling(t *testing.T) {
+	calls := 0
+	client := chatgpt.NewChatGPTClient("test-key", "gpt-4o-mini", nil)
+	client.Budget = budget.NewBudgetGuard(1.5, map[string]float64{"gpt-4o-mini": 0.5})
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		body := `{"output":[{"type":"message","content":[{"text":"ok"}]}],"usage":{"input_tokens":600,"output_tokens":400,"total_tokens":1000}}`
+		return jsonResponse(http.StatusOK, body), nil
+	})}
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Chat("hello"); err != nil {
+			t.Fatalf("call %d failed before the ceiling was reached: %v", i+1, err)
+		}
+	}
+	if _, err := client.Chat("hello"); !errors.Is(err, budget.ErrBudgetExceeded) {
+		t.Fatalf("expected ErrBudgetExceeded on the fourth call, got: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 requests to reach the API, got %d", calls)
+	}
+	if spent := client.Budget.Spent(); spent != 1.5 {
+		t.Fatalf("expected $1.5 spent, got $%v", spent)
+	}
+}
+
+func TestConfigLoadsBudgetSection(t *testing.T) {
+	loadTestConfig(t, `
+budget:
+  ceiling: 12.5
+  rates:
+    text-embedding-3-small: 0.00002
+`)
+	cfg := config.GetLoadedConfig().Budget
+	if cfg.Ceiling != 12.5 {
+		t.Errorf("expected a $12.5 ceiling, got %v", cfg.Ceiling)
+	}
+	if rate := cfg.Rates["text-embedding-3-small"]; rate != 0.00002 {
+		t.Errorf("expected the embedding rate to be loaded, got %v", rate)
+	}
+}
@@ -0,0 +1,88 @@
+package test
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/respcache"
+)
+
+// countingChatClient returns a ChatGPTClient whose transport answers every request with text and counts the calls.
+func countingChatClient(calls *int32, text string) *chatgpt.ChatGPTClient {
+	client := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", nil)
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(calls, 1)
+		return jsonResponse(http.StatusOK, messageResponse(text)), nil
+	})}
+	return client
+}
+
+func TestChatGPTClientCachesIdenticalRequests(t *testing.T) {
+	var calls int32
+	client := countingChatClient(&calls, "summary")
+	client.Cache = respcache.NewLRU(8)
+
+	req := modelClient.ChatRequest{Input: []modelClient.Message{{Role: "user", Content: "Summarize the docs"}}, Temperature: 0.3}
+	for i := 0; i < 2; i++ {
+		text, err := client.ChatAdvanced(req)
+		if err != nil {
+			t.Fatalf("ChatAdvanced failed: %v", err)
+		}
+		if text != "summary" {
+			t.Fatalf("unexpected response %q", text)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected identical requests to hit the transport once, got %d", calls)
+	}
+
+	req.Temperature = 0.9
+	if _, err := client.ChatAdvanced(req); err != nil {
+		t.Fatalf("ChatAdvanced failed: %v", err)
+	}
+	req.NoCache = true
+	if _, err := client.ChatAdvanced(req); err != nil {
+		t.Fatalf("ChatAdvanced failed: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected a different temperature and a NoCache request to reach the transport, got %d calls", calls)
+	}
+}
+
+func TestDiskResponseCacheSurvivesNewClient(t *testing.T) {
+	dir := t.TempDir()
+	req := modelClient.ChatRequest{Input: []modelClient.Message{{Role: "user", Content: "Summarize the docs"}}}
+
+	var calls int32
+	for i := 0; i < 2; i++ {
+		cache, err := respcache.NewDisk(dir)
+		if err != nil {
+			t.Fatalf("NewDisk failed: %v", err)
+		}
+		client := countingChatClient(&calls, "summary")
+		client.Cache = cache
+		if text, err := client.ChatAdvanced(req); err != nil || text != "summary" {
+			t.Fatalf("ChatAdvanced returned %q, %v", text, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the second client to be served from disk, got %d calls", calls)
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := respcache.NewLRU(2)
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+	cache.Get("a")
+	cache.Set("c", "3")
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if v, ok := cache.Get("a"); !ok || v != "1" {
+		t.Errorf("expected a to be kept, got %q, %v", v, ok)
+	}
+}
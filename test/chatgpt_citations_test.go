@@ -0,0 +1,52 @@
+package test
+
+import (
+	"net/http"
+	"testing"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+const citedResponse = `{
+  "output": [
+    {"type": "web_search_call", "status": "completed"},
+    {"type": "message", "content": [{
+      "type": "output_text",
+      "text": "Go 1.22 changed loop variable scoping.",
+      "annotations": [
+        {"type": "url_citation", "start_index": 0, "end_index": 7, "url": "https://go.dev/blog/loopvar-preview", "title": "Fixing For Loops in Go 1.22"},
+        {"type": "url_citation", "start_index": 8, "end_index": 20, "url": "https://go.dev/doc/go1.22", "title": "Go 1.22 Release Notes"},
+        {"type": "url_citation", "start_index": 21, "end_index": 30, "url": "https://go.dev/doc/go1.22", "title": "Go 1.22 Release Notes"}
+      ]
+    }]}
+  ]
+}`
+
+func TestChatAdvancedWithCitationsExtractsURLCitations(t *testing.T) {
+	client := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", nil)
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, citedResponse), nil
+	})}
+
+	req := modelClient.ChatRequest{Input: []modelClient.Message{{Role: "user", Content: "What changed in Go 1.22?"}}}
+	text, citations, err := client.ChatAdvancedWithCitations(req)
+	if err != nil {
+		t.Fatalf("ChatAdvancedWithCitations failed: %v", err)
+	}
+	if text != "Go 1.22 changed loop variable scoping." {
+		t.Fatalf("unexpected text %q", text)
+	}
+	want := []modelClient.Citation{
+		{URL: "https://go.dev/blog/loopvar-preview", Title: "Fixing For Loops in Go 1.22"},
+		{URL: "https://go.dev/doc/go1.22", Title: "Go 1.22 Release Notes"},
+	}
+	if len(citations) != len(want) {
+		t.Fatalf("expected %d distinct citations, got %+v", len(want), citations)
+	}
+	for i := range want {
+		if citations[i] != want[i] {
+			t.Errorf("citation %d: expected %+v, got %+v", i, want[i], citations[i])
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/httputil"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+func TestDeleteAllFilesReportsFailuresAndDeletesTheRest(t *testing.T) {
+	var deleted []string
+	client := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", nil)
+	client.Retry = httputil.RetryPolicy{}
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v1/files":
+			if req.URL.Query().Get("after") == "" {
+				return jsonResponse(http.StatusOK, `{"data":[{"id":"file_1"},{"id":"file_2"}],"has_more":true}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"file_3"}],"has_more":false}`), nil
+		case req.Method == "DELETE" && strings.HasPrefix(req.URL.Path, "/v1/files/"):
+			id := strings.TrimPrefix(req.URL.Path, "/v1/files/")
+			if id == "file_2" {
+				return jsonResponse(http.StatusInternalServerError, `{"error":"boom"}`), nil
+			}
+			deleted = append(deleted, id)
+			return jsonResponse(http.StatusOK, `{"deleted":true}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	err := client.DeleteAllFiles()
+	if err == nil {
+		t.Fatal("expected the failed deletion to be reported")
+	}
+	var statusErr *apierr.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected the 500 response in the error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "file_2") || !strings.Contains(err.Error(), "1 of 3") {
+		t.Fatalf("expected the error to name file_2 and count the failures, got %v", err)
+	}
+	sort.Strings(deleted)
+	if strings.Join(deleted, ",") != "file_1,file_3" {
+		t.Fatalf("expected the other files on both pages to be deleted, got %v", deleted)
+	}
+}
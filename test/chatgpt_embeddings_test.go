@@ -0,0 +1,66 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/context/embedding/openai"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+func TestChatGPTClientEmbeddings(t *testing.T) {
+	var requests int
+	client := chatgpt.NewChatGPTClient("secret", "gpt-4o-mini", nil)
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		if req.Method != http.MethodPost || req.URL.String() != "https://api.openai.com/v1/embeddings" {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		body, _ := io.ReadAll(req.Body)
+		var payload struct {
+			Model string   `json:"model"`
+			Input []string `json:"input"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("invalid request body %s: %v", body, err)
+		}
+		if payload.Model != chatgpt.DefaultEmbeddingModel || !reflect.DeepEqual(payload.Input, []string{"alpha", "beta", "gamma"}) {
+			t.Errorf("unexpected request body %s", body)
+		}
+		// Results arrive out of order and are placed by index.
+		return jsonResponse(http.StatusOK, `{"data":[
+			{"embedding":[3],"index":2},
+			{"embedding":[1],"index":0},
+			{"embedding":[2],"index":1}
+		],"usage":{"total_tokens":3}}`), nil
+	})}
+
+	got, err := client.Embeddings(context.Background(), []string{"alpha", "beta", "gamma"})
+	if err != nil {
+		t.Fatalf("Embeddings failed: %v", err)
+	}
+	if want := [][]float64{{1}, {2}, {3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	// The embedding provider delegates to the chat client when one is set.
+	provider := openai.NewOpenAIEmbeddingProvider("other", chatgpt.DefaultEmbeddingModel)
+	provider.SetEmbedder(client)
+	batch, err := provider.ComputeEmbeddings([]string{"alpha", "beta", "gamma"})
+	if err != nil {
+		t.Fatalf("ComputeEmbeddings failed: %v", err)
+	}
+	if !reflect.DeepEqual(batch, got) {
+		t.Errorf("expected the delegated embeddings %v, got %v", got, batch)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests through the chat client, got %d", requests)
+	}
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+func TestChatGPTClientFallsBackOnUnavailableModel(t *testing.T) {
+	var requested []string
+	client := chatgpt.NewChatGPTClient("test-key", "retired-model", nil)
+	client.SetFallbackModels("gpt-4o-mini")
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Model string `json:"model"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		requested = append(requested, body.Model)
+		if body.Model == "retired-model" {
+			return jsonResponse(http.StatusNotFound, `{"error":{"message":"model not found"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, messageResponse("served by "+body.Model)), nil
+	})}
+
+	resp, err := client.Chat("hello")
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if resp != "served by gpt-4o-mini" {
+		t.Fatalf("expected response from fallback model, got %q", resp)
+	}
+	if len(requested) != 2 || requested[0] != "retired-model" || requested[1] != "gpt-4o-mini" {
+		t.Fatalf("unexpected model order: %v", requested)
+	}
+}
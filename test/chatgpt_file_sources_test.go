@@ -0,0 +1,55 @@
+package test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+const fileCitedResponse = `{
+  "output": [
+    {"type": "file_search_call", "status": "completed", "queries": ["workflow"], "results": [
+      {"file_id": "file-workflow", "filename": "workflow.go", "score": 0.91},
+      {"file_id": "file-config", "filename": "config.go", "score": 0.72}
+    ]},
+    {"type": "message", "content": [{
+      "type": "output_text",
+      "text": "{\"result\": [{\"content\": \"Steps are indexed by ID.\", \"category\": \"architecture\", \"importance\": 3}]}",
+      "annotations": [
+        {"type": "file_citation", "index": 10, "file_id": "file-workflow", "filename": "workflow.go"},
+        {"type": "file_citation", "index": 20, "file_id": "file-agent", "filename": "agent.go"},
+        {"type": "file_citation", "index": 30, "file_id": "file-agent", "filename": "agent.go"}
+      ]
+    }]}
+  ]
+}`
+
+func TestChatAdvancedParsedWithSourcesExtractsFileIDs(t *testing.T) {
+	client := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", nil)
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, fileCitedResponse), nil
+	})}
+
+	var wrapper struct {
+		Result []struct {
+			Content string `json:"content"`
+		} `json:"result"`
+	}
+	req := modelClient.ChatRequest{Input: []modelClient.Message{{Role: "user", Content: "Study the workflow files."}}}
+	sources, err := client.ChatAdvancedParsedWithSources(req, &wrapper)
+	if err != nil {
+		t.Fatalf("ChatAdvancedParsedWithSources failed: %v", err)
+	}
+	if len(wrapper.Result) != 1 || wrapper.Result[0].Content != "Steps are indexed by ID." {
+		t.Fatalf("unexpected parsed result %+v", wrapper.Result)
+	}
+	want := []string{"file-workflow", "file-config", "file-agent"}
+	if !reflect.DeepEqual(sources, want) {
+		t.Fatalf("expected distinct file IDs %v, got %v", want, sources)
+	}
+
+	var _ modelClient.SourceReportingClient = client
+}
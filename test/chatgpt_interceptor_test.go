@@ -0,0 +1,72 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+func TestChatInterceptorsObserveAndModifyRequests(t *testing.T) {
+	var sentModel string
+	client := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", nil)
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var payload struct {
+			Model string `json:"model"`
+		}
+		json.NewDecoder(req.Body).Decode(&payload)
+		sentModel = payload.Model
+		return jsonResponse(http.StatusOK, `{"output":[{"type":"message","content":[{"text":"hello"}]}]}`), nil
+	})}
+
+	var order []string
+	var observed modelClient.ChatRequest
+	client.Use(
+		func(request modelClient.ChatRequest, next chatgpt.ChatSender) (chatgpt.RawChatResponse, error) {
+			order = append(order, "outer before")
+			observed = request
+			resp, err := next(request)
+			order = append(order, "outer after")
+			return resp, err
+		},
+		func(request modelClient.ChatRequest, next chatgpt.ChatSender) (chatgpt.RawChatResponse, error) {
+			order = append(order, "inner before")
+			request.Model = "gpt-4o"
+			resp, err := next(request)
+			if err == nil && resp.StatusCode != http.StatusOK {
+				t.Errorf("expected the raw response status, got %d", resp.StatusCode)
+			}
+			order = append(order, "inner after")
+			return resp, err
+		},
+	)
+
+	text, err := client.Chat("Say hello")
+	if err != nil {
+		t.Fatalf("Chat failed: %v", err)
+	}
+	if text != "hello" {
+		t.Errorf("unexpected answer %q", text)
+	}
+	if observed.Model != "gpt-4o-mini" || len(observed.Input) != 1 {
+		t.Errorf("expected the interceptor to see the outgoing request, got %+v", observed)
+	}
+	if sentModel != "gpt-4o" {
+		t.Errorf("expected the interceptor's model to be sent, got %q", sentModel)
+	}
+	if want := []string{"outer before", "inner before", "inner after", "outer after"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("expected interceptor order %v, got %v", want, order)
+	}
+
+	// An interceptor can answer without sending the request.
+	client.Interceptors = []chatgpt.ChatInterceptor{func(request modelClient.ChatRequest, next chatgpt.ChatSender) (chatgpt.RawChatResponse, error) {
+		return chatgpt.RawChatResponse{StatusCode: http.StatusOK, Body: []byte(`{"output":[{"type":"message","content":[{"text":"recorded"}]}]}`)}, nil
+	}}
+	sentModel = ""
+	if text, err := client.Chat("Say hello"); err != nil || text != "recorded" || sentModel != "" {
+		t.Errorf("expected the recorded answer without a request, got %q, %v (sent %q)", text, err, sentModel)
+	}
+}
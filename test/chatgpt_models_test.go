@@ -0,0 +1,47 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+func modelsTransport() *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/models" {
+			return jsonResponse(http.StatusNotFound, `{}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"object":"list","data":[{"id":"gpt-4o-mini"},{"id":"gpt-4o"}]}`), nil
+	})}
+}
+
+func TestChatGPTListModelsAndValidate(t *testing.T) {
+	c := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", nil)
+	c.HTTPClient = modelsTransport()
+
+	models, err := c.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels failed: %v", err)
+	}
+	if len(models) != 2 || models[0] != "gpt-4o-mini" {
+		t.Fatalf("unexpected models: %v", models)
+	}
+	if err := c.ValidateModel(); err != nil {
+		t.Fatalf("expected gpt-4o-mini to validate, got %v", err)
+	}
+
+	c.SetModel("gpt-4o-mnii")
+	if err := c.ValidateModel(); !errors.Is(err, apierr.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for a misspelled model, got %v", err)
+	}
+
+	c.SetModel("gpt-4o")
+	c.FallbackModels = []string{"gpt-3.5-retired"}
+	if err := c.ValidateModel(); !errors.Is(err, apierr.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unavailable fallback, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+)
+
+func TestChatAdvancedParsedExtractsJSON(t *testing.T) {
+	cases := map[string]string{
+		"fenced":        "```json\n{\"answer\": \"Use PostgreSQL\", \"notes\": [\"a}b\"]}\n```",
+		"leading prose": "Sure! Here is the answer you asked for:\n{\"answer\": \"Use PostgreSQL\", \"notes\": [\"a}b\"]}\nLet me know if you need more.",
+	}
+	for name, text := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			client := countingChatClient(&calls, text)
+			var out struct {
+				Answer string   `json:"answer"`
+				Notes  []string `json:"notes"`
+			}
+			if err := client.ChatAdvancedParsed(modelClient.ChatRequest{}, &out); err != nil {
+				t.Fatalf("ChatAdvancedParsed failed: %v", err)
+			}
+			if out.Answer != "Use PostgreSQL" || len(out.Notes) != 1 || out.Notes[0] != "a}b" {
+				t.Fatalf("unexpected result %+v", out)
+			}
+		})
+	}
+}
+
+func TestChatAdvancedParsedReportsTruncatedJSON(t *testing.T) {
+	for _, text := range []string{`{"result": [{"content": "Use Postgre`, ""} {
+		var calls int32
+		client := countingChatClient(&calls, text)
+		var out map[string]interface{}
+		err := client.ChatAdvancedParsed(modelClient.ChatRequest{}, &out)
+		if err == nil {
+			t.Fatalf("expected an error for %q", text)
+		}
+		if text != "" && !strings.Contains(err.Error(), "Use Postgre") {
+			t.Errorf("expected the error to quote the response, got %v", err)
+		}
+		if text == "" && !strings.Contains(err.Error(), "empty response") {
+			t.Errorf("expected an empty-response error, got %v", err)
+		}
+	}
+}
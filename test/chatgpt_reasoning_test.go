@@ -0,0 +1,62 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+func TestChatRequestSendsReasoningOnlyToReasoningModels(t *testing.T) {
+	var sent []map[string]interface{}
+	client := chatgpt.NewChatGPTClient("key", "o3-mini", nil)
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body map[string]interface{}
+		if err := json.Unmarshal([]byte(readBody(t, req)), &body); err != nil {
+			t.Fatalf("request body is not JSON: %v", err)
+		}
+		sent = append(sent, body)
+		return jsonResponse(http.StatusOK, `{"output":[{"type":"message","content":[{"text":"ok"}]}]}`), nil
+	})}
+
+	input := []modelClient.Message{{Role: "user", Content: "Plan the migration"}}
+	requests := []modelClient.ChatRequest{
+		{Input: input, Reasoning: &modelClient.ReasoningOptions{Effort: "high"}},
+		{Model: "gpt-4o", Input: input, Reasoning: &modelClient.ReasoningOptions{Effort: "high"}},
+		{Input: input},
+	}
+	for _, req := range requests {
+		if _, err := client.ChatAdvanced(req); err != nil {
+			t.Fatalf("ChatAdvanced failed: %v", err)
+		}
+	}
+
+	reasoning, ok := sent[0]["reasoning"].(map[string]interface{})
+	if !ok || reasoning["effort"] != "high" {
+		t.Fatalf("expected the reasoning block for o3-mini, got %v", sent[0])
+	}
+	if _, ok := sent[1]["reasoning"]; ok {
+		t.Fatalf("expected no reasoning block for gpt-4o, got %v", sent[1])
+	}
+	if _, ok := sent[2]["reasoning"]; ok {
+		t.Fatalf("expected no reasoning block when none is set, got %v", sent[2])
+	}
+
+	// The client default applies to reasoning models when the request sets none.
+	client.ReasoningEffort = "low"
+	if _, err := client.ChatAdvanced(modelClient.ChatRequest{Input: input}); err != nil {
+		t.Fatalf("ChatAdvanced failed: %v", err)
+	}
+	if reasoning, ok := sent[3]["reasoning"].(map[string]interface{}); !ok || reasoning["effort"] != "low" {
+		t.Fatalf("expected the default reasoning effort, got %v", sent[3])
+	}
+
+	if _, err := client.ChatAdvanced(modelClient.ChatRequest{Input: input, Reasoning: &modelClient.ReasoningOptions{Effort: "extreme"}}); err == nil {
+		t.Fatal("expected an error for an unknown reasoning effort")
+	}
+	if len(sent) != 4 {
+		t.Fatalf("expected the invalid request not to be sent, got %d requests", len(sent))
+	}
+}
@@ -0,0 +1,36 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+)
+
+func TestHandleOpenClarificationsAnswersOnce(t *testing.T) {
+	card := &fakeCard{
+		name:     "Add persistence layer",
+		members:  []string{"EngineeringManager", "Developer"},
+		comments: []string{"Started working on it.", "@manager which database should I use?"},
+	}
+	em := &agent.EngineeringManagerAgent{BaseAgent: &agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   &fakeModelClient{parsed: `{"answer":"Use PostgreSQL."}`},
+		BoardClient:   &fakeBoard{cards: []*fakeCard{card}},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: &fakePromptBuilder{},
+	}}
+
+	for i := 0; i < 2; i++ {
+		if err := em.HandleOpenClarifications(); err != nil {
+			t.Fatalf("HandleOpenClarifications failed: %v", err)
+		}
+	}
+	if len(card.comments) != 3 {
+		t.Fatalf("expected exactly one answer to be posted, got comments: %v", card.comments)
+	}
+	if !strings.HasPrefix(card.comments[2], "[clarification:") || !strings.Contains(card.comments[2], "Use PostgreSQL.") {
+		t.Fatalf("unexpected answer comment: %q", card.comments[2])
+	}
+}
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/cli"
+)
+
+// recordingCommands returns commands that record which one ran and with which arguments.
+func recordingCommands(ran *string, gotArgs *[]string) []cli.Command {
+	cmd := func(name string) cli.Command {
+		return cli.Command{Name: name, Summary: name + " summary", Run: func(args []string) error {
+			*ran = name
+			*gotArgs = args
+			return nil
+		}}
+	}
+	return []cli.Command{cmd("run"), cmd("workflow"), cmd("cosine")}
+}
+
+func TestDispatchRunsNamedCommand(t *testing.T) {
+	var ran string
+	var args []string
+	var out bytes.Buffer
+	if err := cli.Dispatch(recordingCommands(&ran, &args), "run", []string{"workflow", "-config", "x.yaml"}, &out); err != nil {
+		t.Fatalf("Dispatch failed: %v", err)
+	}
+	if ran != "workflow" {
+		t.Errorf("expected workflow to run, got %q", ran)
+	}
+	if !reflect.DeepEqual(args, []string{"-config", "x.yaml"}) {
+		t.Errorf("unexpected args %v", args)
+	}
+}
+
+func TestDispatchDefaultsForFlagsAndNoArgs(t *testing.T) {
+	for _, in := range [][]string{nil, {"-interval", "5s"}} {
+		var ran string
+		var args []string
+		if err := cli.Dispatch(recordingCommands(&ran, &args), "run", in, &bytes.Buffer{}); err != nil {
+			t.Fatalf("Dispatch(%v) failed: %v", in, err)
+		}
+		if ran != "run" || len(args) != len(in) {
+			t.Errorf("Dispatch(%v): ran %q with %v", in, ran, args)
+		}
+	}
+}
+
+func TestDispatchUnknownCommand(t *testing.T) {
+	var ran string
+	var args []string
+	var out bytes.Buffer
+	err := cli.Dispatch(recordingCommands(&ran, &args), "run", []string{"bogus"}, &out)
+	if !errors.Is(err, cli.ErrUnknownCommand) {
+		t.Fatalf("expected ErrUnknownCommand, got %v", err)
+	}
+	if ran != "" {
+		t.Errorf("no command should run, %q did", ran)
+	}
+	if !strings.Contains(out.String(), "cosine summary") {
+		t.Errorf("usage not printed: %q", out.String())
+	}
+}
@@ -0,0 +1,78 @@
+package test
+
+import (
+	"net/http"
+	"testing"
+
+	githubClient "github.com/egobogo/aiagents/internal/board/github"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+	"github.com/egobogo/aiagents/internal/docs/confluence"
+	"github.com/egobogo/aiagents/internal/docs/notion"
+	"github.com/egobogo/aiagents/internal/httputil"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+	"github.com/egobogo/aiagents/internal/notify/slack"
+)
+
+// Each client sends its requests through httputil.Do, so a single 503 is retried transparently.
+func TestClientsRetryThroughHTTPUtil(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		call func(client *http.Client, retry httputil.RetryPolicy) error
+	}{
+		{"chatgpt", messageResponse("ok"), func(client *http.Client, retry httputil.RetryPolicy) error {
+			c := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", nil)
+			if c.Retry != httputil.DefaultRetryPolicy {
+				t.Errorf("chatgpt: expected the default retry policy, got %+v", c.Retry)
+			}
+			c.HTTPClient, c.Retry = client, retry
+			_, err := c.Chat("hello")
+			return err
+		}},
+		{"vectorstorage", `{}`, func(client *http.Client, retry httputil.RetryPolicy) error {
+			vs := vectorstorage.NewClient("key")
+			vs.HTTPClient, vs.Retry = client, retry
+			return vs.DeleteStorage("vs_1")
+		}},
+		{"notion", `{}`, func(client *http.Client, retry httputil.RetryPolicy) error {
+			nc := notion.NewNotionClient("token", "root")
+			nc.HTTPClient, nc.Retry = client, retry
+			return nc.DeletePage("page-1")
+		}},
+		{"confluence", `{}`, func(client *http.Client, retry httputil.RetryPolicy) error {
+			cc := confluence.NewConfluenceClient("https://acme.test/wiki", "me@example.com", "secret", "ENG", "1")
+			cc.HTTPClient, cc.Retry = client, retry
+			return cc.DeletePage("42")
+		}},
+		{"github", `[]`, func(client *http.Client, retry httputil.RetryPolicy) error {
+			gc := githubClient.NewGitHubClient("token", "acme", "app")
+			gc.HTTPClient, gc.Retry = client, retry
+			_, err := gc.GetMembers()
+			return err
+		}},
+		{"trello comment", `{}`, func(client *http.Client, retry httputil.RetryPolicy) error {
+			tc := trelloClient.NewTrelloClient("key", "token", "board1")
+			tc.Client.Client, tc.Retry = client, retry
+			card := &trelloClient.TrelloCard{ID: "card1", BoardClient: tc, Client: tc.Client}
+			return card.WriteComment("hello")
+		}},
+		{"slack", `{}`, func(client *http.Client, retry httputil.RetryPolicy) error {
+			s := slack.NewSlackNotifier("https://hooks.test/webhook")
+			s.HTTPClient, s.Retry = client, retry
+			return s.Notify("", "hello")
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			client := flakyTransport(&calls, 1, http.StatusServiceUnavailable, tc.body)
+			if err := tc.call(client, fastRetry); err != nil {
+				t.Fatalf("expected the retried request to succeed, got %v", err)
+			}
+			if calls != 2 {
+				t.Fatalf("expected one retry, got %d calls", calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/config"
+)
+
+const terminalListsConfigYAML = `
+board:
+  terminalLists: [Shipped, completed]
+`
+
+func TestCloseTicketUsesConfiguredTerminalList(t *testing.T) {
+	loadTestConfig(t, terminalListsConfigYAML)
+	card := &fakeCard{name: "Login page", list: "In Progress"}
+	b := &fakeBoard{cards: []*fakeCard{card}, lists: []string{"To Do", "In Progress", "Completed"}}
+	a := &agent.BaseAgent{Name: "Manager", BoardClient: b}
+
+	if done, _ := a.IsTicketFinished(card); done {
+		t.Fatal("expected the ticket not to be finished yet")
+	}
+	if err := a.CloseTicket(card); err != nil {
+		t.Fatalf("CloseTicket failed: %v", err)
+	}
+	if card.list != "Completed" {
+		t.Fatalf("expected the ticket in Completed, got %q", card.list)
+	}
+	if done, _ := a.IsTicketFinished(card); !done {
+		t.Error("expected the ticket to be finished")
+	}
+}
+
+func TestCloseTicketListsAvailableListsWhenNoneMatch(t *testing.T) {
+	loadTestConfig(t, terminalListsConfigYAML)
+	card := &fakeCard{name: "Login page", list: "To Do"}
+	b := &fakeBoard{cards: []*fakeCard{card}, lists: []string{"To Do", "Review"}}
+	a := &agent.BaseAgent{Name: "Manager", BoardClient: b}
+
+	err := a.CloseTicket(card)
+	if !errors.Is(err, agent.ErrNoTerminalList) {
+		t.Fatalf("expected ErrNoTerminalList, got %v", err)
+	}
+	for _, want := range []string{"Shipped, completed, Done", "To Do, Review"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the error to mention %q, got %v", want, err)
+		}
+	}
+	if card.list != "To Do" {
+		t.Errorf("expected the ticket to stay in To Do, got %q", card.list)
+	}
+}
+
+func TestTerminalListsIncludeCloseTicketSteps(t *testing.T) {
+	loadTestConfig(t, `
+board:
+  terminalLists: [Completed]
+workflow:
+  steps:
+    - id: review
+      name: Review
+      next: close
+    - id: close
+      name: Closed
+      action: close_ticket
+      column: Released
+`)
+	got := strings.Join(config.GetTerminalLists(), ", ")
+	if got != "Completed, Released, Done" {
+		t.Fatalf("unexpected terminal lists %q", got)
+	}
+}
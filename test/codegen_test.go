@@ -0,0 +1,80 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+func TestParseGeneratedFiles(t *testing.T) {
+	response := "Here are the files:\n" +
+		"!!path!! internal/foo/foo.go\n" +
+		"```go\n" +
+		"package foo\n" +
+		"\n" +
+		"func Foo() {}\n" +
+		"```\n" +
+		"!!path!! README.md\n" +
+		"# Foo\n"
+
+	files, err := codegen.ParseGeneratedFiles(response)
+	if err != nil {
+		t.Fatalf("ParseGeneratedFiles failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+	if files[0].Path != "internal/foo/foo.go" || files[0].Content != "package foo\n\nfunc Foo() {}\n" {
+		t.Errorf("unexpected first file: %+v", files[0])
+	}
+	if files[1].Path != "README.md" || files[1].Content != "# Foo\n" {
+		t.Errorf("unexpected second file: %+v", files[1])
+	}
+}
+
+func TestParseGeneratedFilesEmpty(t *testing.T) {
+	for _, response := range []string{"", "No files this time."} {
+		if _, err := codegen.ParseGeneratedFiles(response); !errors.Is(err, codegen.ErrNoFiles) {
+			t.Errorf("expected ErrNoFiles for %q, got %v", response, err)
+		}
+	}
+}
+
+func TestParseGeneratedFilesMalformed(t *testing.T) {
+	cases := map[string]string{
+		"empty path": "!!path!!\ncontent\n",
+		"escape":     "!!path!! ../outside.go\ncontent\n",
+		"absolute":   "!!path!! /etc/passwd\ncontent\n",
+		"duplicate":  "!!path!! a.go\nx\n!!path!! ./a.go\ny\n",
+	}
+	for name, response := range cases {
+		if _, err := codegen.ParseGeneratedFiles(response); !errors.Is(err, codegen.ErrMalformedMarker) {
+			t.Errorf("%s: expected ErrMalformedMarker, got %v", name, err)
+		}
+	}
+	for _, name := range []string{"escape", "absolute"} {
+		if _, err := codegen.ParseGeneratedFiles(cases[name]); !errors.Is(err, gitrepo.ErrPathOutsideRepo) {
+			t.Errorf("%s: expected ErrPathOutsideRepo, got %v", name, err)
+		}
+	}
+}
+
+func TestWriteGeneratedFiles(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gc, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	files := []codegen.GeneratedFile{{Path: "pkg/deep/file.go", Content: "package deep\n"}}
+	if err := codegen.WriteFiles(gc, files); err != nil {
+		t.Fatalf("WriteFiles failed: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(repoPath, "pkg", "deep", "file.go"))
+	if err != nil || string(got) != "package deep\n" {
+		t.Fatalf("unexpected file content %q (err %v)", got, err)
+	}
+}
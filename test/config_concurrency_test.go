@@ -0,0 +1,82 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/config/filesys"
+	"github.com/egobogo/aiagents/internal/roles"
+	"github.com/egobogo/aiagents/internal/workflow"
+)
+
+// Run with -race: loading the configuration while agents read it and workflows step through it must not race.
+func TestConfigLoadIsSafeWithConcurrentReaders(t *testing.T) {
+	configYAML := temperatureConfigYAML + defaultBranchWorkflowYAML
+	loadTestConfig(t, configYAML)
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(configYAML), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	prov, err := filesys.NewFilesysConfigProvider(configPath)
+	if err != nil {
+		t.Fatalf("NewFilesysConfigProvider failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 4; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				config.SetProvider(prov)
+				if err := config.Load(configPath); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				role, err := roles.Get("Writer")
+				if err != nil {
+					errs <- err
+					return
+				}
+				if role.Prompt != "You write things." {
+					t.Errorf("unexpected role prompt %q", role.Prompt)
+					return
+				}
+				if got := config.GetModeTemperature("Summarize", 1); got != 0.3 {
+					t.Errorf("unexpected temperature %v", got)
+					return
+				}
+				config.GetProjectGoal()
+				config.GetTerminalLists()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				wm := workflow.NewWorkflowManager(config.GetLoadedConfig())
+				if err := wm.NextStep("fix"); err != nil {
+					errs <- err
+					return
+				}
+				if err := wm.Reset(); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("concurrent access failed: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs/confluence"
+)
+
+// fakeConfluence is a minimal in-memory Confluence content API for one space.
+type fakeConfluence struct {
+	mu    sync.Mutex
+	pages map[string]map[string]interface{}
+	order []string
+}
+
+func (f *fakeConfluence) add(id, title, body string, ancestors ...map[string]string) {
+	if ancestors == nil {
+		ancestors = []map[string]string{}
+	}
+	f.pages[id] = map[string]interface{}{
+		"id": id, "title": title,
+		"body":      map[string]interface{}{"storage": map[string]string{"value": body}},
+		"version":   map[string]interface{}{"number": 1, "when": "2026-10-01T10:00:00.000Z"},
+		"ancestors": ancestors,
+		"_links":    map[string]string{"webui": "/spaces/ENG/pages/" + id},
+	}
+	f.order = append(f.order, id)
+}
+
+func (f *fakeConfluence) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if user, pass, ok := r.BasicAuth(); !ok || user != "me@example.com" || pass != "secret" {
+		http.Error(w, `{"message":"unauthorized"}`, http.StatusUnauthorized)
+		return
+	}
+	switch {
+	case r.Method == "POST" && r.URL.Path == "/wiki/rest/api/content":
+		var payload struct {
+			Title     string              `json:"title"`
+			Ancestors []map[string]string `json:"ancestors"`
+			Body      struct {
+				Storage struct {
+					Value string `json:"value"`
+				} `json:"storage"`
+			} `json:"body"`
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		id := fmt.Sprintf("%d", 100+len(f.pages))
+		var ancestors []map[string]string
+		for _, a := range payload.Ancestors {
+			parent := f.pages[a["id"]]
+			ancestors = append(ancestors, parent["ancestors"].([]map[string]string)...)
+			ancestors = append(ancestors, map[string]string{"id": a["id"], "title": parent["title"].(string)})
+		}
+		f.add(id, payload.Title, payload.Body.Storage.Value, ancestors...)
+		json.NewEncoder(w).Encode(f.pages[id])
+	case r.Method == "GET" && r.URL.Path == "/wiki/rest/api/content":
+		if r.URL.Query().Get("start") != "0" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": []interface{}{}})
+			return
+		}
+		var results []interface{}
+		for _, id := range f.order {
+			results = append(results, f.pages[id])
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/wiki/rest/api/content/"):
+		page, ok := f.pages[strings.TrimPrefix(r.URL.Path, "/wiki/rest/api/content/")]
+		if !ok {
+			http.Error(w, `{"message":"not found"}`, http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(page)
+	default:
+		http.Error(w, `{"message":"unexpected"}`, http.StatusBadRequest)
+	}
+}
+
+func TestConfluenceCreateReadList(t *testing.T) {
+	fake := &fakeConfluence{pages: map[string]map[string]interface{}{}}
+	fake.add("1", "Wiki", "<p>Home</p>")
+	fake.add("2", "Unrelated", "<p>Other</p>")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	cc := confluence.NewConfluenceClient(server.URL+"/wiki", "me@example.com", "secret", "ENG", "1")
+
+	arch, err := cc.CreatePage("Architecture", "Services & storage\nSecond line", "")
+	if err != nil {
+		t.Fatalf("CreatePage failed: %v", err)
+	}
+	if arch.ParentID != "1" || arch.Path != "Wiki/Architecture" {
+		t.Fatalf("unexpected created page: %+v", arch)
+	}
+	sub, err := cc.CreatePage("Storage", "Postgres", arch.ID)
+	if err != nil {
+		t.Fatalf("CreatePage (sub) failed: %v", err)
+	}
+
+	read, err := cc.ReadPage(arch.ID)
+	if err != nil {
+		t.Fatalf("ReadPage failed: %v", err)
+	}
+	if read.Content != "Services & storage\nSecond line" {
+		t.Fatalf("unexpected content %q", read.Content)
+	}
+	if read.URL != server.URL+"/wiki/spaces/ENG/pages/"+arch.ID || read.LastEdited.IsZero() {
+		t.Fatalf("unexpected page metadata: %+v", read)
+	}
+
+	pages, err := cc.ListPages()
+	if err != nil {
+		t.Fatalf("ListPages failed: %v", err)
+	}
+	paths := map[string]string{}
+	for _, p := range pages {
+		paths[p.ID] = p.Path
+	}
+	want := map[string]string{"1": "Wiki", arch.ID: "Wiki/Architecture", sub.ID: "Wiki/Architecture/Storage"}
+	if len(paths) != len(want) {
+		t.Fatalf("expected only pages under the root, got %v", paths)
+	}
+	for id, path := range want {
+		if paths[id] != path {
+			t.Errorf("expected page %s at %q, got %q", id, path, paths[id])
+		}
+	}
+}
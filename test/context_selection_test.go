@@ -0,0 +1,84 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/context"
+)
+
+func mixedImportanceMemories() []context.MemoryEntry {
+	now := time.Now()
+	return []context.MemoryEntry{
+		{ID: "trivia1", Content: "The office plant is a fern", Importance: 1, Timestamp: now},
+		{ID: "arch", Content: "Services talk over gRPC", Importance: 10, Timestamp: now.Add(-time.Hour)},
+		{ID: "trivia2", Content: "Standup is at 9:30", Importance: 2, Timestamp: now},
+		{ID: "sec", Content: "Secrets live in Vault", Importance: 9, Timestamp: now.Add(-2 * time.Hour)},
+		{ID: "trivia3", Content: "The wiki theme is dark", Importance: 3, Timestamp: now},
+		{ID: "unset", Content: "Deploys happen on Tuesdays", Timestamp: now},
+		{ID: "trivia4", Content: "Lunch is catered on Fridays", Importance: 1, Timestamp: now.Add(-time.Minute)},
+	}
+}
+
+func selectedIDs(mems []context.MemoryEntry) []string {
+	ids := make([]string, len(mems))
+	for i, m := range mems {
+		ids[i] = m.ID
+	}
+	return ids
+}
+
+func TestSelectContextMemoriesPrefersImportant(t *testing.T) {
+	got := selectedIDs(context.SelectContextMemories(mixedImportanceMemories(), 3))
+	want := []string{"arch", "sec", "unset"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSelectContextMemoriesCapsLowImportance(t *testing.T) {
+	got := selectedIDs(context.SelectContextMemoriesCapped(mixedImportanceMemories(), 0, 2))
+	// trivia3 (3) ranks above the importance-2 and -1 memories; among the 1s the newer one wins.
+	want := []string{"arch", "sec", "unset", "trivia3", "trivia2"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if all := context.SelectContextMemoriesCapped(mixedImportanceMemories(), 0, -1); len(all) != 7 {
+		t.Errorf("a negative cap should keep every memory, got %d", len(all))
+	}
+}
+
+func TestBuildContextMergesSelectedMemories(t *testing.T) {
+	pb := &fakePromptBuilder{}
+	a := &agent.BaseAgent{
+		Name:          "Developer",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{text: "merged"},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: pb,
+	}
+	old := mixedImportanceMemories()
+	for i := 0; i < 30; i++ {
+		old = append(old, context.MemoryEntry{ID: "filler", Content: "Filler fact", Importance: 6})
+	}
+	if _, err := a.BuildContext(nil, old); err != nil {
+		t.Fatalf("BuildContext failed: %v", err)
+	}
+	calls := pb.callsWithMode("ActualizeContext")
+	if len(calls) != 1 {
+		t.Fatalf("expected one ActualizeContext request, got %d", len(calls))
+	}
+	prompt := calls[0].UserInput
+	if !strings.Contains(prompt, "Services talk over gRPC") || !strings.Contains(prompt, "Secrets live in Vault") {
+		t.Errorf("important memories missing from the merge prompt: %s", prompt)
+	}
+	if strings.Contains(prompt, "office plant") || strings.Contains(prompt, "Standup") {
+		t.Errorf("low-importance memories should not fit the default budget: %s", prompt)
+	}
+	// The two memories above importance 6 come first; the filler takes the rest of the budget.
+	if n := strings.Count(prompt, "Filler fact"); n != context.DefaultContextBudget-2 {
+		t.Errorf("expected the budget to be filled with %d filler memories, got %d", context.DefaultContextBudget-2, n)
+	}
+}
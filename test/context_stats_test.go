@@ -0,0 +1,62 @@
+package test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/context/embedding/hashing"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+	"github.com/egobogo/aiagents/internal/context/similarity/hnsw"
+)
+
+func TestInMemoryContextStorageStats(t *testing.T) {
+	searcher, err := hnsw.New(8)
+	if err != nil {
+		t.Fatalf("hnsw.New failed: %v", err)
+	}
+	storage, err := inmemory.NewInMemoryContextStorage(hashing.NewHashingEmbeddingProvider(8), searcher)
+	if err != nil {
+		t.Fatalf("NewInMemoryContextStorage failed: %v", err)
+	}
+	if stats := storage.Stats(); stats.Total != 0 || stats.IndexSize != 0 || !stats.Oldest.IsZero() || stats.AverageImportance != 0 {
+		t.Fatalf("expected empty stats, got %+v", stats)
+	}
+
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	memories := []context.MemoryEntry{
+		{ID: "a", Category: "Architecture", Content: "Services talk over gRPC", Importance: 8, Timestamp: base},
+		{ID: "b", Category: "Architecture", Content: "Events go through Kafka", Importance: 6, Timestamp: base.Add(time.Hour)},
+		{ID: "c", Category: "Ops", Content: "Deploys run on Fridays", Importance: 2, Timestamp: base.Add(-time.Hour)},
+		{ID: "d", Category: "Testing", Content: "Integration tests use a fake board", Timestamp: base.Add(2 * time.Hour)},
+	}
+	data, _ := json.Marshal(memories)
+	if err := storage.ImportMemories(strings.NewReader(string(data))); err != nil {
+		t.Fatalf("ImportMemories failed: %v", err)
+	}
+
+	stats := storage.Stats()
+	want := context.ContextStats{
+		Total:             4,
+		ByCategory:        map[string]int{"Architecture": 2, "Ops": 1, "Testing": 1},
+		AverageImportance: float64(8+6+2+context.DefaultImportance) / 4,
+		Oldest:            base.Add(-time.Hour),
+		Newest:            base.Add(2 * time.Hour),
+		IndexSize:         4,
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Fatalf("unexpected stats:\n got %+v\nwant %+v", stats, want)
+	}
+
+	// Forgotten memories leave cold storage but stay in the index.
+	if err := storage.Forget("c"); err != nil {
+		t.Fatalf("Forget failed: %v", err)
+	}
+	stats = storage.Stats()
+	if stats.Total != 3 || stats.ByCategory["Ops"] != 0 || !stats.Oldest.Equal(base) || stats.IndexSize != 4 {
+		t.Fatalf("unexpected stats after Forget: %+v", stats)
+	}
+}
@@ -0,0 +1,61 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/docs"
+)
+
+func TestDesignerProducesSpecFromBrandbook(t *testing.T) {
+	docsClient := &fakeDocsClient{pages: []docs.Page{
+		{ID: "page_1", Title: "Brandbook", Content: "Primary color is Ocean #0A66C2."},
+		{ID: "page_2", Title: "Deployment", Content: "Deploy with Helm."},
+	}}
+	pb := &fakePromptBuilder{}
+	card := &fakeCard{name: "Signup form", attached: []board.Attachment{
+		{Name: "mockup.PNG", URL: "https://example.com/mockup.png"},
+		{Name: "notes.txt", URL: "https://example.com/notes.txt"},
+	}}
+	designer := agent.NewDesignerAgent(&agent.BaseAgent{
+		Name: "Designer",
+		Role: "Designer",
+		ModelClient: &fakeModelClient{parsed: `{
+			"summary": "Single-column signup form",
+			"colors": [{"name": "Ocean", "hex": "#0A66C2", "usage": "primary button"}],
+			"components": [{"name": "TextField", "description": "email and password inputs"}],
+			"layout_notes": ["center the form on desktop"]
+		}`},
+		DocsClient:    docsClient,
+		Context:       &fakeContextStorage{},
+		PromptBuilder: pb,
+	})
+
+	spec, err := designer.ProduceDesignSpec(card)
+	if err != nil {
+		t.Fatalf("ProduceDesignSpec failed: %v", err)
+	}
+	if len(spec.Colors) != 1 || spec.Colors[0].Hex != "#0A66C2" || len(spec.Components) != 1 {
+		t.Fatalf("unexpected spec: %+v", spec)
+	}
+
+	calls := pb.callsWithMode("Design")
+	if len(calls) != 1 {
+		t.Fatalf("expected one Design prompt, got %d", len(calls))
+	}
+	if !strings.Contains(calls[0].UserInput, "Ocean #0A66C2") {
+		t.Errorf("brandbook missing from prompt: %q", calls[0].UserInput)
+	}
+	if strings.Contains(calls[0].UserInput, "Helm") {
+		t.Errorf("unrelated page included in prompt: %q", calls[0].UserInput)
+	}
+	if len(pb.images) != 1 || pb.images[0] != "https://example.com/mockup.png" {
+		t.Errorf("expected only the image mockup attached, got %v", pb.images)
+	}
+
+	if len(card.comments) != 1 || !strings.Contains(card.comments[0], "Ocean #0A66C2: primary button") {
+		t.Errorf("unexpected card comments: %v", card.comments)
+	}
+}
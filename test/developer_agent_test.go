@@ -0,0 +1,257 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/codegen"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+const generatedCodeResponse = `{"files": [
+	{"path": "internal/greet/greet.go", "content": "package greet\n\nfunc Hello() string { return \"hi\" }\n", "isTest": false},
+	{"path": "internal/greet/greet_test.go", "content": "package greet\n", "isTest": true}
+]}`
+
+func TestDeveloperGenerateCodeSeparatesTests(t *testing.T) {
+	pb := &fakePromptBuilder{}
+	dev := agent.NewDeveloperAgent(&agent.BaseAgent{
+		Name:          "Developer",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{parsed: generatedCodeResponse},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: pb,
+	})
+
+	files, err := dev.GenerateCode("Add a greeting helper.")
+	if err != nil {
+		t.Fatalf("GenerateCode failed: %v", err)
+	}
+	impl, tests := codegen.SplitTests(files)
+	if len(impl) != 1 || impl[0].Path != "internal/greet/greet.go" {
+		t.Errorf("unexpected implementation files: %+v", impl)
+	}
+	if len(tests) != 1 || tests[0].Path != "internal/greet/greet_test.go" {
+		t.Errorf("unexpected test files: %+v", tests)
+	}
+	if calls := pb.callsWithMode("GenerateCode"); len(calls) != 1 {
+		t.Errorf("expected one GenerateCode request, got %d", len(calls))
+	}
+}
+
+func TestDeveloperImplementTicketWritesFiles(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gc, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	card := &fakeCard{name: "Greeting helper", members: []string{"Developer"}}
+	dev := agent.NewDeveloperAgent(&agent.BaseAgent{
+		Name:          "Developer",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{parsed: generatedCodeResponse},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: &fakePromptBuilder{},
+		GitClient:     gc,
+	})
+
+	if _, err := dev.ImplementTicket(card); err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+	for _, p := range []string{"internal/greet/greet.go", "internal/greet/greet_test.go"} {
+		if _, err := os.Stat(filepath.Join(repoPath, filepath.FromSlash(p))); err != nil {
+			t.Errorf("expected %s to be written: %v", p, err)
+		}
+	}
+	want := "Implementation: internal/greet/greet.go\nTests: internal/greet/greet_test.go"
+	if len(card.comments) != 1 || card.comments[0] != want {
+		t.Errorf("unexpected comments: %q", card.comments)
+	}
+}
+
+func TestDeveloperParksTicketWithOpenQuestions(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gc, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	card := &fakeCard{name: "Greeting helper", list: "In Progress", members: []string{"Developer", "EngineeringManager"}}
+	dev := agent.NewDeveloperAgent(&agent.BaseAgent{
+		Name:          "Developer",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{parsed: `{"questions":["Which language should the greeting use?"]}`},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: &fakePromptBuilder{},
+		GitClient:     gc,
+	})
+
+	files, err := dev.ImplementTicket(card)
+	if !errors.Is(err, agent.ErrNeedsClarification) {
+		t.Fatalf("expected ErrNeedsClarification, got %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("no code should be generated, got %+v", files)
+	}
+	if card.list != agent.DefaultWaitingList {
+		t.Errorf("expected the card to be parked in %q, got %q", agent.DefaultWaitingList, card.list)
+	}
+	if len(card.comments) != 1 || card.comments[0] != "@manager Which language should the greeting use?" {
+		t.Errorf("unexpected comments: %q", card.comments)
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "internal", "greet", "greet.go")); !os.IsNotExist(err) {
+		t.Errorf("no code should be written while parked: %v", err)
+	}
+
+	// While the question is unanswered, later polls keep the card parked without asking again.
+	if _, err := dev.ImplementTicket(card); !errors.Is(err, agent.ErrNeedsClarification) || len(card.comments) != 1 {
+		t.Fatalf("expected the card to stay parked, got %v with comments %q", err, card.comments)
+	}
+
+	em := &agent.EngineeringManagerAgent{BaseAgent: &agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   &fakeModelClient{parsed: `{"answer":"English."}`},
+		BoardClient:   &fakeBoard{cards: []*fakeCard{card}},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: &fakePromptBuilder{},
+	}}
+	if err := em.HandleOpenClarifications(); err != nil {
+		t.Fatalf("HandleOpenClarifications failed: %v", err)
+	}
+
+	dev.ModelClient = &fakeModelClient{parsed: generatedCodeResponse}
+	if _, err := dev.ImplementTicket(card); err != nil {
+		t.Fatalf("ImplementTicket after the answer failed: %v", err)
+	}
+	if card.list != agent.DefaultResumeList {
+		t.Errorf("expected the card back in %q, got %q", agent.DefaultResumeList, card.list)
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "internal", "greet", "greet.go")); err != nil {
+		t.Errorf("expected the code to be written once answered: %v", err)
+	}
+}
+
+func TestParkedTicketIsAnsweredAndResumed(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gc, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	// The ticket starts assigned to the developer only.
+	card := &fakeCard{name: "Greeting helper", list: "In Progress", members: []string{"Developer"}}
+	fb := &fakeBoard{cards: []*fakeCard{card}}
+	dev := agent.NewDeveloperAgent(&agent.BaseAgent{
+		Name:          "Developer",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{parsed: `{"questions":["Which language should the greeting use?"]}`},
+		BoardClient:   fb,
+		Context:       &fakeContextStorage{},
+		PromptBuilder: &fakePromptBuilder{},
+		GitClient:     gc,
+	})
+	em := &agent.EngineeringManagerAgent{BaseAgent: &agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   &fakeModelClient{parsed: `{"answer":"English."}`},
+		BoardClient:   fb,
+		Context:       &fakeContextStorage{},
+		PromptBuilder: &fakePromptBuilder{},
+	}}
+
+	if err := dev.Act(); err != nil {
+		t.Fatalf("developer Act failed: %v", err)
+	}
+	if card.list != agent.DefaultWaitingList {
+		t.Fatalf("expected the card to be parked in %q, got %q", agent.DefaultWaitingList, card.list)
+	}
+	if err := em.Act(); err != nil {
+		t.Fatalf("manager Act failed: %v", err)
+	}
+	if len(card.comments) != 2 || !strings.Contains(card.comments[1], "English.") {
+		t.Fatalf("expected the manager to answer the parked ticket, got comments %q", card.comments)
+	}
+
+	dev.ModelClient = &fakeModelClient{parsed: generatedCodeResponse}
+	if err := dev.Act(); err != nil {
+		t.Fatalf("developer Act after the answer failed: %v", err)
+	}
+	if card.list != agent.DefaultResumeList {
+		t.Errorf("expected the card back in %q, got %q", agent.DefaultResumeList, card.list)
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "internal", "greet", "greet.go")); err != nil {
+		t.Errorf("expected the code to be written once answered: %v", err)
+	}
+}
+
+func TestDeveloperImplementTicketPushesOverNewerRemote(t *testing.T) {
+	first, second := newPushRetryClones(t)
+	commitFile(t, second, "billing.go", "package billing\n")
+	if err := second.PushChanges("", ""); err != nil {
+		t.Fatalf("second client push failed: %v", err)
+	}
+
+	card := &fakeCard{name: "Greeting helper", members: []string{"Developer"}}
+	dev := agent.NewDeveloperAgent(&agent.BaseAgent{
+		Name:          "Developer",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{parsed: strings.TrimSuffix(generatedCodeResponse, "}") + `, "message": "Add greeting helper"}`},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: &fakePromptBuilder{},
+		GitClient:     first,
+	})
+	dev.Push = &agent.GitAuth{Email: "developer@example.com"}
+
+	if _, err := dev.ImplementTicket(card); err != nil {
+		t.Fatalf("ImplementTicket failed: %v", err)
+	}
+	if err := second.PullChanges("", ""); err != nil {
+		t.Fatalf("PullChanges failed: %v", err)
+	}
+	for _, p := range []string{"billing.go", "internal/greet/greet.go"} {
+		if _, err := os.Stat(filepath.Join(second.RepoPath, filepath.FromSlash(p))); err != nil {
+			t.Errorf("expected %s on the remote: %v", p, err)
+		}
+	}
+}
+
+func TestDeveloperCommitMessageDescribesChangedFiles(t *testing.T) {
+	pb := &fakePromptBuilder{}
+	dev := agent.NewDeveloperAgent(&agent.BaseAgent{
+		Name:          "Developer",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{parsed: `{"message": "feat(greet): add greeting helper"}`},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: pb,
+	})
+	dev.CommitFormat = agent.CommitFormatConventional
+	files := []codegen.GeneratedFile{
+		{Path: "internal/greet/greet.go", Content: "package greet\n\nfunc Hello() string { return \"hi\" }\n"},
+		{Path: "internal/greet/greet_test.go", Content: "package greet\n", IsTest: true},
+	}
+
+	message, err := dev.CommitMessage(files)
+	if err != nil {
+		t.Fatalf("CommitMessage failed: %v", err)
+	}
+	if message != "feat(greet): add greeting helper" {
+		t.Errorf("unexpected commit message %q", message)
+	}
+	calls := pb.callsWithMode("CommitMessage")
+	if len(calls) != 1 {
+		t.Fatalf("expected one CommitMessage request, got %d", len(calls))
+	}
+	prompt := calls[0].UserInput
+	for _, want := range []string{"internal/greet/greet.go", "internal/greet/greet_test.go", "func Hello()", "Conventional Commits"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected the prompt to contain %q, got:\n%s", want, prompt)
+		}
+	}
+
+	if _, err := dev.CommitMessage(nil); !errors.Is(err, codegen.ErrNoFiles) {
+		t.Errorf("expected ErrNoFiles without changes, got %v", err)
+	}
+}
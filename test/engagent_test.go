@@ -89,11 +89,14 @@ func TestEngineeringManagerAgentContext(t *testing.T) {
 
 	// Create context storage with concrete implementations.
 	embeddingProvider := openai.NewOpenAIEmbeddingProvider(openaiAPIKey, "text-embedding-ada-002")
-	hnswSearcher, err := hnsw.New(1536)
+	hnswSearcher, err := hnsw.New(embeddingProvider.Dimensions())
 	if err != nil {
 		t.Fatalf("Failed to create HNSW SimilaritySearcher: %v", err)
 	}
-	ctxStorage := inmemory.NewInMemoryContextStorage(embeddingProvider, hnswSearcher)
+	ctxStorage, err := inmemory.NewInMemoryContextStorage(embeddingProvider, hnswSearcher)
+	if err != nil {
+		t.Fatalf("Failed to create context storage: %v", err)
+	}
 
 	// Create a BaseAgent with the concrete dependencies.
 	baseAgent := &agent.BaseAgent{
@@ -0,0 +1,598 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/docs"
+	modelClient "github.com/egobogo/aiagents/internal/model"
+)
+
+// roundTripFunc adapts a function to http.RoundTripper so tests can fake API responses.
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// jsonResponse builds an *http.Response with the given status and body.
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// messageResponse returns a minimal Responses API payload with a single message output.
+func messageResponse(text string) string {
+	out, _ := json.Marshal(map[string]interface{}{
+		"output": []map[string]interface{}{
+			{"type": "message", "content": []map[string]string{{"type": "output_text", "text": text}}},
+		},
+	})
+	return string(out)
+}
+
+// fakeModelClient is a ModelClient that answers from canned responses and records every request.
+type fakeModelClient struct {
+	mu       sync.Mutex
+	requests []modelClient.ChatRequest
+	// parsed is unmarshalled into the target of ChatAdvancedParsed.
+	parsed string
+	// text is returned by Chat and ChatAdvanced.
+	text string
+}
+
+func (f *fakeModelClient) Chat(prompt string) (string, error) {
+	return f.ChatAdvanced(modelClient.ChatRequest{Input: []modelClient.Message{{Role: "user", Content: prompt}}})
+}
+
+func (f *fakeModelClient) ChatAdvanced(req modelClient.ChatRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	return f.text, nil
+}
+
+func (f *fakeModelClient) ChatAdvancedParsed(req modelClient.ChatRequest, target interface{}) error {
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+	parsed := f.parsed
+	if parsed == "" {
+		parsed = "{}"
+	}
+	return json.Unmarshal([]byte(parsed), target)
+}
+
+func (f *fakeModelClient) SetModel(model string)       {}
+func (f *fakeModelClient) SetTemperature(temp float64) {}
+func (f *fakeModelClient) GetModel() string            { return "fake-model" }
+func (f *fakeModelClient) GetTemperature() float64     { return 0 }
+func (f *fakeModelClient) UploadFile(filePath string, purpose string) (modelClient.File, error) {
+	return modelClient.File{ID: "file_" + filePath}, nil
+}
+func (f *fakeModelClient) GetFile(fileID string) (modelClient.File, error) {
+	return modelClient.File{ID: fileID}, nil
+}
+func (f *fakeModelClient) DeleteAllFiles() error { return nil }
+
+// fakePromptBuilder records every Build call and puts the user input into a single user message.
+type fakePromptBuilder struct {
+	mu     sync.Mutex
+	calls  []promptCall
+	images []string
+}
+
+// promptCall captures the arguments of one Build call.
+type promptCall struct {
+	Mode      string
+	State     string
+	UserInput string
+	Model     string
+}
+
+func (b *fakePromptBuilder) Build(role, mode, state, userInput string, desiredOutput interface{}, temperature float64, modelName string) (modelClient.ChatRequest, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.calls = append(b.calls, promptCall{Mode: mode, State: state, UserInput: userInput, Model: modelName})
+	return modelClient.ChatRequest{
+		Model: modelName,
+		Input: []modelClient.Message{{Role: "user", Content: userInput}},
+	}, nil
+}
+
+func (b *fakePromptBuilder) AddFile(chatReq *modelClient.ChatRequest, vectorStoreIDs []string) error {
+	return nil
+}
+
+func (b *fakePromptBuilder) AddWeb(chatReq *modelClient.ChatRequest, webTool modelClient.WebSearch) error {
+	return nil
+}
+
+func (b *fakePromptBuilder) AddImage(chatReq *modelClient.ChatRequest, imageURL string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.images = append(b.images, imageURL)
+	return nil
+}
+
+// callsWithMode returns the recorded calls made with the given mode.
+func (b *fakePromptBuilder) callsWithMode(mode string) []promptCall {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []promptCall
+	for _, c := range b.calls {
+		if c.Mode == mode {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// fakeContextStorage is a minimal ContextStorage keeping memories in a slice.
+type fakeContextStorage struct {
+	mu       sync.Mutex
+	hot      string
+	memories []context.MemoryEntry
+}
+
+func (s *fakeContextStorage) Remember(me context.EasyMemory) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.memories = append(s.memories, context.MemoryEntry{
+		ID:         fmt.Sprintf("mem_%d", len(s.memories)+1),
+		Category:   me.Category,
+		Content:    me.Content,
+		Importance: me.Importance,
+		Links:      me.Links,
+		Tags:       me.Tags,
+	})
+	return nil
+}
+
+func (s *fakeContextStorage) Forget(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, m := range s.memories {
+		if m.ID == id {
+			s.memories = append(s.memories[:i], s.memories[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("memory with ID %s not found", id)
+}
+
+func (s *fakeContextStorage) SetContext(summary string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hot = summary
+	return nil
+}
+
+func (s *fakeContextStorage) GetContext() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hot
+}
+
+func (s *fakeContextStorage) GetMemories() []context.MemoryEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]context.MemoryEntry(nil), s.memories...)
+}
+
+func (s *fakeContextStorage) SearchMemories(query string) []context.MemoryEntry {
+	return nil
+}
+
+func (s *fakeContextStorage) SearchMemoriesWithParams(query string, k int, threshold float64) []context.MemoryEntry {
+	return nil
+}
+
+func (s *fakeContextStorage) FilterRelatedMemories(newMems []context.EasyMemory) []context.MemoryEntry {
+	return nil
+}
+
+func (s *fakeContextStorage) MemoryExists(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, m := range s.memories {
+		if m.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fakeContextStorage) GetLinkedMemories(id string) []context.MemoryEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var linked []context.MemoryEntry
+	for _, m := range s.memories {
+		if m.ID != id && slices.Contains(m.Links, id) {
+			linked = append(linked, m)
+		}
+	}
+	return linked
+}
+
+func (s *fakeContextStorage) ExportMemories(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s.GetMemories())
+}
+
+func (s *fakeContextStorage) Stats() context.ContextStats {
+	return context.ComputeStats(s.GetMemories())
+}
+
+func (s *fakeContextStorage) ImportMemories(r io.Reader) error {
+	var memories []context.MemoryEntry
+	if err := json.NewDecoder(r).Decode(&memories); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.memories = append(s.memories, memories...)
+	return nil
+}
+
+// fakeDocsClient is an in-memory DocumentationClient.
+type fakeDocsClient struct {
+	pages []docs.Page
+}
+
+func (d *fakeDocsClient) CreatePage(title string, content string, parentPageID string) (docs.Page, error) {
+	page := docs.Page{ID: fmt.Sprintf("page_%d", len(d.pages)+1), Title: title, Content: content, ParentID: parentPageID}
+	d.pages = append(d.pages, page)
+	return page, nil
+}
+
+func (d *fakeDocsClient) UpdatePage(pageID string, content string, replace bool) error {
+	for i := range d.pages {
+		if d.pages[i].ID == pageID {
+			if replace {
+				d.pages[i].Content = content
+			} else {
+				d.pages[i].Content += "\n" + content
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("page %s not found", pageID)
+}
+
+func (d *fakeDocsClient) UpdateTitle(pageID, newTitle string) error {
+	for i := range d.pages {
+		if d.pages[i].ID == pageID {
+			d.pages[i].Title = newTitle
+			return nil
+		}
+	}
+	return fmt.Errorf("page %s not found", pageID)
+}
+
+func (d *fakeDocsClient) ReadPage(pageID string) (docs.Page, error) {
+	for _, p := range d.pages {
+		if p.ID == pageID {
+			return p, nil
+		}
+	}
+	return docs.Page{}, fmt.Errorf("page %s not found", pageID)
+}
+
+func (d *fakeDocsClient) SearchPages(query string) ([]docs.Page, error) {
+	var out []docs.Page
+	for _, p := range d.pages {
+		if strings.Contains(p.Title, query) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (d *fakeDocsClient) ListPages() ([]docs.Page, error) {
+	return append([]docs.Page(nil), d.pages...), nil
+}
+
+func (d *fakeDocsClient) ListSubPages(parentPageID string) ([]docs.Page, error) {
+	var out []docs.Page
+	for _, p := range d.pages {
+		if p.ParentID == parentPageID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (d *fakeDocsClient) DeletePage(pageID string) error {
+	for i, p := range d.pages {
+		if p.ID == pageID {
+			d.pages = append(d.pages[:i], d.pages[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("page %s not found", pageID)
+}
+
+func (d *fakeDocsClient) PrintTree() (string, error) {
+	var b strings.Builder
+	for _, p := range d.pages {
+		b.WriteString(p.Title + "\n")
+	}
+	return b.String(), nil
+}
+
+// fakeCard is an in-memory board.Card recording comments and assignments.
+type fakeCard struct {
+	mu          sync.Mutex
+	name        string
+	description string
+	list        string
+	labels      []string
+	members     []string
+	comments    []string
+	fields      map[string]string
+	attached    []board.Attachment
+	updated     time.Time
+}
+
+func (c *fakeCard) GetName() string                                 { return c.name }
+func (c *fakeCard) ChangeName(newName string) error                 { c.name = newName; return nil }
+func (c *fakeCard) GetURL() string                                  { return "https://example.com/" + c.name }
+func (c *fakeCard) GetList() (board.List, error)                    { return fakeList(c.list), nil }
+func (c *fakeCard) Move(newListName string) error                   { c.list = newListName; return nil }
+func (c *fakeCard) GetAttachments() ([]board.Attachment, error)     { return c.attached, nil }
+func (c *fakeCard) AddAttachment(attachment board.Attachment) error { return nil }
+
+func (c *fakeCard) GetAssignedMembers() ([]board.Member, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []board.Member
+	for _, m := range c.members {
+		out = append(out, board.Member{ID: m, Name: m})
+	}
+	return out, nil
+}
+
+func (c *fakeCard) AssignTo(userName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.members = append(c.members, userName)
+	return nil
+}
+
+func (c *fakeCard) UnassignFrom(userName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var kept []string
+	for _, m := range c.members {
+		if m != userName {
+			kept = append(kept, m)
+		}
+	}
+	c.members = kept
+	return nil
+}
+
+func (c *fakeCard) ReadComments() ([]board.Comment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []board.Comment
+	for _, text := range c.comments {
+		out = append(out, board.Comment{Text: text})
+	}
+	return out, nil
+}
+
+func (c *fakeCard) WriteComment(comment string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.comments = append(c.comments, comment)
+	return nil
+}
+
+func (c *fakeCard) GetCustomFields() (map[string]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make(map[string]string, len(c.fields))
+	for k, v := range c.fields {
+		out[k] = v
+	}
+	return out, nil
+}
+
+func (c *fakeCard) SetCustomField(name, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fields == nil {
+		c.fields = make(map[string]string)
+	}
+	if value == "" {
+		delete(c.fields, name)
+		return nil
+	}
+	c.fields[name] = value
+	return nil
+}
+
+// fakeList is a board.List identified by its name.
+type fakeList string
+
+func (l fakeList) GetName() string { return string(l) }
+func (l fakeList) GetID() string   { return string(l) }
+
+// fakeBoard is an in-memory board.BoardClient over fakeCards.
+type fakeBoard struct {
+	cards []*fakeCard
+	lists []string
+	// members are board members without cards; members of cards are added by GetMembers.
+	members []board.Member
+}
+
+// loadFakeBoard builds a fake board holding the state captured in snapshot.
+// Comment authors are not kept, since fake cards store comment text only.
+func loadFakeBoard(snapshot board.BoardSnapshot) *fakeBoard {
+	b := &fakeBoard{
+		lists:   append([]string(nil), snapshot.Lists...),
+		members: append([]board.Member(nil), snapshot.Members...),
+	}
+	for _, cs := range snapshot.Cards {
+		card := &fakeCard{
+			name:        cs.Name,
+			description: cs.Description,
+			list:        cs.List,
+			members:     append([]string(nil), cs.Assignees...),
+			labels:      append([]string(nil), cs.Labels...),
+		}
+		for _, c := range cs.Comments {
+			card.comments = append(card.comments, c.Text)
+		}
+		b.cards = append(b.cards, card)
+	}
+	return b
+}
+
+// ExportBoard captures the fake board in the same form as TrelloClient.ExportBoard.
+func (b *fakeBoard) ExportBoard() (board.BoardSnapshot, error) {
+	snapshot := board.BoardSnapshot{Name: b.GetName(), Lists: append([]string(nil), b.lists...)}
+	members, err := b.GetMembers()
+	if err != nil {
+		return board.BoardSnapshot{}, err
+	}
+	snapshot.Members = members
+	for _, c := range b.cards {
+		comments, err := c.ReadComments()
+		if err != nil {
+			return board.BoardSnapshot{}, err
+		}
+		snapshot.Cards = append(snapshot.Cards, board.CardSnapshot{
+			ID:          c.name,
+			Name:        c.name,
+			Description: c.description,
+			List:        c.list,
+			Assignees:   append([]string(nil), c.members...),
+			Labels:      append([]string(nil), c.labels...),
+			Comments:    comments,
+		})
+	}
+	return snapshot, nil
+}
+
+func (b *fakeBoard) GetName() string { return "fake board" }
+func (b *fakeBoard) GetURL() string  { return "https://example.com/board" }
+
+func (b *fakeBoard) GetMembers() ([]board.Member, error) {
+	seen := make(map[string]bool)
+	var out []board.Member
+	for _, m := range b.members {
+		seen[m.Name] = true
+		out = append(out, m)
+	}
+	for _, c := range b.cards {
+		for _, m := range c.members {
+			if !seen[m] {
+				seen[m] = true
+				out = append(out, board.Member{ID: m, Name: m})
+			}
+		}
+	}
+	return out, nil
+}
+
+func (b *fakeBoard) GetCards() ([]board.Card, error) {
+	var out []board.Card
+	for _, c := range b.cards {
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func (b *fakeBoard) CreateCard(name, description, listName string) (board.Card, error) {
+	card := &fakeCard{name: name, list: listName}
+	b.cards = append(b.cards, card)
+	return card, nil
+}
+
+func (b *fakeBoard) CreateCardDetailed(spec board.CardSpec) (board.Card, error) {
+	card := &fakeCard{name: spec.Name, list: spec.ListName, members: append([]string(nil), spec.Assignees...)}
+	b.cards = append(b.cards, card)
+	return card, nil
+}
+
+func (b *fakeBoard) GetCard(id string) (board.Card, error) {
+	for _, c := range b.cards {
+		if c.name == id {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("card %s not found", id)
+}
+
+func (b *fakeBoard) GetCardsAssignedTo(userName string) ([]board.Card, error) {
+	var out []board.Card
+	for _, c := range b.cards {
+		for _, m := range c.members {
+			if strings.EqualFold(m, userName) {
+				out = append(out, c)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (b *fakeBoard) GetCardsFromList(listName string) ([]board.Card, error) {
+	var out []board.Card
+	for _, c := range b.cards {
+		if strings.EqualFold(c.list, listName) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (b *fakeBoard) GetCardsUpdatedSince(since time.Time) ([]board.Card, error) {
+	var out []board.Card
+	for _, c := range b.cards {
+		if c.updated.After(since) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (b *fakeBoard) CreateList(name string) (board.List, error) {
+	b.lists = append(b.lists, name)
+	return fakeList(name), nil
+}
+
+func (b *fakeBoard) RenameList(listID, newName string) error {
+	for i, l := range b.lists {
+		if l == listID {
+			b.lists[i] = newName
+			return nil
+		}
+	}
+	return fmt.Errorf("list %s not found", listID)
+}
+
+func (b *fakeBoard) MoveList(listID, pos string) error {
+	return nil
+}
+
+func (b *fakeBoard) GetLists() ([]board.List, error) {
+	var out []board.List
+	for _, l := range b.lists {
+		out = append(out, fakeList(l))
+	}
+	return out, nil
+}
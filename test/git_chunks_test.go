@@ -0,0 +1,92 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+func TestGatherRepoInfoChunksStayUnderLimit(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gc, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	want := map[string]bool{"main.go": true}
+	for i := 0; i < 8; i++ {
+		name := fmt.Sprintf("file%d.go", i)
+		body := fmt.Sprintf("package main\n\n// %s\nfunc F%d() {}\n", strings.Repeat("x", 40*i), i)
+		if err := gc.WriteFile(name, []byte(body)); err != nil {
+			t.Fatalf("WriteFile %s failed: %v", name, err)
+		}
+		want[name] = true
+	}
+	if err := gc.WriteFile("notes.txt", []byte("not code")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	// Vendored dependencies are left out, as ListCodeFiles and PrintTree leave them out.
+	if err := os.MkdirAll(filepath.Join(repoPath, "vendor", "dep"), 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := gc.WriteFile(filepath.Join("vendor", "dep", "dep.go"), []byte("package dep\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	const maxBytes = 400
+	chunks, err := gc.GatherRepoInfoChunks(maxBytes)
+	if err != nil {
+		t.Fatalf("GatherRepoInfoChunks failed: %v", err)
+	}
+	if len(chunks) < 2 {
+		t.Fatalf("expected the files to be split across chunks, got %d", len(chunks))
+	}
+	seen := map[string]int{}
+	for i, chunk := range chunks {
+		encoded, err := json.Marshal(chunk)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if len(encoded) > maxBytes {
+			t.Errorf("chunk %d is %d bytes, over the %d limit", i, len(encoded), maxBytes)
+		}
+		for _, f := range chunk.Files {
+			seen[f.Path]++
+		}
+	}
+	for name := range want {
+		if seen[name] != 1 {
+			t.Errorf("%s appears %d times", name, seen[name])
+		}
+	}
+	if len(seen) != len(want) {
+		t.Errorf("unexpected files in chunks: %v", seen)
+	}
+}
+
+func TestGatherRepoInfoChunksKeepsLargeFileWhole(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gc, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	big := "package main\n\n// " + strings.Repeat("y", 500) + "\n"
+	if err := gc.WriteFile("big.go", []byte(big)); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	chunks, err := gc.GatherRepoInfoChunks(200)
+	if err != nil {
+		t.Fatalf("GatherRepoInfoChunks failed: %v", err)
+	}
+	for _, chunk := range chunks {
+		for _, f := range chunk.Files {
+			if f.Path == "big.go" && (len(chunk.Files) != 1 || f.Content != big) {
+				t.Errorf("big.go should be alone and intact in its chunk: %+v", chunk)
+			}
+		}
+	}
+}
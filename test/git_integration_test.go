@@ -9,33 +9,25 @@ import (
 	"time"
 
 	"github.com/egobogo/aiagents/internal/gitrepo"
-	"github.com/joho/godotenv"
+	"github.com/egobogo/aiagents/internal/testutil"
 )
 
 func TestAgentGitPushPullAndCleanup(t *testing.T) {
-	// Load environment variables from the .env file in the project root.
-	if err := godotenv.Load("../.env"); err != nil {
-		t.Log("No .env file found; using system environment variables")
-	}
-
-	// Read required environment variables.
-	repoPath := os.Getenv("GIT_REPO_PATH")
-	repoURL := os.Getenv("GIT_REPO_URL")
-	username := os.Getenv("GIT_USERNAME")
-	token := os.Getenv("GIT_TOKEN")
-	if repoPath == "" || repoURL == "" || username == "" || token == "" {
-		t.Fatalf("Required environment variables (GIT_REPO_PATH, GIT_REPO_URL, GIT_USERNAME, GIT_TOKEN) not set; skipping test")
-	}
-
-	// Create a GitClient instance for your live repository.
-	client, err := gitrepo.NewGitClient(repoURL, repoPath)
+	// A local bare repository stands in for the hosted remote, so no credentials are needed.
+	remote := testutil.NewGitRemote(t)
+	client, err := gitrepo.NewGitClient(remote.BarePath, remote.ClonePath)
 	if err != nil {
 		t.Fatalf("NewGitClient failed: %v", err)
 	}
+	// A second clone plays the other agent that pulls what this one pushes.
+	other, err := gitrepo.NewGitClient(remote.BarePath, remote.Clone(t))
+	if err != nil {
+		t.Fatalf("NewGitClient for the second clone failed: %v", err)
+	}
 
-	// First, pull remote changes to update the local repository.
-	if err := client.PullChanges(username, token); err != nil {
-		t.Logf("Initial PullChanges error (possibly already up-to-date): %v", err)
+	// Pulling a fresh clone has nothing to fetch and is not an error.
+	if err := client.PullChanges("", ""); err != nil {
+		t.Fatalf("initial PullChanges failed: %v", err)
 	}
 
 	// Generate a unique file name and content.
@@ -49,36 +41,45 @@ func TestAgentGitPushPullAndCleanup(t *testing.T) {
 
 	// Commit the changes with a unique commit message.
 	commitMsg := "Test commit " + time.Now().Format("20060102150405")
-	if err := client.CommitChanges(commitMsg, username, "tester@example.com"); err != nil {
+	if err := client.CommitChanges(commitMsg, "tester", "tester@example.com"); err != nil {
 		t.Fatalf("CommitChanges failed: %v", err)
 	}
 
 	// Push the commit to the remote repository.
-	if err := client.PushChanges(username, token); err != nil {
+	if err := client.PushChanges("", ""); err != nil {
 		t.Fatalf("PushChanges failed: %v", err)
 	}
 
-	t.Logf("File %s created and pushed with commit message: %s", fileName, commitMsg)
-
-	// Wait a short time to allow push propagation.
-	time.Sleep(2 * time.Second)
+	// The other clone receives the file.
+	if err := other.PullChanges("", ""); err != nil {
+		t.Fatalf("PullChanges failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(other.RepoPath, fileName))
+	if err != nil || string(content) != uniqueContent {
+		t.Fatalf("expected the pushed file in the other clone, got %q, %v", content, err)
+	}
 
 	// Cleanup: Delete the test file.
-	testFilePath := filepath.Join(repoPath, fileName)
-	if err := os.Remove(testFilePath); err != nil {
+	if err := os.Remove(filepath.Join(client.RepoPath, fileName)); err != nil {
 		t.Fatalf("Failed to remove test file: %v", err)
 	}
 
 	// Commit the deletion.
 	cleanupMsg := "Cleanup: remove test file " + fileName
-	if err := client.CommitChanges(cleanupMsg, username, "tester@example.com"); err != nil {
+	if err := client.CommitChanges(cleanupMsg, "tester", "tester@example.com"); err != nil {
 		t.Fatalf("CommitChanges for cleanup failed: %v", err)
 	}
 
 	// Push the cleanup commit.
-	if err := client.PushChanges(username, token); err != nil {
+	if err := client.PushChanges("", ""); err != nil {
 		t.Fatalf("PushChanges for cleanup failed: %v", err)
 	}
 
-	t.Logf("Cleanup complete: test file %s deleted with commit message: %s", fileName, cleanupMsg)
+	// The deletion reaches the other clone too.
+	if err := other.PullChanges("", ""); err != nil {
+		t.Fatalf("PullChanges after cleanup failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(other.RepoPath, fileName)); !os.IsNotExist(err) {
+		t.Fatalf("expected the test file to be removed in the other clone, got %v", err)
+	}
 }
@@ -0,0 +1,124 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+// newPushRetryClones creates a bare remote seeded from a local repo and returns two clients
+// pointing at it, as two agents working on the same project would.
+func newPushRetryClones(t *testing.T) (*gitrepo.GitClient, *gitrepo.GitClient) {
+	t.Helper()
+	remotePath := t.TempDir()
+	if _, err := git.PlainInit(remotePath, true); err != nil {
+		t.Fatalf("failed to init remote: %v", err)
+	}
+	first, err := gitrepo.NewGitClient("", initLocalRepo(t))
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	if _, err := first.Repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}}); err != nil {
+		t.Fatalf("failed to add remote: %v", err)
+	}
+	if err := first.PushChanges("", ""); err != nil {
+		t.Fatalf("initial push failed: %v", err)
+	}
+	second, err := gitrepo.NewGitClient(remotePath, filepath.Join(t.TempDir(), "second"))
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	return first, second
+}
+
+func commitFile(t *testing.T, gc *gitrepo.GitClient, name, content string) {
+	t.Helper()
+	if err := gc.WriteFile(name, []byte(content)); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := gc.CommitChanges("update "+name, "agent", "agent@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+}
+
+func TestPushWithRetryRebasesOnRejection(t *testing.T) {
+	first, second := newPushRetryClones(t)
+
+	commitFile(t, second, "billing.go", "package billing\n")
+	if err := second.PushChanges("", ""); err != nil {
+		t.Fatalf("second client push failed: %v", err)
+	}
+
+	commitFile(t, first, "auth.go", "package auth\n")
+	if err := first.PushChanges("", ""); !errors.Is(err, gitrepo.ErrPushRejected) {
+		t.Fatalf("expected the stale push to be rejected with ErrPushRejected, got %v", err)
+	}
+	if err := first.PushWithRetry("", "", 3); err != nil {
+		t.Fatalf("PushWithRetry failed: %v", err)
+	}
+
+	// The second client now sees both commits.
+	if err := second.PullChanges("", ""); err != nil {
+		t.Fatalf("PullChanges failed: %v", err)
+	}
+	for _, name := range []string{"auth.go", "billing.go", "main.go"} {
+		if _, err := os.Stat(filepath.Join(second.RepoPath, name)); err != nil {
+			t.Errorf("expected %s after pulling the rebased push: %v", name, err)
+		}
+	}
+}
+
+func TestPushWithRetryReportsConflicts(t *testing.T) {
+	first, second := newPushRetryClones(t)
+
+	commitFile(t, second, "main.go", "package main\n\n// second\n")
+	if err := second.PushChanges("", ""); err != nil {
+		t.Fatalf("second client push failed: %v", err)
+	}
+	commitFile(t, first, "main.go", "package main\n\n// first\n")
+
+	err := first.PushWithRetry("", "", 3)
+	if !errors.Is(err, gitrepo.ErrPushConflict) {
+		t.Fatalf("expected ErrPushConflict, got %v", err)
+	}
+}
+
+func TestPushWithRetryRestoresHeadWhenReplayFails(t *testing.T) {
+	first, second := newPushRetryClones(t)
+
+	// The remote adds a docs directory while the local commit adds a file named docs, so the
+	// local commit cannot be written on top of the remote.
+	if err := os.MkdirAll(filepath.Join(second.RepoPath, "docs"), 0755); err != nil {
+		t.Fatalf("failed to create docs: %v", err)
+	}
+	commitFile(t, second, "docs/guide.md", "# guide\n")
+	if err := second.PushChanges("", ""); err != nil {
+		t.Fatalf("second client push failed: %v", err)
+	}
+	commitFile(t, first, "docs", "plain file\n")
+	head, err := first.Repo.Head()
+	if err != nil {
+		t.Fatalf("failed to read HEAD: %v", err)
+	}
+
+	if err := first.PushWithRetry("", "", 3); err == nil {
+		t.Fatal("expected the failed replay to be reported")
+	}
+	after, err := first.Repo.Head()
+	if err != nil {
+		t.Fatalf("failed to read HEAD: %v", err)
+	}
+	if after.Hash() != head.Hash() {
+		t.Fatalf("expected HEAD to be restored to %s, got %s", head.Hash(), after.Hash())
+	}
+	content, err := os.ReadFile(filepath.Join(first.RepoPath, "docs"))
+	if err != nil || string(content) != "plain file\n" {
+		t.Fatalf("expected the local file to be restored, got %q (%v)", content, err)
+	}
+}
@@ -0,0 +1,134 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+func TestGitClientReadsFilesAndDirectories(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gc, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	if err := gc.WriteFile("handler.go", []byte("package main\n\nfunc handle() {}\n")); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	content, err := gc.GetFileContent("handler.go")
+	if err != nil {
+		t.Fatalf("GetFileContent failed: %v", err)
+	}
+	if string(content) != "package main\n\nfunc handle() {}\n" {
+		t.Errorf("unexpected content: %q", content)
+	}
+
+	entries, err := gc.ListDir("")
+	if err != nil {
+		t.Fatalf("ListDir failed: %v", err)
+	}
+	names := map[string]bool{}
+	for _, e := range entries {
+		names[e.Name()] = true
+	}
+	if !names["main.go"] || !names["handler.go"] || names[".git"] || len(names) != 2 {
+		t.Errorf("unexpected directory listing: %v", names)
+	}
+}
+
+func TestGitClientRejectsPathTraversal(t *testing.T) {
+	gc, err := gitrepo.NewGitClient("", initLocalRepo(t))
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	for _, p := range []string{"../secret.txt", "sub/../../secret.txt", "/etc/passwd"} {
+		if _, err := gc.GetFileContent(p); !errors.Is(err, gitrepo.ErrPathOutsideRepo) {
+			t.Errorf("GetFileContent(%q): expected ErrPathOutsideRepo, got %v", p, err)
+		}
+	}
+	if _, err := gc.ListDir(".."); !errors.Is(err, gitrepo.ErrPathOutsideRepo) {
+		t.Errorf("ListDir(..): expected ErrPathOutsideRepo, got %v", err)
+	}
+}
+
+func TestGitClientWriteFileRejectsEscapingPaths(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "a", "b", "repo")
+	if err := os.MkdirAll(repoPath, 0o755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if _, err := git.PlainInit(repoPath, false); err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+	gc, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	outside := filepath.Join(t.TempDir(), "x")
+
+	for _, p := range []string{"../../x", outside} {
+		if err := gc.WriteFile(p, []byte("pwned")); !errors.Is(err, gitrepo.ErrPathOutsideRepo) {
+			t.Errorf("WriteFile(%q): expected ErrPathOutsideRepo, got %v", p, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(repoPath, "..", "..", "x")); !os.IsNotExist(err) {
+		t.Errorf("file escaping the repository was written: %v", err)
+	}
+	if _, err := os.Stat(outside); !os.IsNotExist(err) {
+		t.Errorf("absolute path was written: %v", err)
+	}
+
+	if err := gc.WriteFile("notes.txt", []byte("ok")); err != nil {
+		t.Fatalf("WriteFile of a normal path failed: %v", err)
+	}
+	if content, err := os.ReadFile(filepath.Join(repoPath, "notes.txt")); err != nil || string(content) != "ok" {
+		t.Errorf("expected notes.txt to be written, got %q, %v", content, err)
+	}
+}
+
+func TestGitClientLogNewestFirst(t *testing.T) {
+	gc, err := gitrepo.NewGitClient("", initLocalRepo(t))
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	initial, err := gc.HeadCommit()
+	if err != nil {
+		t.Fatalf("HeadCommit failed: %v", err)
+	}
+	commitFile(t, gc, "a.go", "package a\n")
+	commitFile(t, gc, "b.go", "package b\n")
+	head, err := gc.HeadCommit()
+	if err != nil {
+		t.Fatalf("HeadCommit failed: %v", err)
+	}
+	if head == initial {
+		t.Fatalf("expected HEAD to move after committing")
+	}
+
+	commits, err := gc.Log(2)
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(commits))
+	}
+	if commits[0].SHA != head || commits[0].Message != "update b.go" || commits[1].Message != "update a.go" {
+		t.Errorf("expected newest-first commits, got %+v", commits)
+	}
+	if commits[0].Author != "agent" || commits[0].Time.IsZero() {
+		t.Errorf("expected author and time, got %+v", commits[0])
+	}
+
+	all, err := gc.Log(0)
+	if err != nil {
+		t.Fatalf("Log failed: %v", err)
+	}
+	if len(all) != 3 || all[2].SHA != initial {
+		t.Errorf("expected the whole history ending with the initial commit, got %+v", all)
+	}
+}
@@ -0,0 +1,162 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	githubClient "github.com/egobogo/aiagents/internal/board/github"
+)
+
+// fakeGitHub is a minimal in-memory GitHub Issues API for one repository.
+type fakeGitHub struct {
+	mu       sync.Mutex
+	labels   []string
+	issues   map[int]map[string]interface{}
+	comments map[int][]string
+}
+
+func (f *fakeGitHub) issueJSON(n int) map[string]interface{} {
+	return f.issues[n]
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	path := strings.TrimPrefix(r.URL.Path, "/repos/acme/app")
+	var body map[string]interface{}
+	if r.Body != nil {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+	write := func(v interface{}) { json.NewEncoder(w).Encode(v) }
+
+	switch {
+	case r.Method == "GET" && path == "/labels":
+		var out []map[string]string
+		for _, l := range f.labels {
+			out = append(out, map[string]string{"name": l})
+		}
+		write(out)
+	case r.Method == "POST" && path == "/issues":
+		n := len(f.issues) + 1
+		var labels []map[string]string
+		for _, l := range body["labels"].([]interface{}) {
+			labels = append(labels, map[string]string{"name": l.(string)})
+		}
+		f.issues[n] = map[string]interface{}{
+			"number": n, "title": body["title"], "body": body["body"],
+			"html_url": fmt.Sprintf("https://github.com/acme/app/issues/%d", n),
+			"labels": labels, "assignees": []map[string]string{},
+		}
+		w.WriteHeader(http.StatusCreated)
+		write(f.issueJSON(n))
+	default:
+		var n int
+		var suffix string
+		fmt.Sscanf(path, "/issues/%d", &n)
+		if n == 0 || f.issues[n] == nil {
+			http.Error(w, `{"message":"Not Found"}`, http.StatusNotFound)
+			return
+		}
+		suffix = strings.TrimPrefix(path, fmt.Sprintf("/issues/%d", n))
+		switch {
+		case r.Method == "POST" && suffix == "/comments":
+			f.comments[n] = append(f.comments[n], body["body"].(string))
+			w.WriteHeader(http.StatusCreated)
+			write(map[string]string{"body": body["body"].(string)})
+		case r.Method == "GET" && suffix == "/comments":
+			var out []map[string]interface{}
+			for _, c := range f.comments[n] {
+				out = append(out, map[string]interface{}{"body": c, "user": map[string]string{"login": "bot"}})
+			}
+			write(out)
+		case r.Method == "POST" && suffix == "/assignees":
+			var assignees []map[string]string
+			for _, a := range body["assignees"].([]interface{}) {
+				assignees = append(assignees, map[string]string{"login": a.(string)})
+			}
+			f.issues[n]["assignees"] = assignees
+			w.WriteHeader(http.StatusCreated)
+			write(f.issueJSON(n))
+		case r.Method == "PUT" && suffix == "/labels":
+			var labels []map[string]string
+			for _, l := range body["labels"].([]interface{}) {
+				labels = append(labels, map[string]string{"name": l.(string)})
+			}
+			f.issues[n]["labels"] = labels
+			write(labels)
+		default:
+			http.Error(w, `{"message":"unexpected"}`, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestGitHubBoardClient(t *testing.T) {
+	fake := &fakeGitHub{
+		labels:   []string{"status: To Do", "status: In Progress", "bug"},
+		issues:   map[int]map[string]interface{}{},
+		comments: map[int][]string{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	gc := githubClient.NewGitHubClient("token", "acme", "app")
+	gc.BaseURL = server.URL
+
+	lists, err := gc.GetLists()
+	if err != nil {
+		t.Fatalf("GetLists failed: %v", err)
+	}
+	if len(lists) != 2 || lists[0].GetName() != "To Do" {
+		t.Fatalf("expected the two status labels as lists, got %v", lists)
+	}
+
+	card, err := gc.CreateCard("Fix login", "Users cannot log in", "to do")
+	if err != nil {
+		t.Fatalf("CreateCard failed: %v", err)
+	}
+	if card.GetURL() != "https://github.com/acme/app/issues/1" {
+		t.Fatalf("unexpected card URL %q", card.GetURL())
+	}
+	if list, err := card.GetList(); err != nil || list.GetName() != "To Do" {
+		t.Fatalf("expected new card in To Do, got %v (err %v)", list, err)
+	}
+
+	if err := card.WriteComment("Starting work"); err != nil {
+		t.Fatalf("WriteComment failed: %v", err)
+	}
+	comments, err := card.ReadComments()
+	if err != nil || len(comments) != 1 || comments[0].Text != "Starting work" {
+		t.Fatalf("unexpected comments %v (err %v)", comments, err)
+	}
+
+	if err := card.AssignTo("octocat"); err != nil {
+		t.Fatalf("AssignTo failed: %v", err)
+	}
+	members, _ := card.GetAssignedMembers()
+	if len(members) != 1 || members[0].Name != "octocat" {
+		t.Fatalf("unexpected assignees %v", members)
+	}
+
+	if err := card.SetCustomField("priority", "high"); err != nil {
+		t.Fatalf("SetCustomField failed: %v", err)
+	}
+	if err := card.Move("In Progress"); err != nil {
+		t.Fatalf("Move failed: %v", err)
+	}
+	if list, _ := card.GetList(); list.GetName() != "In Progress" {
+		t.Fatalf("expected card in In Progress, got %s", list.GetName())
+	}
+	labels := fake.issues[1]["labels"].([]map[string]string)
+	if len(labels) != 2 || labels[0]["name"] != "priority: high" || labels[1]["name"] != "status: In Progress" {
+		t.Fatalf("expected the status label replaced and other labels kept, got %v", labels)
+	}
+
+	if err := card.Move("Nowhere"); err == nil {
+		t.Fatalf("expected moving to an unknown list to fail")
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	modelClient "github.com/egobogo/aiagents/internal/model"
 	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
 	"github.com/joho/godotenv"
 )
 
@@ -23,9 +24,9 @@ func TestFileManipulation(t *testing.T) {
 		t.Fatalf("OPENAI_API_KEY not set, skipping integration tests")
 	}
 
-	// Optionally, you can set an initial VectorStoreID if you already have one,
-	// but here we will create a new one.
-	client := chatgpt.NewChatGPTClient(apiKey, "gpt-4o-mini", "")
+	// Vector stores are managed by a dedicated client; here we will create a new one.
+	vsClient := vectorstorage.NewClient(apiKey)
+	client := chatgpt.NewChatGPTClient(apiKey, "gpt-4o-mini", vsClient)
 
 	// Create a temporary file for testing.
 	tmpDir := os.TempDir()
@@ -56,16 +57,16 @@ func TestFileManipulation(t *testing.T) {
 
 	// Step 3: Create a new vector store for our project.
 	vectorStoreName := fmt.Sprintf("Test Vector Store %d", time.Now().Unix())
-	vectorStore, err := client.CreateVectorStore(vectorStoreName)
+	vectorStore, err := vsClient.CreateStorage(vectorStoreName)
 	if err != nil {
-		t.Fatalf("CreateVectorStore failed: %v", err)
+		t.Fatalf("CreateStorage failed: %v", err)
 	}
 	t.Logf("Vector store created: ID=%s, Name=%s", vectorStore.ID, vectorStore.Name)
 
 	// Step 4: Attach the uploaded file to the vector store.
-	attachedFile, err := client.AddFileToVectorStore(vectorStore.ID, uploadedFile.ID)
+	attachedFile, err := vsClient.AttachFile(vectorStore.ID, uploadedFile.ID)
 	if err != nil {
-		t.Fatalf("AddFileToVectorStore failed: %v", err)
+		t.Fatalf("AttachFile failed: %v", err)
 	}
 	t.Logf("File attached to vector store: FileID=%s", attachedFile.ID)
 
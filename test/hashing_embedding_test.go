@@ -0,0 +1,62 @@
+package test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/context/embedding/hashing"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+	"github.com/egobogo/aiagents/internal/context/similarity/hnsw"
+)
+
+func TestHashingEmbeddingDeterministic(t *testing.T) {
+	p := hashing.NewHashingEmbeddingProvider(64)
+
+	a, err := p.ComputeEmbedding("Deploy the payment service")
+	if err != nil {
+		t.Fatalf("ComputeEmbedding failed: %v", err)
+	}
+	b, err := p.ComputeEmbedding("Deploy the payment service")
+	if err != nil {
+		t.Fatalf("ComputeEmbedding failed: %v", err)
+	}
+	if len(a) != 64 {
+		t.Fatalf("expected 64 dimensions, got %d", len(a))
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("identical text produced different vectors")
+	}
+
+	c, err := p.ComputeEmbedding("Refactor the login page")
+	if err != nil {
+		t.Fatalf("ComputeEmbedding failed: %v", err)
+	}
+	if reflect.DeepEqual(a, c) {
+		t.Fatalf("different text produced identical vectors")
+	}
+
+	if _, err := p.ComputeEmbedding("  ...  "); err == nil {
+		t.Fatalf("expected an error for text without words")
+	}
+}
+
+func TestContextStorageRejectsDimensionMismatch(t *testing.T) {
+	provider := hashing.NewHashingEmbeddingProvider(3072)
+	searcher, err := hnsw.New(1536)
+	if err != nil {
+		t.Fatalf("hnsw.New failed: %v", err)
+	}
+	_, err = inmemory.NewInMemoryContextStorage(provider, searcher)
+	if err == nil || !strings.Contains(err.Error(), "3072") || !strings.Contains(err.Error(), "1536") {
+		t.Fatalf("expected a dimension mismatch error naming both sizes, got %v", err)
+	}
+
+	matched, err := hnsw.New(provider.Dimensions())
+	if err != nil {
+		t.Fatalf("hnsw.New failed: %v", err)
+	}
+	if _, err := inmemory.NewInMemoryContextStorage(provider, matched); err != nil {
+		t.Fatalf("expected matching dimensions to be accepted, got %v", err)
+	}
+}
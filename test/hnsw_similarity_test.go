@@ -0,0 +1,42 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/context/similarity/hnsw"
+)
+
+func TestHNSWSearchKeepsOnlySimilarMemories(t *testing.T) {
+	searcher, err := hnsw.New(3)
+	if err != nil {
+		t.Fatalf("hnsw.New failed: %v", err)
+	}
+	memories := []context.MemoryEntry{
+		{ID: "near", Content: "near", Embedding: []float64{1, 0.05, 0}},
+		{ID: "orthogonal", Content: "orthogonal", Embedding: []float64{0, 0, 1}},
+	}
+	for _, mem := range memories {
+		if err := searcher.IndexMemory(mem); err != nil {
+			t.Fatalf("IndexMemory(%s) failed: %v", mem.ID, err)
+		}
+	}
+
+	query := []float64{1, 0, 0}
+	matches, err := searcher.Search(query, 2, 0.9)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(matches) != 1 || matches[0].ID != "near" {
+		t.Fatalf("expected only the near memory above 0.9, got %+v", matches)
+	}
+
+	// A threshold below zero admits unrelated memories too.
+	matches, err = searcher.Search(query, 2, -0.5)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("expected both memories above -0.5, got %+v", matches)
+	}
+}
@@ -0,0 +1,141 @@
+package test
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/context/embedding/openai"
+	"github.com/egobogo/aiagents/internal/docs/notion"
+	"github.com/egobogo/aiagents/internal/httputil"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+)
+
+// rewriteTransport sends every request to target instead of its original host, recording the original hosts.
+type rewriteTransport struct {
+	target *url.URL
+	mu     sync.Mutex
+	hosts  map[string]bool
+}
+
+func (rt *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	rt.hosts[req.URL.Host] = true
+	rt.mu.Unlock()
+	out := req.Clone(req.Context())
+	out.URL.Scheme = rt.target.Scheme
+	out.URL.Host = rt.target.Host
+	out.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(out)
+}
+
+func TestClientsUseInjectedHTTPClient(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/embeddings" {
+			w.Write([]byte(`{"data":[{"embedding":[0.6,0.8],"index":0}]}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	target, _ := url.Parse(server.URL)
+	rt := &rewriteTransport{target: target, hosts: map[string]bool{}}
+	client := &http.Client{Transport: rt}
+
+	vsClient := vectorstorage.NewClient("key")
+	vsClient.HTTPClient = client
+	modelClient := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", vsClient)
+	modelClient.HTTPClient = client
+	docsClient := notion.NewNotionClient("token", "root-page")
+	docsClient.HTTPClient = client
+	embeddings := openai.NewOpenAIEmbeddingProvider("key", "text-embedding-3-small")
+	embeddings.SetHTTPClient(client)
+
+	ctx := context.Background()
+	if err := modelClient.Ping(ctx); err != nil {
+		t.Errorf("ChatGPT Ping failed: %v", err)
+	}
+	if err := vsClient.Ping(ctx); err != nil {
+		t.Errorf("vector storage Ping failed: %v", err)
+	}
+	if err := docsClient.Ping(ctx); err != nil {
+		t.Errorf("Notion Ping failed: %v", err)
+	}
+	emb, err := embeddings.ComputeEmbedding("hello")
+	if err != nil || len(emb) != 2 {
+		t.Errorf("ComputeEmbedding returned %v, %v", emb, err)
+	}
+
+	sort.Strings(paths)
+	want := []string{"/v1/embeddings", "/v1/models", "/v1/pages/root-page", "/v1/vector_stores"}
+	if len(paths) != len(want) {
+		t.Fatalf("expected requests %v to reach the rewritten host, got %v", want, paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("request %d: expected %s, got %s", i, want[i], paths[i])
+		}
+	}
+	if !rt.hosts["api.openai.com"] || !rt.hosts["api.notion.com"] {
+		t.Errorf("expected the original OpenAI and Notion hosts to go through the transport, got %v", rt.hosts)
+	}
+}
+
+func TestNewClientTrustsCAFileAndUsesProxy(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0o600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	plain, err := httputil.NewClient("", "")
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	if _, err := plain.Get(server.URL); err == nil {
+		t.Fatal("expected the test certificate to be rejected without the CA file")
+	}
+
+	trusting, err := httputil.NewClient("", caFile)
+	if err != nil {
+		t.Fatalf("NewClient with CA file failed: %v", err)
+	}
+	resp, err := trusting.Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the CA file to be trusted: %v", err)
+	}
+	resp.Body.Close()
+
+	proxied, err := httputil.NewClient("http://proxy.internal:3128", "")
+	if err != nil {
+		t.Fatalf("NewClient with proxy failed: %v", err)
+	}
+	req, _ := http.NewRequest("GET", "https://api.openai.com/v1/models", nil)
+	proxyURL, err := proxied.Transport.(*http.Transport).Proxy(req)
+	if err != nil || proxyURL == nil || proxyURL.Host != "proxy.internal:3128" {
+		t.Errorf("expected requests to go through the proxy, got %v (err %v)", proxyURL, err)
+	}
+
+	if _, err := httputil.NewClient("", filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Error("expected an error for a missing CA file")
+	}
+}
@@ -0,0 +1,101 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/httputil"
+)
+
+// fastRetry retries quickly so tests exercising backoff stay fast.
+var fastRetry = httputil.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+// flakyTransport answers the first failures requests with status, then 200 with body, counting every call.
+func flakyTransport(calls *int32, failures int32, status int, body string) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(calls, 1) <= failures {
+			return jsonResponse(status, `{"error":"try again"}`), nil
+		}
+		return jsonResponse(http.StatusOK, body), nil
+	})}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	var calls int32
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			resp := jsonResponse(http.StatusTooManyRequests, `{}`)
+			resp.Header.Set("Retry-After", "1")
+			return resp, nil
+		}
+		body, _ := io.ReadAll(req.Body)
+		return jsonResponse(http.StatusOK, string(body)), nil
+	})}
+
+	req, _ := http.NewRequest("POST", "https://api.test/items", strings.NewReader(`{"n":1}`))
+	start := time.Now()
+	resp, err := httputil.Do(context.Background(), client, req, httputil.RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("expected to wait for Retry-After, retried after %s", elapsed)
+	}
+	if body, _ := io.ReadAll(resp.Body); resp.StatusCode != http.StatusOK || string(body) != `{"n":1}` {
+		t.Fatalf("expected the retried request to carry the body, got %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestRetryBacksOffOnServiceUnavailable(t *testing.T) {
+	var calls int32
+	client := flakyTransport(&calls, 2, http.StatusServiceUnavailable, `{}`)
+	req, _ := http.NewRequest("GET", "https://api.test/items", nil)
+	resp, err := httputil.Do(context.Background(), client, req, fastRetry)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Fatalf("expected success on the third attempt, got status %d after %d calls", resp.StatusCode, calls)
+	}
+
+	// Once the retries run out the last response is returned for the caller to report.
+	calls = 0
+	client = flakyTransport(&calls, 10, http.StatusServiceUnavailable, `{}`)
+	req, _ = http.NewRequest("GET", "https://api.test/items", nil)
+	resp, err = httputil.Do(context.Background(), client, req, fastRetry)
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusServiceUnavailable || calls != 4 {
+		t.Fatalf("expected the final 503 after 4 calls, got status %d after %d calls", resp.StatusCode, calls)
+	}
+}
+
+func TestRetryStopsWhenContextIsCancelled(t *testing.T) {
+	var calls int32
+	client := flakyTransport(&calls, 10, http.StatusServiceUnavailable, `{}`)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequest("GET", "https://api.test/items", nil)
+	start := time.Now()
+	_, err := httputil.Do(ctx, client, req, httputil.RetryPolicy{MaxRetries: 5, BaseDelay: time.Hour})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("expected the backoff to be interrupted, waited %s", elapsed)
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single attempt, got %d", calls)
+	}
+}
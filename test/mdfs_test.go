@@ -0,0 +1,116 @@
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs"
+	"github.com/egobogo/aiagents/internal/docs/mdfs"
+)
+
+// buildDocsTree creates the same small wiki in any DocumentationClient.
+func buildDocsTree(t *testing.T, dc docs.DocumentationClient) {
+	t.Helper()
+	arch, err := dc.CreatePage("Architecture", "System overview", "")
+	if err != nil {
+		t.Fatalf("CreatePage failed: %v", err)
+	}
+	if _, err := dc.CreatePage("Storage", "Postgres and Redis", arch.ID); err != nil {
+		t.Fatalf("CreatePage failed: %v", err)
+	}
+	if _, err := dc.CreatePage("Services", "API and workers", arch.ID); err != nil {
+		t.Fatalf("CreatePage failed: %v", err)
+	}
+	if _, err := dc.CreatePage("Runbook", "On-call steps", ""); err != nil {
+		t.Fatalf("CreatePage failed: %v", err)
+	}
+}
+
+// pageShape maps each page title to "parent title|content".
+func pageShape(t *testing.T, dc docs.DocumentationClient) map[string]string {
+	t.Helper()
+	pages, err := dc.ListPages()
+	if err != nil {
+		t.Fatalf("ListPages failed: %v", err)
+	}
+	titles := map[string]string{}
+	for _, p := range pages {
+		titles[p.ID] = p.Title
+	}
+	shape := map[string]string{}
+	for _, p := range pages {
+		shape[p.Title] = titles[p.ParentID] + "|" + p.Content
+	}
+	return shape
+}
+
+func TestMarkdownDocsMatchesInMemoryFake(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "wiki")
+	mc, err := mdfs.NewMarkdownClient(dir)
+	if err != nil {
+		t.Fatalf("NewMarkdownClient failed: %v", err)
+	}
+	fake := &fakeDocsClient{}
+	buildDocsTree(t, mc)
+	buildDocsTree(t, fake)
+
+	got, want := pageShape(t, mc), pageShape(t, fake)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d pages, got %v", len(want), got)
+	}
+	for title, shape := range want {
+		if got[title] != shape {
+			t.Errorf("page %s: expected %q, got %q", title, shape, got[title])
+		}
+	}
+
+	pages, _ := mc.ListPages()
+	for _, p := range pages {
+		if p.Title == "Storage" && p.Path != "Architecture/Storage" {
+			t.Errorf("expected Storage path Architecture/Storage, got %q", p.Path)
+		}
+	}
+
+	tree, err := mc.PrintTree()
+	if err != nil {
+		t.Fatalf("PrintTree failed: %v", err)
+	}
+	fakeTree, _ := fake.PrintTree()
+	for _, title := range strings.Fields(fakeTree) {
+		if !strings.Contains(tree, title) {
+			t.Errorf("tree is missing %s:\n%s", title, tree)
+		}
+	}
+	if !strings.Contains(tree, "│   ├── Services") && !strings.Contains(tree, "│   └── Services") {
+		t.Errorf("expected Services nested under Architecture:\n%s", tree)
+	}
+}
+
+func TestMarkdownDocsUpdateAndDelete(t *testing.T) {
+	mc, err := mdfs.NewMarkdownClient(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewMarkdownClient failed: %v", err)
+	}
+	buildDocsTree(t, mc)
+
+	if err := mc.UpdatePage("architecture.md", "More details", false); err != nil {
+		t.Fatalf("UpdatePage failed: %v", err)
+	}
+	page, err := mc.ReadPage("architecture.md")
+	if err != nil || page.Content != "System overview\n\nMore details" {
+		t.Fatalf("unexpected content %q (err %v)", page.Content, err)
+	}
+
+	if err := mc.DeletePage("architecture.md"); err != nil {
+		t.Fatalf("DeletePage failed: %v", err)
+	}
+	pages, _ := mc.ListPages()
+	if len(pages) != 1 || pages[0].Title != "Runbook" {
+		t.Fatalf("expected only Runbook after deleting Architecture, got %v", pages)
+	}
+
+	if _, err := mc.ReadPage("../outside.md"); err == nil {
+		t.Fatalf("expected IDs outside the root to be rejected")
+	}
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+)
+
+func TestExportImportMemoriesRoundTrip(t *testing.T) {
+	emb := fakeEmbeddings{
+		"Use PostgreSQL for persistence": {1, 0, 0},
+		"Cache hot reads in Redis":       {0.8, 0.6, 0},
+		"Deploy with Helm charts":        {0, 0, 1},
+		"database":                       {0.9, 0.1, 0},
+	}
+	src, err := inmemory.NewInMemoryContextStorage(emb, &bruteForceSearcher{})
+	if err != nil {
+		t.Fatalf("NewInMemoryContextStorage failed: %v", err)
+	}
+	for _, content := range []string{"Use PostgreSQL for persistence", "Cache hot reads in Redis", "Deploy with Helm charts"} {
+		if err := src.Remember(context.EasyMemory{Category: "Architecture", Content: content, Importance: 3}); err != nil {
+			t.Fatalf("Remember failed: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := src.ExportMemories(&buf); err != nil {
+		t.Fatalf("ExportMemories failed: %v", err)
+	}
+
+	// The fresh storage has no embeddings for the memory texts, so import must reuse the exported vectors.
+	dst, err := inmemory.NewInMemoryContextStorage(fakeEmbeddings{"database": emb["database"]}, &bruteForceSearcher{})
+	if err != nil {
+		t.Fatalf("NewInMemoryContextStorage failed: %v", err)
+	}
+	if err := dst.ImportMemories(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("ImportMemories failed: %v", err)
+	}
+
+	if got := len(dst.GetMemories()); got != 3 {
+		t.Fatalf("expected 3 imported memories, got %d", got)
+	}
+	want := src.SearchMemoriesWithParams("database", 2, 0.5)
+	got := dst.SearchMemoriesWithParams("database", 2, 0.5)
+	if len(want) != 2 || len(got) != len(want) {
+		t.Fatalf("search results differ: source %d, imported %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID || got[i].Content != want[i].Content {
+			t.Errorf("result %d: expected %q (%s), got %q (%s)", i, want[i].Content, want[i].ID, got[i].Content, got[i].ID)
+		}
+	}
+
+	// Importing the same export again must not duplicate entries.
+	if err := dst.ImportMemories(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("second ImportMemories failed: %v", err)
+	}
+	if got := len(dst.GetMemories()); got != 3 {
+		t.Errorf("expected re-import to be idempotent, got %d memories", got)
+	}
+}
+
+func TestImportMemoriesRejectsInvalidJSON(t *testing.T) {
+	storage, err := inmemory.NewInMemoryContextStorage(fakeEmbeddings{}, &bruteForceSearcher{})
+	if err != nil {
+		t.Fatalf("NewInMemoryContextStorage failed: %v", err)
+	}
+	if err := storage.ImportMemories(bytes.NewReader([]byte("not json"))); err == nil {
+		t.Fatal("expected an error for malformed input")
+	}
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+)
+
+func TestGetLinkedMemoriesTraversesBothDirections(t *testing.T) {
+	emb := fakeEmbeddings{
+		"Orders are stored in PostgreSQL":                   {1, 0, 0},
+		"Orders table is partitioned by month\nTags: TKT-7": {0.9, 0.1, 0},
+		"Deploy with Helm charts":                           {0, 0, 1},
+	}
+	storage, err := inmemory.NewInMemoryContextStorage(emb, &bruteForceSearcher{})
+	if err != nil {
+		t.Fatalf("NewInMemoryContextStorage failed: %v", err)
+	}
+	if err := storage.Remember(context.EasyMemory{Category: "Architecture", Content: "Orders are stored in PostgreSQL", Importance: 5}); err != nil {
+		t.Fatalf("Remember failed: %v", err)
+	}
+	if err := storage.Remember(context.EasyMemory{Category: "Architecture", Content: "Deploy with Helm charts", Importance: 2}); err != nil {
+		t.Fatalf("Remember failed: %v", err)
+	}
+	var baseID string
+	for _, m := range storage.GetMemories() {
+		if m.Content == "Orders are stored in PostgreSQL" {
+			baseID = m.ID
+		}
+	}
+	refinement := context.EasyMemory{
+		Category:   "Architecture",
+		Content:    "Orders table is partitioned by month",
+		Importance: 4,
+		Links:      []string{baseID, "missing-id"},
+		Tags:       []string{"TKT-7"},
+	}
+	if err := storage.Remember(refinement); err != nil {
+		t.Fatalf("Remember failed: %v", err)
+	}
+	var refinementID string
+	for _, m := range storage.GetMemories() {
+		if m.Content == refinement.Content {
+			refinementID = m.ID
+			if len(m.Tags) != 1 || m.Tags[0] != "TKT-7" {
+				t.Errorf("expected tags to be stored, got %v", m.Tags)
+			}
+		}
+	}
+
+	linked := storage.GetLinkedMemories(refinementID)
+	if len(linked) != 1 || linked[0].ID != baseID {
+		t.Fatalf("expected the refined memory as neighbor, got %+v", linked)
+	}
+	back := storage.GetLinkedMemories(baseID)
+	if len(back) != 1 || back[0].ID != refinementID {
+		t.Fatalf("expected the refining memory as neighbor, got %+v", back)
+	}
+	if got := storage.GetLinkedMemories("missing-id"); len(got) != 0 {
+		t.Errorf("expected no neighbors for an unknown ID, got %+v", got)
+	}
+}
@@ -0,0 +1,48 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/context/embedding/hashing"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+)
+
+func TestRememberNormalizesImportanceAndCategory(t *testing.T) {
+	storage, err := inmemory.NewInMemoryContextStorage(hashing.NewHashingEmbeddingProvider(16), &bruteForceSearcher{})
+	if err != nil {
+		t.Fatalf("NewInMemoryContextStorage failed: %v", err)
+	}
+	inputs := []context.EasyMemory{
+		{Category: "", Content: "unset importance", Importance: 0},
+		{Category: "  architecture ", Content: "negative importance", Importance: -3},
+		{Category: "Ops", Content: "huge importance", Importance: 1000},
+		{Category: "Gossip", Content: "unknown category", Importance: 7},
+	}
+	for _, m := range inputs {
+		if err := storage.Remember(m); err != nil {
+			t.Fatalf("Remember failed: %v", err)
+		}
+	}
+
+	want := map[string]struct {
+		category   string
+		importance int
+	}{
+		"unset importance":    {context.DefaultCategory, context.DefaultImportance},
+		"negative importance": {"Architecture", context.MinImportance},
+		"huge importance":     {"Ops", context.MaxImportance},
+		"unknown category":    {"Gossip", 7},
+	}
+	memories := storage.GetMemories()
+	if len(memories) != len(want) {
+		t.Fatalf("expected %d memories, got %d", len(want), len(memories))
+	}
+	for _, m := range memories {
+		w := want[m.Content]
+		if m.Category != w.category || m.Importance != w.importance {
+			t.Errorf("%q: expected category %q and importance %d, got %q and %d",
+				m.Content, w.category, w.importance, m.Category, m.Importance)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/promptbuilder/chatgptpromptbuilder"
+)
+
+func TestRefreshMemoriesSkipsUnknownDeleteIDs(t *testing.T) {
+	storage := &fakeContextStorage{}
+	for _, content := range []string{"Deploys run on Fridays", "Login uses OAuth"} {
+		if err := storage.Remember(context.EasyMemory{Content: content}); err != nil {
+			t.Fatalf("Remember failed: %v", err)
+		}
+	}
+	old := storage.GetMemories()
+	// mem_9 is offered to the model but was deleted meanwhile.
+	old = append(old, context.MemoryEntry{ID: "mem_9", Content: "Stale memory"})
+
+	pb := &fakePromptBuilder{}
+	a := &agent.BaseAgent{
+		Name:          "Agent",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{parsed: `{"delete_ids": ["mem_1", "made-up", "mem_1", "mem_9"]}`},
+		Context:       storage,
+		PromptBuilder: pb,
+	}
+	report, err := a.RefreshMemoriesReport(old, []context.EasyMemory{{Content: "Deploys run on Mondays"}})
+	if err != nil {
+		t.Fatalf("RefreshMemoriesReport failed: %v", err)
+	}
+	if !reflect.DeepEqual(report.Forgotten, []string{"mem_1"}) {
+		t.Errorf("expected only mem_1 to be forgotten, got %v", report.Forgotten)
+	}
+	if !reflect.DeepEqual(report.InvalidIDs, []string{"made-up", "mem_9"}) {
+		t.Errorf("expected the unknown IDs to be reported, got %v", report.InvalidIDs)
+	}
+	if storage.MemoryExists("mem_1") || !storage.MemoryExists("mem_2") {
+		t.Errorf("unexpected memories after refresh: %+v", storage.GetMemories())
+	}
+	if got := len(storage.GetMemories()); got != 2 {
+		t.Errorf("expected the new memory to be remembered, got %d memories", got)
+	}
+
+	calls := pb.callsWithMode("RefreshMemories")
+	if len(calls) != 1 || !strings.Contains(calls[0].UserInput, "chosen from this list: mem_1, mem_2, mem_9") {
+		t.Errorf("expected the prompt to list the allowed IDs, got %+v", calls)
+	}
+}
+
+func TestRefreshMemoriesWithoutOldMemoriesRemembersNew(t *testing.T) {
+	storage := &fakeContextStorage{}
+	pb := &fakePromptBuilder{}
+	a := &agent.BaseAgent{
+		Name:          "Agent",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{},
+		Context:       storage,
+		PromptBuilder: pb,
+	}
+	newMems := []context.EasyMemory{{Content: "Deploys run on Mondays"}, {Content: "Login uses OAuth"}}
+	if err := a.RefreshMemories(nil, newMems); err != nil {
+		t.Fatalf("RefreshMemories failed: %v", err)
+	}
+	if got := len(storage.GetMemories()); got != 2 {
+		t.Errorf("expected both new memories to be remembered, got %d", got)
+	}
+	if calls := pb.callsWithMode("RefreshMemories"); len(calls) != 0 {
+		t.Errorf("expected no model call without old memories, got %d", len(calls))
+	}
+}
+
+func TestRefreshMemoriesSchemaOffersOnlyOldIDs(t *testing.T) {
+	loadTestConfig(t, `
+roles:
+  Developer:
+    name: Developer
+    prompt: You write code.
+globalModes:
+  RefreshMemories: Decide which memories are obsolete.
+`)
+	storage := &fakeContextStorage{}
+	for _, content := range []string{"Deploys run on Fridays", "Login uses OAuth"} {
+		if err := storage.Remember(context.EasyMemory{Content: content}); err != nil {
+			t.Fatalf("Remember failed: %v", err)
+		}
+	}
+	client := &fakeModelClient{parsed: `{"delete_ids": ["mem_1"]}`}
+	a := &agent.BaseAgent{
+		Name:          "Agent",
+		Role:          "Developer",
+		ModelClient:   client,
+		Context:       storage,
+		PromptBuilder: chatgptpromptbuilder.New(),
+	}
+	if _, err := a.RefreshMemoriesReport(storage.GetMemories(), []context.EasyMemory{{Content: "Deploys run on Mondays"}}); err != nil {
+		t.Fatalf("RefreshMemoriesReport failed: %v", err)
+	}
+
+	if len(client.requests) != 1 || client.requests[0].Text == nil {
+		t.Fatalf("expected one structured request, got %+v", client.requests)
+	}
+	schema, err := json.Marshal(client.requests[0].Text.Format.Schema)
+	if err != nil {
+		t.Fatalf("failed to marshal schema: %v", err)
+	}
+	var parsed struct {
+		Properties struct {
+			DeleteIDs struct {
+				Items struct {
+					Enum []string `json:"enum"`
+				} `json:"items"`
+			} `json:"delete_ids"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	if got := parsed.Properties.DeleteIDs.Items.Enum; !reflect.DeepEqual(got, []string{"mem_1", "mem_2"}) {
+		t.Fatalf("expected delete_ids items to be limited to the old IDs, got %v in %s", got, schema)
+	}
+}
@@ -0,0 +1,115 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/context/embedding"
+	"github.com/egobogo/aiagents/internal/context/embedding/hashing"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+	"github.com/egobogo/aiagents/internal/context/similarity/hnsw"
+)
+
+// batchingProvider wraps a provider with a ComputeEmbeddings method that records the batch sizes.
+type batchingProvider struct {
+	embedding.EmbeddingProvider
+	batches []int
+	err     error
+}
+
+func (p *batchingProvider) ComputeEmbeddings(texts []string) ([][]float64, error) {
+	p.batches = append(p.batches, len(texts))
+	if p.err != nil {
+		return nil, p.err
+	}
+	return embedding.ComputeAll(p.EmbeddingProvider, texts)
+}
+
+func newReindexStorage(t *testing.T) (*inmemory.InMemoryContextStorage, *hnsw.HNSWSimilaritySearcher) {
+	t.Helper()
+	searcher, err := hnsw.New(4)
+	if err != nil {
+		t.Fatalf("hnsw.New failed: %v", err)
+	}
+	storage, err := inmemory.NewInMemoryContextStorage(hashing.NewHashingEmbeddingProvider(4), searcher)
+	if err != nil {
+		t.Fatalf("NewInMemoryContextStorage failed: %v", err)
+	}
+	for _, content := range []string{"Deploy the payment service on Fridays", "Login page uses OAuth", "Database migrations run nightly"} {
+		if err := storage.Remember(context.EasyMemory{Content: content, Category: "Ops", Importance: 5}); err != nil {
+			t.Fatalf("Remember failed: %v", err)
+		}
+	}
+	return storage, searcher
+}
+
+func TestReindexWithProviderSwitchesDimension(t *testing.T) {
+	storage, searcher := newReindexStorage(t)
+	provider := &batchingProvider{EmbeddingProvider: hashing.NewHashingEmbeddingProvider(8)}
+
+	if err := storage.ReindexWithProvider(provider); err != nil {
+		t.Fatalf("ReindexWithProvider failed: %v", err)
+	}
+	if len(provider.batches) != 1 || provider.batches[0] != 3 {
+		t.Errorf("expected one batch of 3 memories, got %v", provider.batches)
+	}
+	if searcher.Dimensions() != 8 {
+		t.Errorf("expected the index to be rebuilt at 8 dimensions, got %d", searcher.Dimensions())
+	}
+	for _, mem := range storage.GetMemories() {
+		if len(mem.Embedding) != 8 {
+			t.Errorf("memory %q has a %d-dimensional embedding", mem.Content, len(mem.Embedding))
+		}
+	}
+
+	results := storage.SearchMemoriesWithParams("Login page uses OAuth", 1, -1)
+	if len(results) != 1 || results[0].Content != "Login page uses OAuth" {
+		t.Fatalf("expected search to find the memory after reindexing, got %+v", results)
+	}
+	if err := storage.Remember(context.EasyMemory{Content: "Alerts go to the ops channel", Category: "Ops", Importance: 5}); err != nil {
+		t.Fatalf("Remember after reindexing failed: %v", err)
+	}
+}
+
+func TestReindexWithProviderFailureKeepsStorage(t *testing.T) {
+	storage, searcher := newReindexStorage(t)
+	provider := &batchingProvider{EmbeddingProvider: hashing.NewHashingEmbeddingProvider(8), err: errors.New("quota exceeded")}
+
+	if err := storage.ReindexWithProvider(provider); err == nil {
+		t.Fatal("expected the embedding error to be returned")
+	}
+	if searcher.Dimensions() != 4 {
+		t.Errorf("index should keep 4 dimensions, got %d", searcher.Dimensions())
+	}
+	results := storage.SearchMemoriesWithParams("Login page uses OAuth", 3, -1)
+	if len(results) == 0 {
+		t.Fatal("the old provider and index should still serve searches")
+	}
+}
+
+// TestReindexWhileSearching is meant to be run with -race: searches read the embedding provider
+// while ReindexWithProvider replaces it, and FilterRelatedMemories must not deadlock against it.
+func TestReindexWhileSearching(t *testing.T) {
+	storage, _ := newReindexStorage(t)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			storage.SearchMemoriesWithParams("Login page uses OAuth", 1, -1)
+			storage.FilterRelatedMemories([]context.EasyMemory{{Content: "Login page uses OAuth"}})
+		}
+	}()
+	for _, dim := range []int{8, 4, 8} {
+		if err := storage.ReindexWithProvider(hashing.NewHashingEmbeddingProvider(dim)); err != nil {
+			t.Fatalf("ReindexWithProvider failed: %v", err)
+		}
+	}
+	<-done
+
+	results := storage.SearchMemoriesWithParams("Login page uses OAuth", 1, -1)
+	if len(results) != 1 || results[0].Content != "Login page uses OAuth" {
+		t.Fatalf("expected search to work after concurrent reindexing, got %+v", results)
+	}
+}
@@ -0,0 +1,103 @@
+package test
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/context"
+	"github.com/egobogo/aiagents/internal/context/inmemory"
+)
+
+// fakeEmbeddings maps known texts to fixed vectors.
+type fakeEmbeddings map[string][]float64
+
+func (f fakeEmbeddings) ComputeEmbedding(text string) ([]float64, error) {
+	emb, ok := f[text]
+	if !ok {
+		return nil, fmt.Errorf("no embedding for %q", text)
+	}
+	return emb, nil
+}
+
+// Dimensions reports 0 because the fake holds vectors of any length.
+func (f fakeEmbeddings) Dimensions() int { return 0 }
+
+// bruteForceSearcher is an exact cosine-similarity SimilaritySearcher.
+type bruteForceSearcher struct {
+	memories []context.MemoryEntry
+}
+
+func (b *bruteForceSearcher) IndexMemory(mem context.MemoryEntry) error {
+	b.memories = append(b.memories, mem)
+	return nil
+}
+
+func (b *bruteForceSearcher) Search(query []float64, k int, threshold float64) ([]context.MemoryEntry, error) {
+	type scored struct {
+		mem   context.MemoryEntry
+		score float64
+	}
+	var hits []scored
+	for _, m := range b.memories {
+		if s := cosine(query, m.Embedding); s >= threshold {
+			hits = append(hits, scored{m, s})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	var out []context.MemoryEntry
+	for i := 0; i < len(hits) && i < k; i++ {
+		out = append(out, hits[i].mem)
+	}
+	return out, nil
+}
+
+// Dimensions reports 0 because the brute-force search accepts any length.
+func (b *bruteForceSearcher) Dimensions() int { return 0 }
+
+func cosine(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+func TestSearchMemoriesWithParamsThreshold(t *testing.T) {
+	emb := fakeEmbeddings{
+		"query":     {1, 0},
+		"close":     {0.95, 0.05},
+		"unrelated": {0.3, 0.95},
+	}
+	storage, err := inmemory.NewInMemoryContextStorage(emb, &bruteForceSearcher{})
+	if err != nil {
+		t.Fatalf("NewInMemoryContextStorage failed: %v", err)
+	}
+	for _, text := range []string{"close", "unrelated"} {
+		if err := storage.Remember(context.EasyMemory{Category: "Test", Content: text}); err != nil {
+			t.Fatalf("Remember failed: %v", err)
+		}
+	}
+
+	if got := storage.SearchMemoriesWithParams("query", 10, 0.1); len(got) != 2 {
+		t.Fatalf("expected both memories with a loose threshold, got %d", len(got))
+	}
+	got := storage.SearchMemoriesWithParams("query", 10, 0.9)
+	if len(got) != 1 || got[0].Content != "close" {
+		t.Fatalf("expected only the close memory with a tight threshold, got %v", got)
+	}
+	if got := storage.SearchMemoriesWithParams("query", 1, 0.0); len(got) != 1 {
+		t.Fatalf("expected k to cap the results at 1, got %d", len(got))
+	}
+
+	storage.SetSearchDefaults(10, 0.9)
+	if got := storage.SearchMemories("query"); len(got) != 1 {
+		t.Fatalf("expected SearchMemories to use the configured defaults, got %d results", len(got))
+	}
+}
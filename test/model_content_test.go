@@ -0,0 +1,59 @@
+package test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+)
+
+func TestMessageContentMarshalsBothForms(t *testing.T) {
+	cases := []struct {
+		name string
+		msg  modelClient.Message
+		want string
+	}{
+		{"text", modelClient.NewTextMessage("user", "Estimate the ticket"), `{"role":"user","content":"Estimate the ticket"}`},
+		{"structured", modelClient.NewStructuredMessage("user", map[string]string{"type": "input_text", "text": "Estimate the ticket"}),
+			`{"role":"user","content":[{"text":"Estimate the ticket","type":"input_text"}]}`},
+		{"content value", modelClient.Message{Role: "assistant", Content: modelClient.Content{Parts: []map[string]string{{"type": "output_text", "text": "Done"}}}},
+			`{"role":"assistant","content":[{"text":"Done","type":"output_text"}]}`},
+		{"decoded parts", modelClient.Message{Role: "user", Content: []interface{}{map[string]interface{}{"type": "input_text", "text": "Hi"}}},
+			`{"role":"user","content":[{"text":"Hi","type":"input_text"}]}`},
+	}
+	for _, tc := range cases {
+		got, err := json.Marshal(tc.msg)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", tc.name, err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("%s: got %s, want %s", tc.name, got, tc.want)
+		}
+
+		// Round-tripping keeps the content in the form the request builders use.
+		var decoded modelClient.Message
+		if err := json.Unmarshal(got, &decoded); err != nil {
+			t.Fatalf("%s: Unmarshal failed: %v", tc.name, err)
+		}
+		if decoded.Text() != tc.msg.Text() {
+			t.Errorf("%s: round trip text %q, want %q", tc.name, decoded.Text(), tc.msg.Text())
+		}
+		again, _ := json.Marshal(decoded)
+		if string(again) != tc.want {
+			t.Errorf("%s: round trip got %s, want %s", tc.name, again, tc.want)
+		}
+	}
+
+	var decoded modelClient.Message
+	if err := json.Unmarshal([]byte(`{"role":"user","content":[{"type":"input_text","text":"a"},{"type":"input_image","image_url":"https://x/y.png"}]}`), &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	want := []map[string]string{{"type": "input_text", "text": "a"}, {"type": "input_image", "image_url": "https://x/y.png"}}
+	if !reflect.DeepEqual(decoded.Content, want) {
+		t.Fatalf("expected structured parts %v, got %#v", want, decoded.Content)
+	}
+	if err := json.Unmarshal([]byte(`{"role":"user","content":[{"type":"input_text","text":1}]}`), &decoded); err == nil {
+		t.Fatal("expected an error for a part with a non-string field")
+	}
+}
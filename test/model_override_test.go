@@ -0,0 +1,75 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+)
+
+func TestChatAdvancedSendsPerRequestModel(t *testing.T) {
+	var sent []string
+	client := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", nil)
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Model string `json:"model"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		sent = append(sent, body.Model)
+		return jsonResponse(http.StatusOK, messageResponse("ok")), nil
+	})}
+
+	req := modelClient.ChatRequest{Model: "gpt-4o", Input: []modelClient.Message{{Role: "user", Content: "Write the handler"}}}
+	if _, err := client.ChatAdvanced(req); err != nil {
+		t.Fatalf("ChatAdvanced failed: %v", err)
+	}
+	if _, err := client.ChatAdvanced(modelClient.ChatRequest{Input: req.Input}); err != nil {
+		t.Fatalf("ChatAdvanced failed: %v", err)
+	}
+	if len(sent) != 2 || sent[0] != "gpt-4o" || sent[1] != "gpt-4o-mini" {
+		t.Fatalf("expected the per-request model, then the client default; got %v", sent)
+	}
+}
+
+const modeModelsConfigYAML = `
+roles:
+  Developer:
+    name: Developer
+    prompt: You write code.
+modeModels:
+  Summarize: cheap-model
+`
+
+func TestThinkUsesModelPerMode(t *testing.T) {
+	loadTestConfig(t, modeModelsConfigYAML)
+	builder := &fakePromptBuilder{}
+	a := &agent.BaseAgent{
+		Name:          "Developer",
+		Role:          "Developer",
+		ModelClient:   &fakeModelClient{text: "ok", parsed: `{"result":[]}`},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: builder,
+	}
+
+	if _, err := a.Think("", "Implement the login handler", "GenerateCode", "strong-model", nil); err != nil {
+		t.Fatalf("Think failed: %v", err)
+	}
+
+	want := map[string]string{"Summarize": "cheap-model", "GenerateCode": "strong-model"}
+	for mode, model := range want {
+		calls := builder.callsWithMode(mode)
+		if len(calls) == 0 {
+			t.Fatalf("expected a %s request", mode)
+		}
+		for _, c := range calls {
+			if c.Model != model {
+				t.Errorf("%s: expected model %q, got %q", mode, model, c.Model)
+			}
+		}
+	}
+}
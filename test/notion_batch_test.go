@@ -0,0 +1,95 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+func TestNotionCreatePagesParentsBeforeChildren(t *testing.T) {
+	var mu sync.Mutex
+	created := map[string]string{} // title -> parent page ID
+	ids := map[string]string{}     // title -> new page ID
+
+	nc := notion.NewNotionClient("token", "root-page")
+	nc.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == "GET" {
+			// No page exists yet under any parent.
+			return jsonResponse(http.StatusOK, `{"results":[]}`), nil
+		}
+		var payload struct {
+			Parent struct {
+				PageID string `json:"page_id"`
+			} `json:"parent"`
+			Properties struct {
+				Title struct {
+					Title []struct {
+						Text struct {
+							Content string `json:"content"`
+						} `json:"text"`
+					} `json:"title"`
+				} `json:"title"`
+			} `json:"properties"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			return nil, err
+		}
+		title := payload.Properties.Title.Title[0].Text.Content
+		mu.Lock()
+		defer mu.Unlock()
+		id := fmt.Sprintf("id-%d", len(ids)+1)
+		created[title] = payload.Parent.PageID
+		ids[title] = id
+		body := fmt.Sprintf(`{"id":%q,"url":"https://notion.so/%s","properties":{"title":{"title":[{"text":{"content":%q}}]}}}`, id, id, title)
+		return jsonResponse(http.StatusOK, body), nil
+	})}
+
+	pages, err := nc.CreatePages([]notion.PageSpec{
+		{Ref: "arch", Title: "Architecture", Content: "Overview"},
+		{Title: "Services", Content: "Service list", ParentID: "arch"},
+		{Title: "Storage", Content: "Databases", ParentID: "arch"},
+		{Title: "Runbook", Content: "Ops", ParentID: "existing-page"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePages failed: %v", err)
+	}
+	if len(pages) != 4 {
+		t.Fatalf("expected 4 pages, got %d", len(pages))
+	}
+	if created["Architecture"] != "root-page" {
+		t.Errorf("expected Architecture under the root page, got %q", created["Architecture"])
+	}
+	for _, child := range []string{"Services", "Storage"} {
+		if created[child] != ids["Architecture"] {
+			t.Errorf("expected %s under %s, got %q", child, ids["Architecture"], created[child])
+		}
+	}
+	if created["Runbook"] != "existing-page" {
+		t.Errorf("expected Runbook under existing-page, got %q", created["Runbook"])
+	}
+	if pages[1].ParentID != pages[0].ID {
+		t.Errorf("expected returned child ParentID %q, got %q", pages[0].ID, pages[1].ParentID)
+	}
+}
+
+func TestNotionCreatePagesSkipsChildrenOfFailedParent(t *testing.T) {
+	nc := notion.NewNotionClient("token", "root-page")
+	nc.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusBadRequest, `{"message":"bad"}`), nil
+	})}
+
+	pages, err := nc.CreatePages([]notion.PageSpec{
+		{Ref: "arch", Title: "Architecture"},
+		{Title: "Services", ParentID: "arch"},
+	})
+	if err == nil {
+		t.Fatalf("expected an aggregated error")
+	}
+	if len(pages) != 2 || pages[0].ID != "" || pages[1].ID != "" {
+		t.Fatalf("expected zero pages for failed specs, got %+v", pages)
+	}
+}
@@ -0,0 +1,94 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+type appendedBlock struct {
+	Type string `json:"type"`
+	Code struct {
+		Language string `json:"language"`
+		RichText []struct {
+			Text struct {
+				Content string `json:"content"`
+			} `json:"text"`
+		} `json:"rich_text"`
+	} `json:"code"`
+}
+
+func TestNotionAppendCodeBlock(t *testing.T) {
+	var blocks []appendedBlock
+	var paths []string
+	nc := notion.NewNotionClient("token", "root-page")
+	nc.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.Method+" "+req.URL.Path)
+		var payload struct {
+			Children []appendedBlock `json:"children"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, payload.Children...)
+		return jsonResponse(http.StatusOK, `{}`), nil
+	})}
+
+	code := "package main\n\nfunc main() {}\n"
+	if err := nc.AppendCodeBlock("page-1", "go", code); err != nil {
+		t.Fatalf("AppendCodeBlock failed: %v", err)
+	}
+	if len(paths) != 1 || paths[0] != "PATCH /v1/blocks/page-1/children" {
+		t.Fatalf("unexpected requests: %v", paths)
+	}
+	if len(blocks) != 1 || blocks[0].Type != "code" || blocks[0].Code.Language != "go" {
+		t.Fatalf("expected one go code block, got %+v", blocks)
+	}
+	if got := blocks[0].Code.RichText[0].Text.Content; got != code {
+		t.Fatalf("code content mangled: %q", got)
+	}
+}
+
+func TestNotionAppendCodeBlockSplitsLongCode(t *testing.T) {
+	var blocks []appendedBlock
+	nc := notion.NewNotionClient("token", "root-page")
+	nc.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var payload struct {
+			Children []appendedBlock `json:"children"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, payload.Children...)
+		return jsonResponse(http.StatusOK, `{}`), nil
+	})}
+
+	line := strings.Repeat("x", 99) + "\n"
+	code := strings.Repeat(line, 50) // 5000 characters
+	if err := nc.AppendCodeBlock("page-1", "python", code); err != nil {
+		t.Fatalf("AppendCodeBlock failed: %v", err)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 code blocks, got %d", len(blocks))
+	}
+	var joined strings.Builder
+	for _, b := range blocks {
+		content := b.Code.RichText[0].Text.Content
+		if len(content) > 2000 {
+			t.Fatalf("block exceeds the rich-text limit: %d characters", len(content))
+		}
+		if !strings.HasSuffix(content, "\n") {
+			t.Fatalf("expected blocks to break on line boundaries")
+		}
+		if b.Code.Language != "python" {
+			t.Fatalf("expected language python, got %q", b.Code.Language)
+		}
+		joined.WriteString(content)
+	}
+	if joined.String() != code {
+		t.Fatalf("split code does not reassemble to the original")
+	}
+}
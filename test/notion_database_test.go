@@ -0,0 +1,73 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+// databaseRow builds a database query result row whose title property is called "Name".
+func databaseRow(id, title string) map[string]interface{} {
+	return map[string]interface{}{
+		"object": "page",
+		"id":     id,
+		"url":    "https://notion.so/" + id,
+		"parent": map[string]string{"type": "database_id", "database_id": "db-1"},
+		"properties": map[string]interface{}{
+			"Status": map[string]interface{}{"type": "select", "select": map[string]string{"name": "Done"}},
+			"Name": map[string]interface{}{
+				"type":  "title",
+				"title": []map[string]interface{}{{"text": map[string]string{"content": title}}},
+			},
+		},
+	}
+}
+
+func TestNotionListPagesQueriesDatabaseRoot(t *testing.T) {
+	var cursors []interface{}
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/databases/db-1":
+			return jsonResponse(http.StatusOK, `{"object":"database","id":"db-1","url":"https://notion.so/db-1",
+				"title":[{"text":{"content":"Engineering wiki"}}]}`), nil
+		case req.Method == http.MethodPost && req.URL.Path == "/databases/db-1/query":
+			var payload map[string]interface{}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				return nil, err
+			}
+			cursors = append(cursors, payload["start_cursor"])
+			page := map[string]interface{}{"results": []interface{}{databaseRow("row-1", "Architecture")}, "has_more": true, "next_cursor": "c2"}
+			if payload["start_cursor"] == "c2" {
+				page = map[string]interface{}{"results": []interface{}{databaseRow("row-2", "Runbook")}, "has_more": false}
+			}
+			body, _ := json.Marshal(page)
+			return jsonResponse(http.StatusOK, string(body)), nil
+		}
+		return jsonResponse(http.StatusNotFound, `{}`), nil
+	})}
+	nc := notion.NewNotionClient("token", "db-1")
+	nc.BaseURL = "https://notion.test"
+	nc.HTTPClient = client
+
+	pages, err := nc.ListPages()
+	if err != nil {
+		t.Fatalf("ListPages failed: %v", err)
+	}
+	if len(cursors) != 2 || cursors[0] != nil || cursors[1] != "c2" {
+		t.Fatalf("expected two paginated queries, got cursors %v", cursors)
+	}
+	if len(pages) != 3 {
+		t.Fatalf("expected the database and its two rows, got %+v", pages)
+	}
+	if pages[0].ID != "db-1" || pages[0].Title != "Engineering wiki" {
+		t.Errorf("unexpected root %+v", pages[0])
+	}
+	for i, want := range []string{"Architecture", "Runbook"} {
+		p := pages[i+1]
+		if p.Title != want || p.ParentID != "db-1" || p.Path != "Engineering wiki/"+want {
+			t.Errorf("row %d: unexpected page %+v", i, p)
+		}
+	}
+}
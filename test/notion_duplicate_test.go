@@ -0,0 +1,82 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+func TestNotionDuplicatePageCopiesBlocks(t *testing.T) {
+	richText := func(text string) map[string]interface{} {
+		return map[string]interface{}{"rich_text": []interface{}{map[string]interface{}{"type": "text", "text": map[string]interface{}{"content": text}}}}
+	}
+	children := map[string][]map[string]interface{}{
+		"source": {
+			{"object": "block", "id": "b1", "type": "heading_2", "has_children": false, "created_time": "2024-01-01T00:00:00Z", "heading_2": richText("Overview")},
+			{"object": "block", "id": "b2", "type": "bulleted_list_item", "has_children": true, "bulleted_list_item": richText("Storage")},
+			{"object": "block", "id": "b3", "type": "child_page", "has_children": true, "child_page": map[string]interface{}{"title": "Runbook"}},
+		},
+		"b2": {
+			{"object": "block", "id": "b4", "type": "paragraph", "has_children": false, "paragraph": richText("PostgreSQL 16")},
+		},
+	}
+
+	var created map[string]interface{}
+	var appended []interface{}
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodGet:
+			id := req.URL.Path[len("/blocks/") : len(req.URL.Path)-len("/children")]
+			body, _ := json.Marshal(map[string]interface{}{"results": children[id], "has_more": false})
+			return jsonResponse(http.StatusOK, string(body)), nil
+		case req.Method == http.MethodPost && req.URL.Path == "/pages":
+			if err := json.NewDecoder(req.Body).Decode(&created); err != nil {
+				return nil, err
+			}
+			return jsonResponse(http.StatusOK, `{"id":"copy","url":"https://notion.so/copy",
+				"properties":{"title":{"title":[{"text":{"content":"Billing architecture"}}]}}}`), nil
+		case req.Method == http.MethodPatch && req.URL.Path == "/blocks/copy/children":
+			var payload struct {
+				Children []interface{} `json:"children"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				return nil, err
+			}
+			appended = append(appended, payload.Children...)
+			return jsonResponse(http.StatusOK, `{}`), nil
+		}
+		return jsonResponse(http.StatusNotFound, `{}`), nil
+	})}
+	nc := notion.NewNotionClient("token", "root")
+	nc.BaseURL = "https://notion.test"
+	nc.HTTPClient = client
+
+	page, err := nc.DuplicatePage("source", "services", "Billing architecture")
+	if err != nil {
+		t.Fatalf("DuplicatePage failed: %v", err)
+	}
+	if page.ID == "source" || page.ID != "copy" || page.Title != "Billing architecture" || page.ParentID != "services" {
+		t.Fatalf("unexpected page %+v", page)
+	}
+	if parent := created["parent"].(map[string]interface{}); parent["page_id"] != "services" {
+		t.Errorf("expected the copy under services, got %v", parent)
+	}
+
+	nested := richText("Storage")
+	nested["children"] = []interface{}{map[string]interface{}{"object": "block", "type": "paragraph", "paragraph": richText("PostgreSQL 16")}}
+	want := []interface{}{
+		map[string]interface{}{"object": "block", "type": "heading_2", "heading_2": richText("Overview")},
+		map[string]interface{}{"object": "block", "type": "bulleted_list_item", "bulleted_list_item": nested},
+	}
+	// Round-trip through JSON so the expectation has the same types as the decoded request.
+	data, _ := json.Marshal(want)
+	var wantDecoded []interface{}
+	json.Unmarshal(data, &wantDecoded)
+	if !reflect.DeepEqual(appended, wantDecoded) {
+		got, _ := json.MarshalIndent(appended, "", "  ")
+		t.Fatalf("copied blocks differ from the source body:\n%s", got)
+	}
+}
@@ -0,0 +1,123 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+// fakeNotionTree serves the page-creation and block-children endpoints over an in-memory page tree.
+type fakeNotionTree struct {
+	mu       sync.Mutex
+	children map[string][]string // parent ID -> child page IDs
+	titles   map[string]string   // page ID -> title
+	creates  int
+}
+
+func newFakeNotionTree() *fakeNotionTree {
+	return &fakeNotionTree{children: map[string][]string{}, titles: map[string]string{}}
+}
+
+func (f *fakeNotionTree) roundTrip(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/children"):
+		parent := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/v1/blocks/"), "/children")
+		var results []map[string]interface{}
+		for _, id := range f.children[parent] {
+			results = append(results, map[string]interface{}{
+				"id": id, "type": "child_page", "child_page": map[string]string{"title": f.titles[id]},
+			})
+		}
+		body, _ := json.Marshal(map[string]interface{}{"results": results, "has_more": false})
+		return jsonResponse(http.StatusOK, string(body)), nil
+	case req.Method == "POST" && strings.HasSuffix(req.URL.Path, "/pages"):
+		var payload struct {
+			Parent struct {
+				PageID string `json:"page_id"`
+			} `json:"parent"`
+			Properties struct {
+				Title struct {
+					Title []struct {
+						Text struct {
+							Content string `json:"content"`
+						} `json:"text"`
+					} `json:"title"`
+				} `json:"title"`
+			} `json:"properties"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			return nil, err
+		}
+		f.creates++
+		id := fmt.Sprintf("page-%d", f.creates)
+		title := payload.Properties.Title.Title[0].Text.Content
+		f.titles[id] = title
+		f.children[payload.Parent.PageID] = append(f.children[payload.Parent.PageID], id)
+		body := fmt.Sprintf(`{"id":%q,"url":"https://notion.example/%s","properties":{"title":{"title":[{"text":{"content":%q}}]}}}`, id, id, title)
+		return jsonResponse(http.StatusOK, body), nil
+	}
+	return jsonResponse(http.StatusNotFound, `{"message":"not found"}`), nil
+}
+
+func TestNotionCreatePageIfAbsentReturnsExistingPage(t *testing.T) {
+	tree := newFakeNotionTree()
+	nc := notion.NewNotionClient("token", "root-page")
+	nc.HTTPClient = &http.Client{Transport: roundTripFunc(tree.roundTrip)}
+
+	first, created, err := nc.CreatePageIfAbsent("Architecture", "Overview", "")
+	if err != nil || !created {
+		t.Fatalf("first call: created=%v err=%v", created, err)
+	}
+	second, created, err := nc.CreatePageIfAbsent("Architecture", "Overview", "root-page")
+	if err != nil {
+		t.Fatalf("second call failed: %v", err)
+	}
+	if created {
+		t.Fatal("second call should reuse the existing page")
+	}
+	if second.ID != first.ID || second.ParentID != "root-page" || second.Title != "Architecture" {
+		t.Errorf("unexpected existing page %+v, first was %+v", second, first)
+	}
+	if tree.creates != 1 {
+		t.Errorf("expected one page to be created, got %d", tree.creates)
+	}
+
+	// The same title under another parent is a different page.
+	if _, created, err := nc.CreatePageIfAbsent("Architecture", "Overview", first.ID); err != nil || !created {
+		t.Errorf("expected a new page under another parent: created=%v err=%v", created, err)
+	}
+}
+
+func TestNotionCreatePagesIsIdempotent(t *testing.T) {
+	tree := newFakeNotionTree()
+	nc := notion.NewNotionClient("token", "root-page")
+	nc.HTTPClient = &http.Client{Transport: roundTripFunc(tree.roundTrip)}
+	specs := []notion.PageSpec{
+		{Ref: "arch", Title: "Architecture", Content: "Overview"},
+		{Title: "Services", Content: "Service list", ParentID: "arch"},
+	}
+
+	first, err := nc.CreatePages(specs)
+	if err != nil {
+		t.Fatalf("first CreatePages failed: %v", err)
+	}
+	second, err := nc.CreatePages(specs)
+	if err != nil {
+		t.Fatalf("second CreatePages failed: %v", err)
+	}
+	if tree.creates != 2 {
+		t.Errorf("expected re-running the batch to create nothing, got %d creates", tree.creates)
+	}
+	for i := range specs {
+		if first[i].ID != second[i].ID {
+			t.Errorf("spec %d: expected the existing page %q, got %q", i, first[i].ID, second[i].ID)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+// nestedBlocksClient serves a page whose first block nests five levels deep and which has the same bullet twice.
+func nestedBlocksClient() *http.Client {
+	block := func(id, typ, text string, hasChildren bool) map[string]interface{} {
+		return map[string]interface{}{
+			"id":           id,
+			"type":         typ,
+			"has_children": hasChildren,
+			typ:            map[string]interface{}{"rich_text": []map[string]interface{}{{"text": map[string]string{"content": text}}}},
+		}
+	}
+	children := map[string][]map[string]interface{}{
+		"page": {
+			block("level-1", "paragraph", "Level 1", true),
+			block("bullet-a", "bulleted_list_item", "Repeat me", false),
+			block("bullet-b", "bulleted_list_item", "Repeat me", false),
+		},
+	}
+	for level := 2; level <= 5; level++ {
+		parent := fmt.Sprintf("level-%d", level-1)
+		children[parent] = []map[string]interface{}{
+			block(fmt.Sprintf("level-%d", level), "paragraph", fmt.Sprintf("Level %d", level), level < 5),
+		}
+	}
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		id := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/blocks/"), "/children")
+		body, _ := json.Marshal(map[string]interface{}{"results": children[id], "has_more": false})
+		return jsonResponse(http.StatusOK, string(body)), nil
+	})}
+}
+
+func TestNotionReadNestedContentKeepsRepeatsAndRespectsDepth(t *testing.T) {
+	nc := notion.NewNotionClient("token", "root")
+	nc.BaseURL = "https://notion.test"
+	nc.HTTPClient = nestedBlocksClient()
+
+	full, err := nc.ReadNestedContent("page", 5)
+	if err != nil {
+		t.Fatalf("ReadNestedContent failed: %v", err)
+	}
+	want := "Level 1\nLevel 2\nLevel 3\nLevel 4\nLevel 5\n- Repeat me\n- Repeat me\n"
+	if full != want {
+		t.Fatalf("unexpected content:\n%s\nwant:\n%s", full, want)
+	}
+
+	shallow, err := nc.ReadNestedContent("page", 3)
+	if err != nil {
+		t.Fatalf("ReadNestedContent failed: %v", err)
+	}
+	if !strings.Contains(shallow, "Level 3") || strings.Contains(shallow, "Level 4") {
+		t.Fatalf("expected content down to level 3 only, got:\n%s", shallow)
+	}
+	if strings.Count(shallow, "- Repeat me") != 2 {
+		t.Fatalf("expected both repeated bullets, got:\n%s", shallow)
+	}
+
+	nc.BlockTypes = []string{"bulleted_list_item"}
+	bullets, err := nc.ReadNestedContent("page", 0)
+	if err != nil {
+		t.Fatalf("ReadNestedContent failed: %v", err)
+	}
+	if bullets != "- Repeat me\n- Repeat me\n" {
+		t.Fatalf("expected only bullets, got:\n%s", bullets)
+	}
+}
@@ -0,0 +1,55 @@
+package test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+func TestNotionGetPageProperties(t *testing.T) {
+	nc := notion.NewNotionClient("token", "root")
+	nc.BaseURL = "https://notion.test"
+	nc.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet || req.URL.Path != "/pages/page-1" {
+			t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+			return jsonResponse(http.StatusNotFound, `{}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"object":"page","id":"page-1","properties":{
+			"Name":{"id":"title","type":"title","title":[{"plain_text":"Login page","text":{"content":"Login page"}}]},
+			"Status":{"id":"s1","type":"select","select":{"id":"o1","name":"In Progress","color":"blue"}},
+			"Due":{"id":"d1","type":"date","date":{"start":"2025-03-14","end":null}},
+			"Tags":{"id":"t1","type":"multi_select","multi_select":[{"name":"frontend"},{"name":"auth"}]},
+			"Reviewed":{"id":"c1","type":"checkbox","checkbox":true},
+			"Owner":{"id":"r1","type":"select","select":null}
+		}}`), nil
+	})}
+
+	props, err := nc.GetPageProperties("page-1")
+	if err != nil {
+		t.Fatalf("GetPageProperties failed: %v", err)
+	}
+	if len(props) != 6 {
+		t.Fatalf("expected every property to be returned, got %v", props)
+	}
+	if status, ok := notion.SelectValue(props, "Status"); !ok || status != "In Progress" {
+		t.Errorf("expected the select value In Progress, got %q (%v)", status, ok)
+	}
+	if due, ok := notion.DateValue(props, "Due"); !ok || !due.Equal(time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the date 2025-03-14, got %v (%v)", due, ok)
+	}
+	if tags, ok := notion.MultiSelectValues(props, "Tags"); !ok || !reflect.DeepEqual(tags, []string{"frontend", "auth"}) {
+		t.Errorf("unexpected multi-select values %v (%v)", tags, ok)
+	}
+	if checked, ok := notion.CheckboxValue(props, "Reviewed"); !ok || !checked {
+		t.Errorf("expected the checkbox to be checked, got %v (%v)", checked, ok)
+	}
+	if _, ok := notion.SelectValue(props, "Owner"); ok {
+		t.Error("expected an empty select to report no value")
+	}
+	if _, ok := notion.DateValue(props, "Status"); ok {
+		t.Error("expected a type mismatch to report no value")
+	}
+}
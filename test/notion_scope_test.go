@@ -0,0 +1,87 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+// notionSearchTransport answers /search with the given pages (id -> parent id) and titles.
+// A non-empty query only returns pages whose title contains it.
+func notionSearchTransport(parents, titles map[string]string) *http.Client {
+	return &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if !strings.HasSuffix(req.URL.Path, "/search") {
+			return jsonResponse(http.StatusNotFound, `{}`), nil
+		}
+		var payload struct {
+			Query string `json:"query"`
+		}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			return nil, err
+		}
+		var results []map[string]interface{}
+		for id, parent := range parents {
+			if payload.Query != "" && !strings.Contains(titles[id], payload.Query) {
+				continue
+			}
+			results = append(results, map[string]interface{}{
+				"id":     id,
+				"url":    "https://notion.so/" + id,
+				"parent": map[string]string{"type": "page_id", "page_id": parent},
+				"properties": map[string]interface{}{
+					"title": map[string]interface{}{"title": []map[string]interface{}{{"text": map[string]string{"content": titles[id]}}}},
+				},
+			})
+		}
+		body, _ := json.Marshal(map[string]interface{}{"results": results, "has_more": false})
+		return jsonResponse(http.StatusOK, string(body)), nil
+	})}
+}
+
+func TestNotionSearchPagesUnderReturnsOnlySubtree(t *testing.T) {
+	parents := map[string]string{
+		"aaaa-1": "project-root", "aaaa-2": "aaaa-1", "aaaa-3": "aaaa-2",
+		"bbbb-1": "other-root", "bbbb-2": "bbbb-1",
+	}
+	titles := map[string]string{
+		"aaaa-1": "Architecture", "aaaa-2": "Architecture: Storage", "aaaa-3": "Backups",
+		"bbbb-1": "Architecture of another team", "bbbb-2": "Their backups",
+	}
+	nc := notion.NewNotionClient("token", "project-root")
+	nc.HTTPClient = notionSearchTransport(parents, titles)
+
+	pages, err := nc.SearchPagesUnder("project-root", "")
+	if err != nil {
+		t.Fatalf("SearchPagesUnder failed: %v", err)
+	}
+	var ids []string
+	for _, p := range pages {
+		ids = append(ids, p.ID)
+	}
+	sort.Strings(ids)
+	if fmt.Sprint(ids) != "[aaaa-1 aaaa-2 aaaa-3]" {
+		t.Fatalf("expected only the project subtree, got %v", ids)
+	}
+
+	pages, err = nc.SearchPagesUnder("project-root", "Architecture")
+	if err != nil {
+		t.Fatalf("SearchPagesUnder with query failed: %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 matching project pages, got %+v", pages)
+	}
+
+	// IDs given without dashes still match.
+	sub, err := nc.ListSubPages("aaaa1")
+	if err != nil {
+		t.Fatalf("ListSubPages failed: %v", err)
+	}
+	if len(sub) != 1 || sub[0].ID != "aaaa-2" {
+		t.Fatalf("expected aaaa-2 as the only child, got %+v", sub)
+	}
+}
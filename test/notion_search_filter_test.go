@@ -0,0 +1,59 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+func TestNotionSearchPagesWithFilter(t *testing.T) {
+	var payloads []map[string]interface{}
+	nc := notion.NewNotionClient("token", "root")
+	nc.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+			return nil, err
+		}
+		payloads = append(payloads, payload)
+		// Notion matches the query loosely, so it also returns pages the filter has to drop.
+		return jsonResponse(http.StatusOK, `{"results": [
+			{"id": "p1", "url": "u1", "parent": {"type": "page_id", "page_id": "root"}, "properties": {"title": {"title": [{"text": {"content": "API Design"}}]}}},
+			{"id": "p2", "url": "u2", "parent": {"type": "page_id", "page_id": "root"}, "properties": {"title": {"title": [{"text": {"content": "Rapid prototyping"}}]}}},
+			{"id": "p3", "url": "u3", "parent": {"type": "page_id", "page_id": "root"}, "properties": {"title": {"title": [{"text": {"content": "api rate limits"}}]}}}
+		], "has_more": false}`), nil
+	})}
+
+	pages, err := nc.SearchPagesWithFilter("api", notion.SearchFilter{TitlePrefix: "API", SortByEdited: notion.SortDescending})
+	if err != nil {
+		t.Fatalf("SearchPagesWithFilter failed: %v", err)
+	}
+	var ids []string
+	for _, p := range pages {
+		ids = append(ids, p.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"p1", "p3"}) {
+		t.Fatalf("expected the pages whose title starts with api, got %v", ids)
+	}
+	wantSort := map[string]interface{}{"direction": "descending", "timestamp": "last_edited_time"}
+	if len(payloads) != 1 || !reflect.DeepEqual(payloads[0]["sort"], wantSort) || payloads[0]["query"] != "api" {
+		t.Fatalf("expected the query and sort to be sent, got %v", payloads)
+	}
+
+	pages, err = nc.SearchPagesWithFilter("", notion.SearchFilter{TitleContains: "PROTO"})
+	if err != nil {
+		t.Fatalf("SearchPagesWithFilter failed: %v", err)
+	}
+	if len(pages) != 1 || pages[0].ID != "p2" {
+		t.Fatalf("expected only the page containing proto, got %+v", pages)
+	}
+	if _, ok := payloads[1]["sort"]; ok {
+		t.Fatalf("expected no sort without SortByEdited, got %v", payloads[1])
+	}
+
+	if _, err := nc.SearchPagesWithFilter("", notion.SearchFilter{SortByEdited: "sideways"}); err == nil {
+		t.Fatal("expected an error for an invalid sort direction")
+	}
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+func TestNotionUpdateTitleRenamesUntitledPage(t *testing.T) {
+	// The page starts untitled: its title property has no rich-text parts.
+	title := []interface{}{}
+	var patched map[string]interface{}
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodPatch && req.URL.Path == "/pages/page-1":
+			if err := json.NewDecoder(req.Body).Decode(&patched); err != nil {
+				return nil, err
+			}
+			props := patched["properties"].(map[string]interface{})
+			title = props["title"].(map[string]interface{})["title"].([]interface{})
+			return jsonResponse(http.StatusOK, `{"id":"page-1"}`), nil
+		case req.Method == http.MethodGet && req.URL.Path == "/pages/page-1":
+			body, _ := json.Marshal(map[string]interface{}{
+				"id":         "page-1",
+				"url":        "https://notion.so/page-1",
+				"parent":     map[string]string{"type": "page_id", "page_id": "root"},
+				"properties": map[string]interface{}{"title": map[string]interface{}{"title": title}},
+			})
+			return jsonResponse(http.StatusOK, string(body)), nil
+		case strings.HasPrefix(req.URL.Path, "/blocks/"):
+			return jsonResponse(http.StatusOK, `{"results":[],"has_more":false}`), nil
+		}
+		return jsonResponse(http.StatusNotFound, `{}`), nil
+	})}
+	nc := notion.NewNotionClient("token", "root")
+	nc.BaseURL = "https://notion.test"
+	nc.HTTPClient = client
+
+	before, err := nc.ReadPage("page-1")
+	if err != nil {
+		t.Fatalf("ReadPage of an untitled page failed: %v", err)
+	}
+	if before.Title != "" {
+		t.Fatalf("expected an untitled page, got %q", before.Title)
+	}
+
+	if err := nc.UpdateTitle("page-1", "Payments architecture"); err != nil {
+		t.Fatalf("UpdateTitle failed: %v", err)
+	}
+	parts, ok := patched["properties"].(map[string]interface{})["title"].(map[string]interface{})["title"].([]interface{})
+	if !ok || len(parts) != 1 {
+		t.Fatalf("unexpected title payload: %v", patched)
+	}
+	text := parts[0].(map[string]interface{})["text"].(map[string]interface{})
+	if text["content"] != "Payments architecture" {
+		t.Errorf("expected the new title in the payload, got %v", text)
+	}
+
+	after, err := nc.ReadPage("page-1")
+	if err != nil {
+		t.Fatalf("ReadPage failed: %v", err)
+	}
+	if after.Title != "Payments architecture" {
+		t.Errorf("expected the renamed title, got %q", after.Title)
+	}
+}
@@ -0,0 +1,45 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/docs/notion"
+)
+
+func TestNotionPrintTreeWithUnreadableRoot(t *testing.T) {
+	parents := map[string]string{
+		"aaaa-1": "project-root", "aaaa-2": "aaaa-1",
+		"bbbb-1": "other-root",
+	}
+	titles := map[string]string{"aaaa-1": "Architecture", "aaaa-2": "Storage", "bbbb-1": "Another team"}
+	nc := notion.NewNotionClient("token", "project-root")
+	// The transport answers only /search; reading the root page returns 404.
+	nc.HTTPClient = notionSearchTransport(parents, titles)
+
+	tree, err := nc.PrintTree()
+	if err != nil {
+		t.Fatalf("PrintTree failed: %v", err)
+	}
+	want := "[root page project-root could not be read; showing the pages found under it]\n" +
+		"└── Architecture (ID: aaaa-1, URL: https://notion.so/aaaa-1)\n" +
+		"    └── Storage (ID: aaaa-2, URL: https://notion.so/aaaa-2)\n"
+	if tree != want {
+		t.Fatalf("unexpected tree:\n%s\nwant:\n%s", tree, want)
+	}
+
+	// With nothing found under the root either, the error says the root is not accessible.
+	nc.HTTPClient = notionSearchTransport(map[string]string{"bbbb-1": "other-root"}, titles)
+	tree, err = nc.PrintTree()
+	if err == nil {
+		t.Fatalf("expected an error, got tree:\n%s", tree)
+	}
+	if !errors.Is(err, notion.ErrRootUnreadable) || !errors.Is(err, apierr.ErrNotFound) {
+		t.Fatalf("expected ErrRootUnreadable wrapping ErrNotFound, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "root page project-root is not accessible") {
+		t.Fatalf("expected a clear root access error, got %v", err)
+	}
+}
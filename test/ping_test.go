@@ -0,0 +1,52 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+	"github.com/egobogo/aiagents/internal/docs/notion"
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+)
+
+func TestPingReportsUnauthorized(t *testing.T) {
+	unauthorized := statusTransport(http.StatusUnauthorized)
+
+	vs := vectorstorage.NewClient("bad-key")
+	vs.HTTPClient = unauthorized
+	chat := chatgpt.NewChatGPTClient("bad-key", "gpt-4o-mini", vs)
+	chat.HTTPClient = unauthorized
+	nc := notion.NewNotionClient("bad-token", "root")
+	nc.HTTPClient = unauthorized
+	tc := trelloClient.NewTrelloClient("key", "bad-token", "board1")
+	tc.Client.Client = unauthorized
+
+	checks := map[string]func(context.Context) error{
+		"chatgpt":       chat.Ping,
+		"vectorstorage": vs.Ping,
+		"notion":        nc.Ping,
+		"trello":        tc.Ping,
+	}
+	for name, ping := range checks {
+		if err := ping(context.Background()); !errors.Is(err, apierr.ErrUnauthorized) {
+			t.Errorf("%s: expected ErrUnauthorized, got %v", name, err)
+		}
+	}
+}
+
+func TestPingSucceedsOnOK(t *testing.T) {
+	nc := notion.NewNotionClient("token", "root")
+	nc.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path != "/v1/pages/root" {
+			t.Errorf("unexpected path %s", req.URL.Path)
+		}
+		return jsonResponse(http.StatusOK, `{"id":"root"}`), nil
+	})}
+	if err := nc.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	"github.com/egobogo/aiagents/internal/poller"
+)
+
+// staticTickets is a TicketSource returning a fixed set of cards, or an error.
+type staticTickets struct {
+	cards []board.Card
+	err   error
+}
+
+func (s *staticTickets) FindMyTickets() ([]board.Card, error) {
+	return s.cards, s.err
+}
+
+func TestPollerRecoversFromPanickingTicket(t *testing.T) {
+	source := &staticTickets{cards: []board.Card{&fakeCard{name: "boom"}, &fakeCard{name: "fine"}}}
+	var handled []string
+	p := poller.New(source, func(ctx context.Context, ticket board.Card) error {
+		if ticket.GetName() == "boom" {
+			panic("handler exploded")
+		}
+		handled = append(handled, ticket.GetName())
+		return nil
+	})
+
+	if err := p.ProcessOnce(context.Background()); err != nil {
+		t.Fatalf("ProcessOnce failed: %v", err)
+	}
+	if len(handled) != 1 || handled[0] != "fine" {
+		t.Fatalf("expected the ticket after the panic to be processed, got %v", handled)
+	}
+}
+
+func TestPollerBacksOffOnConsecutiveFailures(t *testing.T) {
+	source := &staticTickets{err: errors.New("board unavailable")}
+	p := poller.New(source, func(ctx context.Context, ticket board.Card) error { return nil })
+	p.BaseInterval = time.Second
+	p.MaxInterval = 5 * time.Second
+
+	want := []time.Duration{2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if err := p.ProcessOnce(context.Background()); err == nil {
+			t.Fatalf("poll %d: expected an error", i+1)
+		}
+		if got := p.NextInterval(); got != w {
+			t.Fatalf("poll %d: expected interval %v, got %v", i+1, w, got)
+		}
+	}
+
+	source.err = nil
+	if err := p.ProcessOnce(context.Background()); err != nil {
+		t.Fatalf("ProcessOnce failed: %v", err)
+	}
+	if got := p.NextInterval(); got != time.Second {
+		t.Fatalf("expected the interval to reset after success, got %v", got)
+	}
+}
+
+// countingTickets counts polls and signals after the first one.
+type countingTickets struct {
+	polls  int
+	polled chan struct{}
+}
+
+func (c *countingTickets) FindMyTickets() ([]board.Card, error) {
+	c.polls++
+	if c.polls == 1 {
+		close(c.polled)
+	}
+	return nil, nil
+}
+
+func TestPollerRunStopsOnCancel(t *testing.T) {
+	source := &countingTickets{polled: make(chan struct{})}
+	p := poller.New(source, func(ctx context.Context, ticket board.Card) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- p.Run(ctx, time.Hour) }()
+
+	<-source.polled
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run returned an error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not exit promptly after cancellation")
+	}
+	if source.polls != 1 {
+		t.Fatalf("expected no new poll after cancellation, got %d polls", source.polls)
+	}
+}
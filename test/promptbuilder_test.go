@@ -0,0 +1,154 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/config/filesys"
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/promptbuilder"
+	"github.com/egobogo/aiagents/internal/promptbuilder/chatgptpromptbuilder"
+	"github.com/egobogo/aiagents/internal/promptbuilder/notoolspromptbuilder"
+)
+
+func TestNoToolsPromptBuilderRejectsTools(t *testing.T) {
+	var builder promptbuilder.PromptBuilder = notoolspromptbuilder.New()
+	chatReq := modelClient.ChatRequest{Model: "gpt-4o-mini"}
+
+	err := builder.AddFile(&chatReq, []string{"vs_123"})
+	if !errors.Is(err, promptbuilder.ErrToolsNotSupported) {
+		t.Fatalf("expected ErrToolsNotSupported from AddFile, got: %v", err)
+	}
+	if err := builder.AddWeb(&chatReq, modelClient.WebSearch{Type: "web_search_preview"}); !errors.Is(err, promptbuilder.ErrToolsNotSupported) {
+		t.Fatalf("expected ErrToolsNotSupported from AddWeb, got: %v", err)
+	}
+	if len(chatReq.Tools) != 0 {
+		t.Fatalf("expected no tools on the request, got %d", len(chatReq.Tools))
+	}
+}
+
+func TestChatGPTPromptBuilderAddImage(t *testing.T) {
+	builder := chatgptpromptbuilder.New()
+	chatReq := modelClient.ChatRequest{
+		Input: []modelClient.Message{{Role: "user", Content: "Describe this picture."}},
+	}
+	if err := builder.AddImage(&chatReq, "https://example.com/cat.png"); err != nil {
+		t.Fatalf("AddImage failed: %v", err)
+	}
+	content, ok := chatReq.Input[0].Content.([]map[string]string)
+	if !ok || len(content) != 2 {
+		t.Fatalf("expected text and image blocks, got: %#v", chatReq.Input[0].Content)
+	}
+	if content[1]["type"] != "input_image" || content[1]["image_url"] != "https://example.com/cat.png" {
+		t.Fatalf("unexpected image block: %#v", content[1])
+	}
+}
+
+const temperatureConfigYAML = `
+roles:
+  Writer:
+    name: Writer
+    prompt: You write things.
+globalModes:
+  Summarize: Summarize the input.
+modeTemperatures:
+  Summarize: 0.3
+`
+
+// loadTestConfig writes the YAML configuration to a temporary file and loads it as the global config.
+func loadTestConfig(t *testing.T, yaml string) {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(yaml), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	prov, err := filesys.NewFilesysConfigProvider(configPath)
+	if err != nil {
+		t.Fatalf("NewFilesysConfigProvider failed: %v", err)
+	}
+	config.SetProvider(prov)
+	if err := config.Load(configPath); err != nil {
+		t.Fatalf("config.Load failed: %v", err)
+	}
+}
+
+func TestChatGPTPromptBuilderUsesGivenTemperature(t *testing.T) {
+	loadTestConfig(t, temperatureConfigYAML)
+
+	builder := chatgptpromptbuilder.New()
+	for _, temperature := range []float64{0.2, 1.1} {
+		chatReq, err := builder.Build("Writer", "Summarize", "", "input", nil, temperature, "gpt-4o-mini")
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		if chatReq.Temperature != temperature {
+			t.Errorf("expected temperature %v, got %v", temperature, chatReq.Temperature)
+		}
+	}
+
+	if got := config.GetModeTemperature("Summarize", 0.7); got != 0.3 {
+		t.Errorf("expected the configured Summarize temperature 0.3, got %v", got)
+	}
+	if got := config.GetModeTemperature("Brainstorm", 0.7); got != 0.7 {
+		t.Errorf("expected the fallback temperature for an unconfigured mode, got %v", got)
+	}
+}
+
+func TestChatGPTPromptBuilderRejectsOversizedPrompt(t *testing.T) {
+	loadTestConfig(t, temperatureConfigYAML)
+	builder := chatgptpromptbuilder.New()
+	hotContext := strings.Repeat("The service keeps every order in PostgreSQL. ", 15000)
+
+	if _, err := builder.Build("Writer", "Summarize", hotContext, "input", nil, 0.3, "gpt-4o-mini"); !errors.Is(err, promptbuilder.ErrPromptTooLarge) {
+		t.Fatalf("expected ErrPromptTooLarge for a 128K-token model, got %v", err)
+	}
+	chatReq, err := builder.Build("Writer", "Summarize", hotContext, "input", nil, 0.3, "gpt-4.1-2025-04-14")
+	if err != nil {
+		t.Fatalf("expected the prompt to fit a 1M-token model, got %v", err)
+	}
+	tokens, err := promptbuilder.EstimateTokens(chatReq)
+	if err != nil {
+		t.Fatalf("EstimateTokens failed: %v", err)
+	}
+	if tokens < len(hotContext)/4 {
+		t.Errorf("estimate %d is below the size of the context alone", tokens)
+	}
+}
+
+const projectGoalConfigYAML = `
+projectGoal: "Project: A payments API for small online shops."
+roles:
+  Writer:
+    name: Writer
+    prompt: You write things.
+globalModes:
+  Summarize: Summarize the input.
+`
+
+func TestChatGPTPromptBuilderUsesConfiguredProjectGoal(t *testing.T) {
+	systemText := func() string {
+		chatReq, err := chatgptpromptbuilder.New().Build("Writer", "Summarize", "", "input", nil, 0.5, "gpt-4o-mini")
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		content, ok := chatReq.Input[0].Content.([]map[string]string)
+		if chatReq.Input[0].Role != "system" || !ok || len(content) == 0 {
+			t.Fatalf("expected a system message first, got %#v", chatReq.Input[0])
+		}
+		return content[0]["text"]
+	}
+
+	loadTestConfig(t, projectGoalConfigYAML)
+	if text := systemText(); !strings.Contains(text, "A payments API for small online shops.") {
+		t.Fatalf("expected the configured goal in the system message, got %q", text)
+	}
+
+	loadTestConfig(t, temperatureConfigYAML)
+	if text := systemText(); !strings.Contains(text, config.DefaultProjectGoal) {
+		t.Fatalf("expected the default goal without a configured one, got %q", text)
+	}
+}
@@ -0,0 +1,48 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+)
+
+func TestQAAgentReportsFailingTests(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	fixture := map[string]string{
+		"go.mod":       "module fixture\n\ngo 1.21\n",
+		"calc.go":      "package fixture\n\nfunc Add(a, b int) int { return a - b }\n",
+		"calc_test.go": "package fixture\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(2, 2) != 4 {\n\t\tt.Fatal(\"2+2 != 4\")\n\t}\n}\n",
+	}
+	for name, content := range fixture {
+		if err := os.WriteFile(filepath.Join(repoPath, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	// The fixture's main.go belongs to another package; drop it so the module builds.
+	os.Remove(filepath.Join(repoPath, "main.go"))
+
+	gitClient, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	qa := agent.NewQAAgent(&agent.BaseAgent{Name: "QA", GitClient: gitClient}, "Developer")
+	card := &fakeCard{name: "Implement Add", members: []string{"QA"}}
+
+	passed, output, err := qa.RunTests(card)
+	if err != nil {
+		t.Fatalf("RunTests failed: %v\n%s", err, output)
+	}
+	if passed {
+		t.Fatalf("expected the fixture tests to fail, output:\n%s", output)
+	}
+	if len(card.comments) != 1 || !strings.Contains(card.comments[0], "TestAdd") {
+		t.Fatalf("expected a comment naming the failing test, got: %v", card.comments)
+	}
+	if len(card.members) != 1 || card.members[0] != "Developer" {
+		t.Fatalf("expected the card to be handed back to the developer, got: %v", card.members)
+	}
+}
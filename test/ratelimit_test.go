@@ -0,0 +1,69 @@
+package test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/model/chatgpt"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+	"github.com/egobogo/aiagents/internal/ratelimit"
+)
+
+func TestSharedLimiterSpacesRequests(t *testing.T) {
+	const calls = 5
+	interval := 50 * time.Millisecond
+
+	var mu sync.Mutex
+	var sent []time.Time
+	transport := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		sent = append(sent, time.Now())
+		mu.Unlock()
+		if req.URL.Path == "/v1/responses" {
+			return jsonResponse(http.StatusOK, messageResponse("ok")), nil
+		}
+		return jsonResponse(http.StatusOK, `{"data":[],"has_more":false}`), nil
+	})}
+
+	// 1200 requests per minute is one every 50ms; a burst of 1 spaces every call.
+	limiter := ratelimit.NewLimiter(int(time.Minute/interval), 1)
+	vs := vectorstorage.NewClient("key")
+	vs.HTTPClient = transport
+	vs.Limiter = limiter
+	chat := chatgpt.NewChatGPTClient("key", "gpt-4o-mini", vs)
+	chat.HTTPClient = transport
+	chat.Limiter = limiter
+
+	for i := 0; i < calls; i++ {
+		var err error
+		if i%2 == 0 {
+			_, err = chat.Chat("hello")
+		} else {
+			_, err = vs.ListStorages()
+		}
+		if err != nil {
+			t.Fatalf("call %d failed: %v", i, err)
+		}
+	}
+
+	if len(sent) != calls {
+		t.Fatalf("expected %d requests, got %d", calls, len(sent))
+	}
+	// Allow a little slack for timer granularity.
+	minGap := interval - 5*time.Millisecond
+	for i := 1; i < len(sent); i++ {
+		if gap := sent[i].Sub(sent[i-1]); gap < minGap {
+			t.Errorf("requests %d and %d were %v apart, want at least %v", i-1, i, gap, interval)
+		}
+	}
+}
+
+func TestNilLimiterLeavesClientUnchanged(t *testing.T) {
+	var limiter *ratelimit.Limiter
+	client := &http.Client{}
+	if got := limiter.Wrap(client); got != client {
+		t.Fatal("expected a nil limiter to return the client as is")
+	}
+}
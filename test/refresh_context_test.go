@@ -0,0 +1,254 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/docs"
+	"github.com/egobogo/aiagents/internal/gitrepo"
+	"github.com/egobogo/aiagents/internal/httputil"
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+)
+
+// initLocalRepo creates a git repository with a single committed Go file and returns its path.
+func initLocalRepo(t *testing.T) string {
+	t.Helper()
+	repoPath := t.TempDir()
+	repo, err := git.PlainInit(repoPath, false)
+	if err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoPath, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree failed: %v", err)
+	}
+	if _, err := worktree.Add("main.go"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := worktree.Commit("initial", &git.CommitOptions{
+		Author: &object.Signature{Name: "tester", Email: "tester@example.com", When: time.Now()},
+	}); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	return repoPath
+}
+
+func TestRefreshContextOnlyResummarizesChangedDocs(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gitClient, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	head, err := gitClient.HeadCommit()
+	if err != nil {
+		t.Fatalf("HeadCommit failed: %v", err)
+	}
+
+	seen := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	docsClient := &fakeDocsClient{pages: []docs.Page{
+		{ID: "unchanged", Title: "Architecture", Content: "unchanged architecture notes", LastEdited: seen},
+		{ID: "changed", Title: "Roadmap", Content: "roadmap was rewritten", LastEdited: seen.Add(time.Hour)},
+	}}
+
+	// Persist a state where both pages and the current HEAD were already ingested.
+	stateDir := t.TempDir()
+	state, _ := json.Marshal(map[string]interface{}{
+		"last_commit": head,
+		"doc_edits":   map[string]time.Time{"unchanged": seen, "changed": seen},
+	})
+	if err := os.WriteFile(filepath.Join(stateDir, "context_refresh_state.json"), state, 0644); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+
+	builder := &fakePromptBuilder{}
+	em := &agent.EngineeringManagerAgent{BaseAgent: &agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   &fakeModelClient{parsed: `{"result":[{"category":"Docs","content":"roadmap","importance":5}]}`, text: "context"},
+		DocsClient:    docsClient,
+		GitClient:     gitClient,
+		Context:       &fakeContextStorage{},
+		PromptBuilder: builder,
+		StateDir:      stateDir,
+	}}
+
+	if err := em.RefreshContext(); err != nil {
+		t.Fatalf("RefreshContext failed: %v", err)
+	}
+
+	summaries := builder.callsWithMode("Summarize")
+	if len(summaries) != 1 {
+		t.Fatalf("expected exactly one summarization, got %d", len(summaries))
+	}
+	if !strings.Contains(summaries[0].UserInput, "roadmap was rewritten") {
+		t.Fatalf("expected the changed doc to be summarized, got: %s", summaries[0].UserInput)
+	}
+	if strings.Contains(summaries[0].UserInput, "unchanged architecture notes") {
+		t.Fatalf("unchanged doc was re-summarized: %s", summaries[0].UserInput)
+	}
+
+	// A second refresh with nothing changed should not summarize anything.
+	if err := em.RefreshContext(); err != nil {
+		t.Fatalf("second RefreshContext failed: %v", err)
+	}
+	if n := len(builder.callsWithMode("Summarize")); n != 1 {
+		t.Fatalf("expected no new summarization on an unchanged refresh, got %d total", n)
+	}
+}
+
+func TestRefreshContextSendsChangedFilesInlineWithoutVectorStore(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gitClient, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	head, err := gitClient.HeadCommit()
+	if err != nil {
+		t.Fatalf("HeadCommit failed: %v", err)
+	}
+
+	// Commit a new file after the recorded HEAD.
+	if err := os.WriteFile(filepath.Join(repoPath, "billing.go"), []byte("package main\n\nfunc ChargeCustomer() {}\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	worktree, err := gitClient.Repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree failed: %v", err)
+	}
+	if _, err := worktree.Add("billing.go"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := worktree.Commit("add billing", &git.CommitOptions{
+		Author: &object.Signature{Name: "tester", Email: "tester@example.com", When: time.Now()},
+	}); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	stateDir := t.TempDir()
+	state, _ := json.Marshal(map[string]interface{}{"last_commit": head, "doc_edits": map[string]time.Time{}})
+	if err := os.WriteFile(filepath.Join(stateDir, "context_refresh_state.json"), state, 0644); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+
+	builder := &fakePromptBuilder{}
+	em := &agent.EngineeringManagerAgent{BaseAgent: &agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   &fakeModelClient{parsed: `{"result":[{"category":"Code","content":"billing","importance":5}]}`, text: "context"},
+		DocsClient:    &fakeDocsClient{},
+		GitClient:     gitClient,
+		Context:       &fakeContextStorage{},
+		PromptBuilder: builder,
+		StateDir:      stateDir,
+	}}
+
+	if err := em.RefreshContext(); err != nil {
+		t.Fatalf("RefreshContext without a vector store failed: %v", err)
+	}
+	summaries := builder.callsWithMode("Summarize")
+	if len(summaries) != 1 {
+		t.Fatalf("expected one summarization of the changed files, got %d", len(summaries))
+	}
+	if !strings.Contains(summaries[0].UserInput, "ChargeCustomer") || strings.Contains(summaries[0].UserInput, `"path":"main.go"`) {
+		t.Fatalf("expected only the changed file inline, got: %s", summaries[0].UserInput)
+	}
+}
+
+// rejectingUploadClient is a fakeModelClient whose uploads of files ending in reject fail.
+type rejectingUploadClient struct {
+	*fakeModelClient
+	reject string
+}
+
+func (c *rejectingUploadClient) UploadFile(filePath string, purpose string) (model.File, error) {
+	if strings.HasSuffix(filePath, c.reject) {
+		return model.File{}, fmt.Errorf("upload rejected")
+	}
+	return c.fakeModelClient.UploadFile(filePath, purpose)
+}
+
+func TestRefreshContextKeepsPartiallySyncedFiles(t *testing.T) {
+	repoPath := initLocalRepo(t)
+	gitClient, err := gitrepo.NewGitClient("", repoPath)
+	if err != nil {
+		t.Fatalf("NewGitClient failed: %v", err)
+	}
+	head, err := gitClient.HeadCommit()
+	if err != nil {
+		t.Fatalf("HeadCommit failed: %v", err)
+	}
+	for _, name := range []string{"billing.go", "broken.go"} {
+		if err := gitClient.WriteFile(name, []byte("package main\n")); err != nil {
+			t.Fatalf("WriteFile failed: %v", err)
+		}
+	}
+	if err := gitClient.CommitChanges("add billing", "tester", "tester@example.com"); err != nil {
+		t.Fatalf("CommitChanges failed: %v", err)
+	}
+
+	stateDir := t.TempDir()
+	state, _ := json.Marshal(map[string]interface{}{"last_commit": head, "doc_edits": map[string]time.Time{}})
+	if err := os.WriteFile(filepath.Join(stateDir, "context_refresh_state.json"), state, 0644); err != nil {
+		t.Fatalf("failed to write state: %v", err)
+	}
+
+	var attached []string
+	vsClient := vectorstorage.NewClient("test-key")
+	vsClient.Retry = httputil.RetryPolicy{}
+	vsClient.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v1/vector_stores":
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"vs_1","name":"aiagents"}],"has_more":false}`), nil
+		case req.Method == "GET" && req.URL.Path == "/v1/vector_stores/vs_1/files":
+			var data []string
+			for _, id := range attached {
+				data = append(data, fmt.Sprintf(`{"id":%q}`, id))
+			}
+			return jsonResponse(http.StatusOK, `{"data":[`+strings.Join(data, ",")+`],"has_more":false}`), nil
+		case req.Method == "POST" && req.URL.Path == "/v1/vector_stores/vs_1/files":
+			var id string
+			fmt.Sscanf(readBody(t, req), `{"file_id":%q}`, &id)
+			attached = append(attached, id)
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"id":%q}`, id)), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	builder := &fakePromptBuilder{}
+	em := &agent.EngineeringManagerAgent{BaseAgent: &agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   &rejectingUploadClient{fakeModelClient: &fakeModelClient{parsed: `{"result":[{"category":"Code","content":"billing","importance":5}]}`, text: "context"}, reject: "broken.go"},
+		DocsClient:    &fakeDocsClient{},
+		GitClient:     gitClient,
+		Context:       &fakeContextStorage{},
+		PromptBuilder: builder,
+		VectorStorage: vsClient,
+		StateDir:      stateDir,
+	}}
+
+	if err := em.RefreshContext(); err != nil {
+		t.Fatalf("RefreshContext should keep the files that synced, got %v", err)
+	}
+	if len(attached) != 1 || !strings.Contains(attached[0], "billing.go") {
+		t.Fatalf("expected billing.go to be attached, got %v", attached)
+	}
+	if n := len(builder.callsWithMode("Summarize")); n != 1 {
+		t.Fatalf("expected the synced file to be summarized, got %d summarizations", n)
+	}
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/roles"
+)
+
+const rolesConfigYAML = `
+roles:
+  Designer:
+    name: Designer
+    prompt: You design for the configured brand.
+    actions:
+      - id: design
+        name: Design
+        mode: Design
+        prompt: Design with the configured palette.
+globalModes:
+  Design: Produce a design spec.
+  Summarize: Summarize the input.
+`
+
+func TestRolesConfigOverridesDefault(t *testing.T) {
+	loadTestConfig(t, rolesConfigYAML)
+
+	designer, err := roles.Get("Designer")
+	if err != nil {
+		t.Fatalf("Get(Designer) failed: %v", err)
+	}
+	if designer.Prompt != "You design for the configured brand." {
+		t.Errorf("expected the configured prompt, got %q", designer.Prompt)
+	}
+	if prompt, err := roles.ModePrompt(designer, "Design"); err != nil || prompt != "Design with the configured palette." {
+		t.Errorf("expected the role's action prompt, got %q, %v", prompt, err)
+	}
+	if prompt, err := roles.ModePrompt(designer, "Summarize"); err != nil || prompt != "Summarize the input." {
+		t.Errorf("expected the global mode prompt, got %q, %v", prompt, err)
+	}
+
+	qa, err := roles.Get("QA")
+	if err != nil {
+		t.Fatalf("Get(QA) failed: %v", err)
+	}
+	if !strings.Contains(qa.Prompt, "QA engineer") {
+		t.Errorf("expected the built-in QA prompt, got %q", qa.Prompt)
+	}
+
+	if _, err := roles.Get("Astronaut"); !errors.Is(err, roles.ErrUnknownRole) {
+		t.Errorf("expected ErrUnknownRole, got %v", err)
+	}
+}
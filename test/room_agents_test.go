@@ -0,0 +1,59 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/room"
+	"github.com/egobogo/aiagents/internal/room/inmemory"
+)
+
+func newRoomAgent(name, reply string) (*agent.BaseAgent, *fakePromptBuilder) {
+	pb := &fakePromptBuilder{}
+	return &agent.BaseAgent{
+		Name:          name,
+		Role:          name,
+		ModelClient:   &fakeModelClient{text: reply, parsed: `{"result":[]}`},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: pb,
+	}, pb
+}
+
+func TestAgentsAnswerEachOtherInRoom(t *testing.T) {
+	manager, _ := newRoomAgent("Manager", "manager reply")
+	developer, devPrompts := newRoomAgent("Developer", "Use PostgreSQL.")
+
+	r := inmemory.NewInMemoryRoom()
+	if err := r.EnterRoom(room.AgentInfo{Name: manager.Name, Role: manager.Role}, room.ParticipantFunc(manager.AnswerMessages)); err != nil {
+		t.Fatalf("EnterRoom failed: %v", err)
+	}
+	if err := r.EnterRoom(room.AgentInfo{Name: developer.Name, Role: developer.Role}, room.ParticipantFunc(developer.AnswerMessages)); err != nil {
+		t.Fatalf("EnterRoom failed: %v", err)
+	}
+
+	question := []modelClient.Message{
+		{Role: "assistant", Content: "We are building the billing service."},
+		{Role: "user", Content: []interface{}{map[string]interface{}{"type": "input_text", "text": "Which database should we use?"}}},
+	}
+	answer, err := r.Ask(manager.Name, developer.Name, question)
+	if err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+	if len(answer) != 1 || answer[0].Role != "assistant" || answer[0].Content != "Use PostgreSQL." {
+		t.Fatalf("unexpected answer: %+v", answer)
+	}
+
+	calls := devPrompts.callsWithMode("Answer")
+	if len(calls) != 1 {
+		t.Fatalf("expected one Answer prompt, got %d", len(calls))
+	}
+	if !strings.Contains(calls[0].UserInput, "Which database should we use?") {
+		t.Errorf("question missing from prompt: %q", calls[0].UserInput)
+	}
+
+	if _, err := r.Ask(developer.Name, manager.Name, []modelClient.Message{{Role: "assistant", Content: "no question"}}); err == nil {
+		t.Fatalf("expected an error when there is no user message")
+	}
+}
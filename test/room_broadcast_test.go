@@ -0,0 +1,64 @@
+package test
+
+import (
+	"testing"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/room"
+	"github.com/egobogo/aiagents/internal/room/inmemory"
+)
+
+// echoParticipant answers with its own name.
+func echoParticipant(name string) room.Participant {
+	return room.ParticipantFunc(func(question []modelClient.Message) ([]modelClient.Message, error) {
+		return []modelClient.Message{{Role: "assistant", Content: name}}, nil
+	})
+}
+
+func TestRoomRoleAndTopicBroadcasts(t *testing.T) {
+	r := inmemory.NewInMemoryRoom()
+	agents := []room.AgentInfo{
+		{Name: "pm", Role: "ProductManager"},
+		{Name: "dev1", Role: "Developer"},
+		{Name: "dev2", Role: "developer"},
+		{Name: "designer", Role: "Designer"},
+	}
+	for _, info := range agents {
+		if err := r.EnterRoom(info, echoParticipant(info.Name)); err != nil {
+			t.Fatalf("EnterRoom failed: %v", err)
+		}
+	}
+	question := []modelClient.Message{{Role: "user", Content: "Is the spec clear?"}}
+
+	answers, err := r.ShoutToRole("pm", "Developer", question)
+	if err != nil {
+		t.Fatalf("ShoutToRole failed: %v", err)
+	}
+	if len(answers) != 2 || answers["dev1"] == nil || answers["dev2"] == nil {
+		t.Fatalf("expected only developers to answer, got %v", answers)
+	}
+
+	for _, name := range []string{"dev1", "designer"} {
+		if err := r.Subscribe(name, "checkout-flow"); err != nil {
+			t.Fatalf("Subscribe failed: %v", err)
+		}
+	}
+	if err := r.Subscribe("ghost", "checkout-flow"); err == nil {
+		t.Fatalf("expected subscribing an unknown agent to fail")
+	}
+	answers, _ = r.ShoutToTopic("pm", "checkout-flow", question)
+	if len(answers) != 2 || answers["dev1"] == nil || answers["designer"] == nil {
+		t.Fatalf("expected topic subscribers to answer, got %v", answers)
+	}
+
+	if err := r.Unsubscribe("designer", "checkout-flow"); err != nil {
+		t.Fatalf("Unsubscribe failed: %v", err)
+	}
+	if err := r.LeaveRoom("dev1"); err != nil {
+		t.Fatalf("LeaveRoom failed: %v", err)
+	}
+	answers, _ = r.ShoutToTopic("pm", "checkout-flow", question)
+	if len(answers) != 0 {
+		t.Fatalf("expected no subscribers left, got %v", answers)
+	}
+}
@@ -0,0 +1,61 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/room"
+	"github.com/egobogo/aiagents/internal/room/inmemory"
+)
+
+func TestTranscriptRoomRecordsAsks(t *testing.T) {
+	var log bytes.Buffer
+	r := room.NewTranscriptRoom(inmemory.NewInMemoryRoom(), &log)
+	for _, info := range []room.AgentInfo{{Name: "pm", Role: "ProductManager"}, {Name: "dev", Role: "Developer"}} {
+		if err := r.EnterRoom(info, echoParticipant(info.Name)); err != nil {
+			t.Fatalf("EnterRoom failed: %v", err)
+		}
+	}
+
+	first := []modelClient.Message{{Role: "user", Content: "Estimate the login ticket"}}
+	second := []modelClient.Message{{Role: "user", Content: "Is the API spec final?"}}
+	if _, err := r.Ask("pm", "dev", first); err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+	if _, err := r.Ask("dev", "pm", second); err != nil {
+		t.Fatalf("Ask failed: %v", err)
+	}
+
+	transcript := r.Transcript()
+	if len(transcript) != 2 {
+		t.Fatalf("expected 2 exchanges, got %d", len(transcript))
+	}
+	if transcript[0].From != "pm" || transcript[0].To != "dev" || transcript[0].Question[0].Content != "Estimate the login ticket" {
+		t.Errorf("unexpected first exchange: %+v", transcript[0])
+	}
+	if transcript[1].From != "dev" || transcript[1].To != "pm" || transcript[1].Answer[0].Content != "pm" {
+		t.Errorf("unexpected second exchange: %+v", transcript[1])
+	}
+	if transcript[1].Time.Before(transcript[0].Time) {
+		t.Errorf("exchanges are out of order")
+	}
+
+	lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 JSON lines, got %d", len(lines))
+	}
+	var ex room.Exchange
+	if err := json.Unmarshal([]byte(lines[0]), &ex); err != nil || ex.Via != "ask" || ex.To != "dev" {
+		t.Fatalf("unexpected JSON line %q (err %v)", lines[0], err)
+	}
+
+	if _, err := r.Ask("pm", "ghost", first); err == nil {
+		t.Fatalf("expected asking an unknown agent to fail")
+	}
+	if got := r.Transcript(); len(got) != 3 || got[2].Error == "" {
+		t.Fatalf("expected the failed ask to be recorded with its error, got %+v", got)
+	}
+}
@@ -0,0 +1,52 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/notify/slack"
+)
+
+func TestSlackNotifierPostsWebhookPayload(t *testing.T) {
+	var payload map[string]interface{}
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	notifier := slack.NewSlackNotifier(server.URL)
+	notifier.Username = "aiagents"
+	if err := notifier.Notify("#dev", "Ticket closed"); err != nil {
+		t.Fatalf("Notify failed: %v", err)
+	}
+	if contentType != "application/json" {
+		t.Errorf("expected JSON content type, got %q", contentType)
+	}
+	want := map[string]interface{}{"text": "Ticket closed", "channel": "#dev", "username": "aiagents"}
+	if len(payload) != len(want) {
+		t.Fatalf("unexpected payload: %v", payload)
+	}
+	for k, v := range want {
+		if payload[k] != v {
+			t.Errorf("expected %s=%v, got %v", k, v, payload[k])
+		}
+	}
+}
+
+func TestSlackNotifierReportsFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if err := slack.NewSlackNotifier(server.URL).Notify("", "hello"); err == nil {
+		t.Fatalf("expected an error for a rejected webhook")
+	}
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/context/summarizing"
+)
+
+func TestSummarizingContextCompressesOversizedContext(t *testing.T) {
+	inner := &fakeContextStorage{}
+	model := &fakeModelClient{text: "Decisions: use PostgreSQL; ship login first."}
+	storage := summarizing.New(inner, model, 50)
+
+	if err := storage.SetContext("short context"); err != nil {
+		t.Fatalf("SetContext failed: %v", err)
+	}
+	if len(model.requests) != 0 || inner.GetContext() != "short context" {
+		t.Fatalf("expected a small context to be stored as is without model calls")
+	}
+
+	oversized := strings.Repeat("We discussed the database choice again at length. ", 20)
+	if err := storage.SetContext(oversized); err != nil {
+		t.Fatalf("SetContext failed: %v", err)
+	}
+	if len(model.requests) != 1 {
+		t.Fatalf("expected exactly one summarization call, got %d", len(model.requests))
+	}
+	if got := storage.GetContext(); got != model.text {
+		t.Fatalf("expected the summary to be stored, got %q", got)
+	}
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/egobogo/aiagents/internal/testutil"
+)
+
+func TestGitRemotePushVisibleInSecondClone(t *testing.T) {
+	remote := testutil.NewGitRemote(t)
+	if _, err := os.Stat(filepath.Join(remote.ClonePath, testutil.SeedFile)); err != nil {
+		t.Fatalf("expected the seed file in the clone: %v", err)
+	}
+
+	repo, err := git.PlainOpen(remote.ClonePath)
+	if err != nil {
+		t.Fatalf("PlainOpen failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(remote.ClonePath, "feature.txt"), []byte("shipped\n"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree failed: %v", err)
+	}
+	if _, err := worktree.Add("feature.txt"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if _, err := worktree.Commit("add feature", &git.CommitOptions{
+		Author: &object.Signature{Name: "tester", Email: "tester@example.com", When: time.Now()},
+	}); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if err := repo.Push(&git.PushOptions{}); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	second := remote.Clone(t)
+	content, err := os.ReadFile(filepath.Join(second, "feature.txt"))
+	if err != nil || string(content) != "shipped\n" {
+		t.Fatalf("expected the pushed file in a second clone, got %q, %v", content, err)
+	}
+}
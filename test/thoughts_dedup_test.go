@@ -0,0 +1,64 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+)
+
+func TestThinkStoresOnlyDistinctThoughts(t *testing.T) {
+	storage := &fakeContextStorage{}
+	a := &agent.BaseAgent{
+		Name: "Developer",
+		Role: "Developer",
+		// Both CreateThoughts passes receive the same batch, so the second pass adds nothing new.
+		ModelClient: &fakeModelClient{text: "ok", parsed: `{"result":[
+			{"category":"Architecture","content":"Use PostgreSQL for persistence.","importance":2},
+			{"category":"Architecture","content":"use postgresql  for persistence","importance":4},
+			{"category":"Ops","content":"Deploy with Helm charts.","importance":3},
+			{"category":"Architecture","content":"PostgreSQL is the persistence layer.","importance":1}
+		]}`},
+		Context:       storage,
+		PromptBuilder: &fakePromptBuilder{},
+		Embeddings: fakeEmbeddings{
+			"Use PostgreSQL for persistence.":      {1, 0},
+			"Deploy with Helm charts.":             {0, 1},
+			"PostgreSQL is the persistence layer.": {0.99, 0.05},
+		},
+	}
+
+	if _, err := a.Answer("", "Which database?", nil); err != nil {
+		t.Fatalf("Answer failed: %v", err)
+	}
+
+	stored := storage.GetMemories()
+	if len(stored) != 2 {
+		t.Fatalf("expected 2 distinct memories, got %d: %+v", len(stored), stored)
+	}
+	if stored[0].Content != "Use PostgreSQL for persistence." || stored[0].Importance != 4 {
+		t.Errorf("expected the merged PostgreSQL memory first with importance 4, got %+v", stored[0])
+	}
+	if stored[1].Content != "Deploy with Helm charts." {
+		t.Errorf("expected the Helm memory second, got %+v", stored[1])
+	}
+}
+
+func TestCreateThoughtsDedupesWithoutEmbeddings(t *testing.T) {
+	a := &agent.BaseAgent{
+		Role: "Developer",
+		ModelClient: &fakeModelClient{parsed: `{"result":[
+			{"category":"A","content":"Cache reads.","importance":1},
+			{"category":"A","content":"cache READS","importance":1},
+			{"category":"B","content":"Log every request.","importance":5}
+		]}`},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: &fakePromptBuilder{},
+	}
+	thoughts, err := a.CreateThoughts("input", nil, nil, "")
+	if err != nil {
+		t.Fatalf("CreateThoughts failed: %v", err)
+	}
+	if len(thoughts) != 2 || thoughts[0].Content != "Log every request." || thoughts[1].Content != "Cache reads." {
+		t.Fatalf("unexpected thoughts: %+v", thoughts)
+	}
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/board"
+)
+
+func TestIngestTicketSpecUsesTextAttachment(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("# Checkout\nPayments must be processed through Stripe."))
+	}))
+	defer srv.Close()
+
+	card := &fakeCard{
+		name:    "Build checkout",
+		members: []string{"EngineeringManager"},
+		attached: []board.Attachment{
+			{ID: "a1", Name: "spec.md", URL: srv.URL + "/spec.md"},
+			{ID: "a2", Name: "mockup.sketch", URL: srv.URL + "/mockup.sketch"},
+			{ID: "a3", Name: "flows.pdf", URL: srv.URL + "/flows.pdf"},
+		},
+	}
+	builder := &fakePromptBuilder{}
+	storage := &fakeContextStorage{}
+	em := &agent.EngineeringManagerAgent{BaseAgent: &agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   &fakeModelClient{parsed: `{"result":[{"content":"Checkout uses Stripe","importance":8}]}`},
+		Context:       storage,
+		PromptBuilder: builder,
+	}}
+
+	thoughts, err := em.IngestTicketSpec(card)
+	if err != nil {
+		t.Fatalf("IngestTicketSpec failed: %v", err)
+	}
+	if len(thoughts) != 1 {
+		t.Fatalf("expected one thought, got %v", thoughts)
+	}
+	if len(builder.calls) != 1 {
+		t.Fatalf("expected one thoughts request, got %d", len(builder.calls))
+	}
+	input := builder.calls[0].UserInput
+	if !strings.Contains(input, "Payments must be processed through Stripe.") {
+		t.Errorf("attachment content missing from the thoughts request: %q", input)
+	}
+	if strings.Contains(input, "mockup.sketch") {
+		t.Errorf("unsupported attachment should be skipped: %q", input)
+	}
+	// Without a vector store the PDF cannot be searched, so the prompt must not offer it.
+	if strings.Contains(input, "flows.pdf") || strings.Contains(input, "file search") {
+		t.Errorf("PDF should be skipped without a vector store: %q", input)
+	}
+}
+
+func TestIngestTicketSpecWithoutAttachments(t *testing.T) {
+	builder := &fakePromptBuilder{}
+	em := &agent.EngineeringManagerAgent{BaseAgent: &agent.BaseAgent{
+		Name:          "EngineeringManager",
+		Role:          "EngineeringManager",
+		ModelClient:   &fakeModelClient{},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: builder,
+	}}
+	thoughts, err := em.IngestTicketSpec(&fakeCard{name: "No spec"})
+	if err != nil {
+		t.Fatalf("IngestTicketSpec failed: %v", err)
+	}
+	if len(thoughts) != 0 || len(builder.calls) != 0 {
+		t.Errorf("expected no thoughts request, got %d calls", len(builder.calls))
+	}
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+)
+
+func TestTrelloGetCardsAssignedTo(t *testing.T) {
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1/members"):
+			return jsonResponse(http.StatusOK, `[{"id":"m_dev","username":"dev","fullName":"Developer"},{"id":"m_qa","username":"qa","fullName":"QA"}]`), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1/lists"):
+			return jsonResponse(http.StatusOK, `[{"id":"list_todo","name":"To Do"},{"id":"list_doing","name":"In Progress"}]`), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1/cards") && req.URL.Query().Get("before") != "":
+			return jsonResponse(http.StatusOK, `[]`), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1/cards"):
+			return jsonResponse(http.StatusOK, `[
+				{"id":"card1","name":"Fix login","idList":"list_doing","idMembers":["m_dev"]},
+				{"id":"card2","name":"Write tests","idList":"list_todo","idMembers":["m_qa"]},
+				{"id":"card3","name":"Pair on release","idList":"list_todo","idMembers":["m_qa","m_dev"]}
+			]`), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1"):
+			return jsonResponse(http.StatusOK, `{"id":"board1","name":"Board"}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	cards, err := tc.GetCardsAssignedTo("Developer")
+	if err != nil {
+		t.Fatalf("GetCardsAssignedTo failed: %v", err)
+	}
+	if len(cards) != 2 || cards[0].GetName() != "Fix login" || cards[1].GetName() != "Pair on release" {
+		t.Fatalf("unexpected cards assigned to Developer: %v", cards)
+	}
+	list, err := cards[0].GetList()
+	if err != nil || list.GetName() != "In Progress" {
+		t.Fatalf("expected the card's list to be set, got %v (err %v)", list, err)
+	}
+	// The returned cards are wired to the client, so member lookups work on them.
+	members, err := cards[1].(*trelloClient.TrelloCard).BoardClient.GetMembers()
+	if err != nil || len(members) != 2 {
+		t.Fatalf("expected the card to reach the board client, got %v (err %v)", members, err)
+	}
+
+	if cards, err := tc.GetCardsAssignedTo("nobody"); err != nil || len(cards) != 0 {
+		t.Fatalf("expected no cards for an unknown member, got %v (err %v)", cards, err)
+	}
+}
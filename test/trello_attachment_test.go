@@ -0,0 +1,59 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/board"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+)
+
+func TestTrelloAddAttachmentEscapesQuery(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.URL.Path != "/cards/card1/attachments" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		q := r.URL.Query()
+		if got := q.Get("name"); got != "Specs & notes v2.pdf" {
+			t.Errorf("expected the name to survive escaping, got %q", got)
+		}
+		if got := q.Get("url"); got != "https://example.com/files/spec v2.pdf?a=1&b=2" {
+			t.Errorf("expected the URL to survive escaping, got %q", got)
+		}
+		if got := q.Get("mimeType"); got != "application/pdf" {
+			t.Errorf("expected the PDF MIME type, got %q", got)
+		}
+		if q.Get("key") != "key" || q.Get("token") != "token" || len(q) != 5 {
+			t.Errorf("unexpected query parameters: %v", q)
+		}
+		w.Write([]byte(`{"id":"att1"}`))
+	}))
+	defer server.Close()
+
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	tc.Client.BaseURL = server.URL
+	card := &trelloClient.TrelloCard{ID: "card1", CardName: "Card", BoardClient: tc, Client: tc.Client}
+
+	err := card.AddAttachment(board.Attachment{Name: "Specs & notes v2.pdf", URL: "https://example.com/files/spec v2.pdf?a=1&b=2"})
+	if err != nil {
+		t.Fatalf("AddAttachment failed: %v", err)
+	}
+
+	for _, att := range []board.Attachment{
+		{Name: "no url"},
+		{Name: "relative", URL: "files/spec.pdf"},
+		{Name: strings.Repeat("x", trelloClient.DefaultMaxAttachmentNameLength+1), URL: "https://example.com/a.txt"},
+	} {
+		if err := card.AddAttachment(att); !errors.Is(err, trelloClient.ErrInvalidAttachment) {
+			t.Errorf("expected ErrInvalidAttachment for %q, got %v", att.URL, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected only the valid attachment to be sent, got %d requests", requests)
+	}
+}
@@ -0,0 +1,93 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+)
+
+// trelloCommentActions returns n comment actions, newest first as Trello does, with IDs act_<n-1> .. act_0.
+func trelloCommentActions(n int, start time.Time) []map[string]interface{} {
+	actions := make([]map[string]interface{}, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		actions = append(actions, map[string]interface{}{
+			"id":            fmt.Sprintf("act_%d", i),
+			"type":          "commentCard",
+			"date":          start.Add(time.Duration(i) * time.Minute),
+			"data":          map[string]interface{}{"text": fmt.Sprintf("comment %d", i)},
+			"memberCreator": map[string]string{"id": "m1", "fullName": "Developer"},
+		})
+	}
+	return actions
+}
+
+func TestTrelloReadCommentsPaginates(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	all := trelloCommentActions(1002, start)
+	var sinceParams []string
+
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		path := req.URL.Path
+		switch {
+		case strings.HasSuffix(path, "/cards/card1/actions"):
+			q := req.URL.Query()
+			if q.Get("filter") != "commentCard" {
+				t.Fatalf("unexpected filter %q", q.Get("filter"))
+			}
+			sinceParams = append(sinceParams, q.Get("since"))
+			limit, _ := strconv.Atoi(q.Get("limit"))
+			from := 0
+			if before := q.Get("before"); before != "" {
+				for i, a := range all {
+					if a["id"] == before {
+						from = i + 1
+					}
+				}
+			}
+			page := all[from:min(from+limit, len(all))]
+			body, _ := json.Marshal(page)
+			return jsonResponse(http.StatusOK, string(body)), nil
+		case strings.HasSuffix(path, "/cards/card1"):
+			return jsonResponse(http.StatusOK, `{"id":"card1","name":"Long-lived","idBoard":"board1","idList":"list1"}`), nil
+		case strings.HasSuffix(path, "/lists/list1"):
+			return jsonResponse(http.StatusOK, `{"id":"list1","name":"Doing"}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	card, err := tc.GetCard("card1")
+	if err != nil {
+		t.Fatalf("GetCard failed: %v", err)
+	}
+	comments, err := card.ReadComments()
+	if err != nil {
+		t.Fatalf("ReadComments failed: %v", err)
+	}
+	if len(comments) != 1002 {
+		t.Fatalf("expected all 1002 comments across pages, got %d", len(comments))
+	}
+	if comments[0].Text != "comment 0" || comments[1001].Text != "comment 1001" {
+		t.Errorf("expected chronological order, got first %q and last %q", comments[0].Text, comments[1001].Text)
+	}
+	if comments[0].Member == nil || comments[0].Member.Name != "Developer" {
+		t.Errorf("expected the comment author, got %+v", comments[0].Member)
+	}
+
+	sinceParams = nil
+	trelloCard := card.(*trelloClient.TrelloCard)
+	since := start.Add(time.Hour)
+	if _, err := trelloCard.ReadCommentsSince(since); err != nil {
+		t.Fatalf("ReadCommentsSince failed: %v", err)
+	}
+	if len(sinceParams) == 0 || sinceParams[0] != since.Format(time.RFC3339Nano) {
+		t.Errorf("expected the since parameter %s, got %v", since.Format(time.RFC3339Nano), sinceParams)
+	}
+}
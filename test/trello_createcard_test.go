@@ -0,0 +1,66 @@
+package test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/board"
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+)
+
+func TestTrelloCreateCardDetailed(t *testing.T) {
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	var created url.Values
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1"):
+			return jsonResponse(http.StatusOK, `{"id":"board1","name":"Board"}`), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1/lists"):
+			return jsonResponse(http.StatusOK, `[{"id":"list_todo","name":"To Do"},{"id":"list_done","name":"Done"}]`), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1/members"):
+			return jsonResponse(http.StatusOK, `[{"id":"m_dev","username":"dev","fullName":"Developer"},{"id":"m_qa","username":"qa","fullName":"QA"}]`), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1/labels"):
+			return jsonResponse(http.StatusOK, `[{"id":"l_bug","name":"Bug","color":"red"},{"id":"l_green","name":"","color":"green"}]`), nil
+		case req.Method == "POST" && strings.HasSuffix(req.URL.Path, "/cards"):
+			created = req.URL.Query()
+			return jsonResponse(http.StatusOK, `{"id":"card1","name":"Fix login","shortUrl":"https://trello.com/c/card1"}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	due := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	card, err := tc.CreateCardDetailed(board.CardSpec{
+		Name:        "Fix login",
+		Description: "Users cannot log in",
+		ListName:    "to do",
+		Assignees:   []string{"Developer", "qa"},
+		Labels:      []string{"bug", "green"},
+		DueDate:     &due,
+		Position:    "top",
+	})
+	if err != nil {
+		t.Fatalf("CreateCardDetailed failed: %v", err)
+	}
+	if card.GetName() != "Fix login" || card.GetURL() != "https://trello.com/c/card1" {
+		t.Fatalf("unexpected card: %s %s", card.GetName(), card.GetURL())
+	}
+
+	want := map[string]string{
+		"name":      "Fix login",
+		"desc":      "Users cannot log in",
+		"idList":    "list_todo",
+		"idMembers": "m_dev,m_qa",
+		"idLabels":  "l_bug,l_green",
+		"due":       "2026-11-01T12:00:00Z",
+		"pos":       "top",
+	}
+	for key, value := range want {
+		if got := created.Get(key); got != value {
+			t.Errorf("expected %s=%q, got %q", key, value, got)
+		}
+	}
+}
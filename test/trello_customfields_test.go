@@ -0,0 +1,49 @@
+package test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+)
+
+func TestTrelloCardCustomFields(t *testing.T) {
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	var putBody string
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1/customFields"):
+			return jsonResponse(http.StatusOK, `[
+				{"id":"cf_prio","name":"Priority","type":"list","options":[{"id":"opt_high","value":{"text":"High"}},{"id":"opt_low","value":{"text":"Low"}}]},
+				{"id":"cf_points","name":"Story Points","type":"number"}
+			]`), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/cards/card1/customFieldItems"):
+			return jsonResponse(http.StatusOK, `[
+				{"idCustomField":"cf_prio","idValue":"opt_high"},
+				{"idCustomField":"cf_points","value":{"number":"5"}}
+			]`), nil
+		case req.Method == "PUT" && strings.HasSuffix(req.URL.Path, "/cards/card1/customField/cf_prio/item"):
+			putBody = readBody(t, req)
+			return jsonResponse(http.StatusOK, `{}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+	card := &trelloClient.TrelloCard{ID: "card1", BoardClient: tc, Client: tc.Client}
+
+	fields, err := card.GetCustomFields()
+	if err != nil {
+		t.Fatalf("GetCustomFields failed: %v", err)
+	}
+	if fields["Priority"] != "High" || fields["Story Points"] != "5" {
+		t.Fatalf("unexpected custom fields: %v", fields)
+	}
+
+	if err := card.SetCustomField("priority", "Low"); err != nil {
+		t.Fatalf("SetCustomField failed: %v", err)
+	}
+	if putBody != `{"idValue":"opt_low"}` {
+		t.Fatalf("expected the dropdown label to be resolved to its option ID, got %s", putBody)
+	}
+}
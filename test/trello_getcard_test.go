@@ -0,0 +1,44 @@
+package test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+)
+
+func TestTrelloGetCard(t *testing.T) {
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/cards/card1"):
+			return jsonResponse(http.StatusOK, `{"id":"card1","name":"Fix login","desc":"details","shortUrl":"https://trello.com/c/card1","idBoard":"board1","idList":"list_doing"}`), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/lists/list_doing"):
+			return jsonResponse(http.StatusOK, `{"id":"list_doing","name":"In Progress"}`), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/cards/other"):
+			return jsonResponse(http.StatusOK, `{"id":"other","name":"Elsewhere","idBoard":"board2","idList":"list_x"}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	card, err := tc.GetCard("card1")
+	if err != nil {
+		t.Fatalf("GetCard failed: %v", err)
+	}
+	if card.GetName() != "Fix login" || card.GetURL() != "https://trello.com/c/card1" {
+		t.Fatalf("unexpected card: %s %s", card.GetName(), card.GetURL())
+	}
+	list, err := card.GetList()
+	if err != nil {
+		t.Fatalf("GetList failed: %v", err)
+	}
+	if list.GetName() != "In Progress" || list.GetID() != "list_doing" {
+		t.Fatalf("unexpected list: %s %s", list.GetName(), list.GetID())
+	}
+
+	if _, err := tc.GetCard("other"); err == nil {
+		t.Fatalf("expected an error for a card on another board")
+	}
+}
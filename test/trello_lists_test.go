@@ -0,0 +1,50 @@
+package test
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+)
+
+func TestTrelloRenameAndMoveList(t *testing.T) {
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	var sent []url.Values
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != "PUT" || req.URL.Path != "/1/lists/list1" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		}
+		sent = append(sent, req.URL.Query())
+		return jsonResponse(http.StatusOK, `{"id":"list1","name":"Review"}`), nil
+	})}
+
+	if err := tc.RenameList("list1", "Review"); err != nil {
+		t.Fatalf("RenameList failed: %v", err)
+	}
+	if err := tc.MoveList("list1", "top"); err != nil {
+		t.Fatalf("MoveList failed: %v", err)
+	}
+	if len(sent) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(sent))
+	}
+	if got := sent[0].Get("name"); got != "Review" {
+		t.Errorf("rename sent name %q", got)
+	}
+	if got := sent[1].Get("pos"); got != "top" {
+		t.Errorf("move sent pos %q", got)
+	}
+	if sent[0].Has("pos") || sent[1].Has("name") {
+		t.Errorf("requests should only carry their own field: %v", sent)
+	}
+}
+
+func TestTrelloRenameListError(t *testing.T) {
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusNotFound, `"list not found"`), nil
+	})}
+	if err := tc.RenameList("missing", "Review"); err == nil {
+		t.Fatal("expected an error for a missing list")
+	}
+}
@@ -0,0 +1,52 @@
+package test
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	trelloClient "github.com/egobogo/aiagents/internal/board/trello"
+)
+
+func TestTrelloMemberLookupsAreCached(t *testing.T) {
+	tc := trelloClient.NewTrelloClient("key", "token", "board1")
+	var memberFetches int32
+	members := `[{"id":"m_dev","username":"dev","fullName":"Developer"}]`
+	tc.Client.Client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/boards/board1/members"):
+			atomic.AddInt32(&memberFetches, 1)
+			return jsonResponse(http.StatusOK, members), nil
+		case req.Method == "GET" && strings.HasSuffix(req.URL.Path, "/cards/card1"):
+			return jsonResponse(http.StatusOK, `{"id":"card1","name":"Fix login","idMembers":["m_dev"]}`), nil
+		case req.Method == "PUT" && strings.HasSuffix(req.URL.Path, "/cards/card1"):
+			return jsonResponse(http.StatusOK, `{"id":"card1"}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+	card := &trelloClient.TrelloCard{ID: "card1", CardName: "Fix login", BoardClient: tc, Client: tc.Client}
+
+	for i := 0; i < 3; i++ {
+		if err := card.AssignTo("Developer"); err != nil {
+			t.Fatalf("AssignTo failed: %v", err)
+		}
+	}
+	assigned, err := card.GetAssignedMembers()
+	if err != nil || len(assigned) != 1 || assigned[0].Name != "Developer" {
+		t.Fatalf("unexpected assigned members %v (err %v)", assigned, err)
+	}
+	if memberFetches != 1 {
+		t.Fatalf("expected board members to be fetched once within the TTL, got %d fetches", memberFetches)
+	}
+
+	// A member missing from the cache triggers a refresh, e.g. after joining the board.
+	members = `[{"id":"m_dev","username":"dev","fullName":"Developer"},{"id":"m_qa","username":"qa","fullName":"QA"}]`
+	if err := card.AssignTo("QA"); err != nil {
+		t.Fatalf("AssignTo a new member failed: %v", err)
+	}
+	if memberFetches != 2 {
+		t.Fatalf("expected a refresh for an unknown member, got %d fetches", memberFetches)
+	}
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/httputil"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+)
+
+func TestDeleteAllStoragesIteratesEveryPage(t *testing.T) {
+	var deleted []string
+	vsClient := vectorstorage.NewClient("test-key")
+	vsClient.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v1/vector_stores":
+			if req.URL.Query().Get("after") == "" {
+				return jsonResponse(http.StatusOK, `{"data":[{"id":"vs_1","name":"aiagents"},{"id":"vs_2","name":"aiagents"}],"last_id":"vs_2","has_more":true}`), nil
+			}
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"vs_3","name":"other"}],"last_id":"vs_3","has_more":false}`), nil
+		case req.Method == "DELETE" && strings.HasPrefix(req.URL.Path, "/v1/vector_stores/"):
+			deleted = append(deleted, strings.TrimPrefix(req.URL.Path, "/v1/vector_stores/"))
+			return jsonResponse(http.StatusOK, `{"deleted":true}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	if err := vsClient.DeleteAllStorages(); err != nil {
+		t.Fatalf("DeleteAllStorages failed: %v", err)
+	}
+	sort.Strings(deleted)
+	if strings.Join(deleted, ",") != "vs_1,vs_2,vs_3" {
+		t.Fatalf("expected every store to be deleted, got %v", deleted)
+	}
+}
+
+func TestDeleteAllStoragesReportsEveryFailure(t *testing.T) {
+	var attempted []string
+	vsClient := vectorstorage.NewClient("test-key")
+	vsClient.Retry = httputil.RetryPolicy{}
+	vsClient.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v1/vector_stores":
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"vs_1"},{"id":"vs_2"},{"id":"vs_3"}],"last_id":"vs_3","has_more":false}`), nil
+		case req.Method == "DELETE" && strings.HasPrefix(req.URL.Path, "/v1/vector_stores/"):
+			id := strings.TrimPrefix(req.URL.Path, "/v1/vector_stores/")
+			attempted = append(attempted, id)
+			if id == "vs_2" {
+				return jsonResponse(http.StatusOK, `{"deleted":true}`), nil
+			}
+			return jsonResponse(http.StatusInternalServerError, `{"error":"boom"}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	err := vsClient.DeleteAllStorages()
+	if err == nil || !strings.Contains(err.Error(), "vs_1") || !strings.Contains(err.Error(), "vs_3") {
+		t.Fatalf("expected both failed stores to be reported, got %v", err)
+	}
+	if len(attempted) != 3 {
+		t.Fatalf("expected every store to be attempted, got %v", attempted)
+	}
+}
+
+func TestCleanupOrphanFilesReportsEveryFailure(t *testing.T) {
+	var attempted []string
+	vsClient := vectorstorage.NewClient("test-key")
+	vsClient.Retry = httputil.RetryPolicy{}
+	vsClient.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v1/vector_stores":
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"vs_1"}],"last_id":"vs_1","has_more":false}`), nil
+		case req.Method == "GET" && req.URL.Path == "/v1/vector_stores/vs_1/files":
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"file_kept"}],"has_more":false}`), nil
+		case req.Method == "GET" && req.URL.Path == "/v1/files":
+			return jsonResponse(http.StatusOK, `{"data":[{"id":"file_kept"},{"id":"file_a"},{"id":"file_b"}],"has_more":false}`), nil
+		case req.Method == "DELETE" && strings.HasPrefix(req.URL.Path, "/v1/files/"):
+			attempted = append(attempted, strings.TrimPrefix(req.URL.Path, "/v1/files/"))
+			return jsonResponse(http.StatusInternalServerError, `{"error":"boom"}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	err := vsClient.CleanupOrphanFiles()
+	if err == nil || !strings.Contains(err.Error(), "file_a") || !strings.Contains(err.Error(), "file_b") {
+		t.Fatalf("expected both failed deletions to be reported, got %v", err)
+	}
+	if strings.Join(attempted, ",") != "file_a,file_b" {
+		t.Fatalf("expected only the orphans to be deleted, got %v", attempted)
+	}
+}
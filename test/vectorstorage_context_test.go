@@ -0,0 +1,44 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+)
+
+func TestAttachFileContextStopsPollingWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	vsClient := vectorstorage.NewClient("test-key")
+	vsClient.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/v1/vector_stores/vs_1/files":
+			return jsonResponse(http.StatusOK, `{"id":"file_1","object":"vector_store.file"}`), nil
+		case req.Method == "GET" && req.URL.Path == "/v1/vector_stores/vs_1/files":
+			// The file is not listed yet; cancel while AttachFile waits for the next poll.
+			cancel()
+			return jsonResponse(http.StatusOK, `{"data":[],"has_more":false}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	start := time.Now()
+	_, err := vsClient.AttachFileContext(ctx, "vs_1", "file_1")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected an error wrapping context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected AttachFileContext to return promptly, took %v", elapsed)
+	}
+
+	// An already cancelled context stops before any request is sent.
+	if _, err := vsClient.ListFilesContext(ctx, "vs_1"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected ListFilesContext to fail with context.Canceled, got %v", err)
+	}
+}
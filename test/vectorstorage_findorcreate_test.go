@@ -0,0 +1,73 @@
+package test
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+)
+
+func TestFindOrCreateStorageCreatesOnce(t *testing.T) {
+	var mu sync.Mutex
+	var stores []string
+	var creates int32
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/v1/vector_stores":
+			// Slow listing widens the window in which callers could race.
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			defer mu.Unlock()
+			body := `{"data":[`
+			for i, id := range stores {
+				if i > 0 {
+					body += ","
+				}
+				body += fmt.Sprintf(`{"id":%q,"name":"race-store"}`, id)
+			}
+			return jsonResponse(http.StatusOK, body+`],"has_more":false}`), nil
+		case req.Method == "POST" && req.URL.Path == "/v1/vector_stores":
+			n := atomic.AddInt32(&creates, 1)
+			id := fmt.Sprintf("vs_%d", n)
+			mu.Lock()
+			stores = append(stores, id)
+			mu.Unlock()
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"id":%q,"name":"race-store"}`, id)), nil
+		}
+		t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		return jsonResponse(http.StatusBadRequest, `{}`), nil
+	})
+
+	const callers = 8
+	ids := make([]string, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Each caller has its own client, as separate agents would.
+			client := vectorstorage.NewClient("key")
+			client.HTTPClient = &http.Client{Transport: transport}
+			vs, err := client.FindOrCreateStorage("race-store")
+			if err != nil {
+				t.Errorf("FindOrCreateStorage failed: %v", err)
+				return
+			}
+			ids[i] = vs.ID
+		}(i)
+	}
+	wg.Wait()
+
+	if creates != 1 {
+		t.Fatalf("expected exactly one CreateStorage call, got %d", creates)
+	}
+	for _, id := range ids {
+		if id != "vs_1" {
+			t.Fatalf("expected every caller to get vs_1, got %v", ids)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	modelClient "github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+)
+
+func TestIterateFilesVisitsEveryPageOnce(t *testing.T) {
+	var requests []string
+	vsClient := vectorstorage.NewClient("test-key")
+	vsClient.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method != "GET" || req.URL.Path != "/v1/vector_stores/vs_1/files" {
+			t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		}
+		after := req.URL.Query().Get("after")
+		requests = append(requests, after)
+		switch after {
+		case "":
+			return jsonResponse(http.StatusOK, `{"object":"list","data":[{"id":"file_1","object":"vector_store.file"},{"id":"file_2","object":"vector_store.file"}],"first_id":"file_1","last_id":"file_2","has_more":true}`), nil
+		case "file_2":
+			// Fields may come in any order; without last_id the last file is the cursor.
+			return jsonResponse(http.StatusOK, `{"has_more":true,"data":[{"id":"file_3","object":"vector_store.file"}]}`), nil
+		case "file_3":
+			return jsonResponse(http.StatusOK, `{"object":"list","data":[{"id":"file_4","object":"vector_store.file"}],"last_id":"file_4","has_more":false}`), nil
+		}
+		t.Fatalf("unexpected cursor %q", after)
+		return nil, nil
+	})}
+
+	var visited []string
+	err := vsClient.IterateFiles("vs_1", func(f modelClient.File) error {
+		visited = append(visited, f.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IterateFiles failed: %v", err)
+	}
+	if want := []string{"file_1", "file_2", "file_3", "file_4"}; !reflect.DeepEqual(visited, want) {
+		t.Fatalf("expected every file once in order %v, got %v", want, visited)
+	}
+
+	// Stopping early skips the remaining pages.
+	requests = nil
+	visited = nil
+	err = vsClient.IterateFiles("vs_1", func(f modelClient.File) error {
+		visited = append(visited, f.ID)
+		if f.ID == "file_2" {
+			return vectorstorage.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("IterateFiles with early stop failed: %v", err)
+	}
+	if !reflect.DeepEqual(visited, []string{"file_1", "file_2"}) || len(requests) != 1 {
+		t.Fatalf("expected to stop after file_2 on the first page, visited %v with %d requests", visited, len(requests))
+	}
+
+	files, err := vsClient.ListFiles("vs_1")
+	if err != nil || len(files) != 4 {
+		t.Fatalf("expected ListFiles to return the four files, got %d (err %v)", len(files), err)
+	}
+}
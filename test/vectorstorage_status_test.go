@@ -0,0 +1,40 @@
+package test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/egobogo/aiagents/internal/apierr"
+	"github.com/egobogo/aiagents/internal/httputil"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+)
+
+// Rejected create, attach and detach requests return the API error instead of an empty result.
+func TestVectorStorageReportsRejectedRequests(t *testing.T) {
+	client := vectorstorage.NewClient("key")
+	client.Retry = httputil.RetryPolicy{}
+	client.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/v1/vector_stores":
+			return jsonResponse(http.StatusUnauthorized, `{"error":{"message":"bad key"}}`), nil
+		case req.Method == "POST" && req.URL.Path == "/v1/vector_stores/vs_1/files":
+			return jsonResponse(http.StatusNotFound, `{"error":{"message":"no such file"}}`), nil
+		case req.Method == "DELETE" && req.URL.Path == "/v1/vector_stores/vs_1/files/file_1":
+			return jsonResponse(http.StatusNotFound, `{"error":{"message":"no such file"}}`), nil
+		}
+		t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		return jsonResponse(http.StatusBadRequest, `{}`), nil
+	})}
+
+	if vs, err := client.CreateStorage("docs"); !errors.Is(err, apierr.ErrUnauthorized) {
+		t.Errorf("expected CreateStorage to fail as unauthorized, got %+v (err %v)", vs, err)
+	}
+	// A rejected attach fails at once instead of polling the file list until the timeout.
+	if _, err := client.AttachFile("vs_1", "file_1"); !errors.Is(err, apierr.ErrNotFound) {
+		t.Errorf("expected AttachFile to fail as not found, got %v", err)
+	}
+	if _, err := client.DeleteFile("vs_1", "file_1"); !errors.Is(err, apierr.ErrNotFound) {
+		t.Errorf("expected DeleteFile to fail as not found, got %v", err)
+	}
+}
@@ -0,0 +1,254 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/egobogo/aiagents/internal/model"
+	"github.com/egobogo/aiagents/internal/model/chatgpt/vectorstorage"
+)
+
+// countingUploader is a fake vectorstorage.Uploader that hands out sequential file IDs.
+type countingUploader struct {
+	mu      sync.Mutex
+	uploads int
+}
+
+func (u *countingUploader) UploadFile(filePath string, purpose string) (model.File, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.uploads++
+	return model.File{ID: fmt.Sprintf("file_%d", u.uploads), Filename: filepath.Base(filePath)}, nil
+}
+
+func TestSyncFilesSkipsUnchangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for _, name := range []string{"a.go", "b.go"} {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte("package "+strings.TrimSuffix(name, ".go")), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		paths = append(paths, p)
+	}
+
+	// Fake vector store that remembers attached files.
+	var mu sync.Mutex
+	var attachedIDs []string
+	vsClient := vectorstorage.NewClient("test-key")
+	vsClient.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch req.Method {
+		case "POST":
+			var id string
+			fmt.Sscanf(readBody(t, req), `{"file_id":%q}`, &id)
+			attachedIDs = append(attachedIDs, id)
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"id":%q}`, id)), nil
+		case "GET":
+			var data []string
+			for _, id := range attachedIDs {
+				data = append(data, fmt.Sprintf(`{"id":%q}`, id))
+			}
+			return jsonResponse(http.StatusOK, `{"data":[`+strings.Join(data, ",")+`],"has_more":false}`), nil
+		}
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+		return nil, nil
+	})}
+
+	indexPath := filepath.Join(dir, "uploads.json")
+	uploader := &countingUploader{}
+	for run := 1; run <= 2; run++ {
+		index, err := vectorstorage.LoadUploadIndex(indexPath)
+		if err != nil {
+			t.Fatalf("LoadUploadIndex failed: %v", err)
+		}
+		attachments, err := vsClient.SyncFiles("vs_1", paths, uploader, index)
+		if err != nil {
+			t.Fatalf("SyncFiles run %d failed: %v", run, err)
+		}
+		if len(attachments) != len(paths) {
+			t.Fatalf("run %d: expected %d attachments, got %d", run, len(paths), len(attachments))
+		}
+	}
+	if uploader.uploads != 2 {
+		t.Fatalf("expected only the first run to upload (2 files), got %d uploads", uploader.uploads)
+	}
+}
+
+// readBody returns the request body as a string.
+func readBody(t *testing.T, req *http.Request) string {
+	t.Helper()
+	if req.Body == nil {
+		return ""
+	}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatalf("failed to read request body: %v", err)
+	}
+	return string(data)
+}
+
+// slowUploader is a fake vectorstorage.Uploader that records how many uploads run at once.
+type slowUploader struct {
+	mu       sync.Mutex
+	inFlight int
+	maxSeen  int
+}
+
+func (u *slowUploader) UploadFile(filePath string, purpose string) (model.File, error) {
+	u.mu.Lock()
+	u.inFlight++
+	if u.inFlight > u.maxSeen {
+		u.maxSeen = u.inFlight
+	}
+	u.mu.Unlock()
+	time.Sleep(10 * time.Millisecond)
+	u.mu.Lock()
+	u.inFlight--
+	u.mu.Unlock()
+	if strings.HasSuffix(filePath, "broken.go") {
+		return model.File{}, fmt.Errorf("upload rejected")
+	}
+	return model.File{ID: "file_" + filepath.Base(filePath)}, nil
+}
+
+func TestSyncFilesUploadsConcurrentlyInOrder(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("f%02d.go", i)
+		if i == 7 {
+			name = "broken.go"
+		}
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(fmt.Sprintf("package f%d", i)), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		paths = append(paths, p)
+	}
+
+	var mu sync.Mutex
+	var attachedIDs []string
+	vsClient := vectorstorage.NewClient("test-key")
+	vsClient.UploadConcurrency = 3
+	vsClient.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch req.Method {
+		case "POST":
+			var id string
+			fmt.Sscanf(readBody(t, req), `{"file_id":%q}`, &id)
+			attachedIDs = append(attachedIDs, id)
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"id":%q}`, id)), nil
+		case "GET":
+			var data []string
+			for _, id := range attachedIDs {
+				data = append(data, fmt.Sprintf(`{"id":%q}`, id))
+			}
+			return jsonResponse(http.StatusOK, `{"data":[`+strings.Join(data, ",")+`],"has_more":false}`), nil
+		}
+		t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		return jsonResponse(http.StatusNotFound, `{}`), nil
+	})}
+
+	uploader := &slowUploader{}
+	index, err := vectorstorage.LoadUploadIndex("")
+	if err != nil {
+		t.Fatalf("LoadUploadIndex failed: %v", err)
+	}
+	attachments, err := vsClient.SyncFiles("vs_1", paths, uploader, index)
+	if err == nil || !strings.Contains(err.Error(), "broken.go") {
+		t.Fatalf("expected the failed upload to be reported, got %v", err)
+	}
+	if uploader.maxSeen > 3 || uploader.maxSeen < 2 {
+		t.Errorf("expected between 2 and 3 concurrent uploads, saw %d", uploader.maxSeen)
+	}
+	if len(attachments) != len(paths)-1 {
+		t.Fatalf("expected %d attachments, got %d", len(paths)-1, len(attachments))
+	}
+	i := 0
+	for _, p := range paths {
+		if strings.HasSuffix(p, "broken.go") {
+			continue
+		}
+		if want := "file_" + filepath.Base(p); attachments[i].FileID != want || attachments[i].VectorStoreID != "vs_1" {
+			t.Errorf("attachment %d: expected %s, got %+v", i, want, attachments[i])
+		}
+		i++
+	}
+}
+
+func TestSyncFilesReplacesEditedFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(path, []byte("package main"), 0644); err != nil {
+		t.Fatalf("failed to write main.go: %v", err)
+	}
+
+	// Fake vector store that tracks attached files and deleted uploads.
+	var mu sync.Mutex
+	attached := map[string]bool{}
+	var deletedUploads []string
+	vsClient := vectorstorage.NewClient("test-key")
+	vsClient.HTTPClient = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case req.Method == "POST":
+			var id string
+			fmt.Sscanf(readBody(t, req), `{"file_id":%q}`, &id)
+			attached[id] = true
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"id":%q}`, id)), nil
+		case req.Method == "GET":
+			var data []string
+			for id := range attached {
+				data = append(data, fmt.Sprintf(`{"id":%q}`, id))
+			}
+			return jsonResponse(http.StatusOK, `{"data":[`+strings.Join(data, ",")+`],"has_more":false}`), nil
+		case req.Method == "DELETE" && strings.HasPrefix(req.URL.Path, "/v1/vector_stores/vs_1/files/"):
+			id := strings.TrimPrefix(req.URL.Path, "/v1/vector_stores/vs_1/files/")
+			delete(attached, id)
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"id":%q,"deleted":true}`, id)), nil
+		case req.Method == "DELETE" && strings.HasPrefix(req.URL.Path, "/v1/files/"):
+			id := strings.TrimPrefix(req.URL.Path, "/v1/files/")
+			deletedUploads = append(deletedUploads, id)
+			return jsonResponse(http.StatusOK, fmt.Sprintf(`{"id":%q,"deleted":true}`, id)), nil
+		}
+		t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+		return jsonResponse(http.StatusNotFound, `{}`), nil
+	})}
+
+	indexPath := filepath.Join(dir, "uploads.json")
+	uploader := &countingUploader{}
+	for run, content := range []string{"package main", "package main\n\nfunc main() {}"} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write main.go: %v", err)
+		}
+		index, err := vectorstorage.LoadUploadIndex(indexPath)
+		if err != nil {
+			t.Fatalf("LoadUploadIndex failed: %v", err)
+		}
+		index.Root = dir
+		if _, err := vsClient.SyncFiles("vs_1", []string{path}, uploader, index); err != nil {
+			t.Fatalf("SyncFiles run %d failed: %v", run+1, err)
+		}
+		if entry, ok := index.Files["main.go"]; !ok || entry.FileID != fmt.Sprintf("file_%d", run+1) {
+			t.Fatalf("run %d: expected main.go to be recorded as file_%d, got %+v", run+1, run+1, index.Files)
+		}
+	}
+
+	if len(attached) != 1 || !attached["file_2"] {
+		t.Errorf("expected only the edited file to stay attached, got %v", attached)
+	}
+	if len(deletedUploads) != 1 || deletedUploads[0] != "file_1" {
+		t.Errorf("expected the superseded upload to be deleted, got %v", deletedUploads)
+	}
+}
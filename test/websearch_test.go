@@ -36,7 +36,7 @@ func TestWebSearch(t *testing.T) {
 	}
 
 	// Initialize ChatGPTClient (no vector store ID needed for web search).
-	client := chatgpt.NewChatGPTClient(apiKey, "gpt-4o-mini", "")
+	client := chatgpt.NewChatGPTClient(apiKey, "gpt-4o-mini", nil)
 
 	// Build a ChatRequest using ChatGPTPromptBuilder.
 	builder := chatgptpromptbuilder.New()
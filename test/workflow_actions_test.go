@@ -0,0 +1,64 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/workflow"
+)
+
+const actionsWorkflowYAML = `
+workflow:
+  steps:
+    - id: review
+      name: Review
+      action: test_review
+      next: close
+    - id: close
+      name: Close
+      action: test_missing
+      next: review
+workflowControl:
+  currentStep: review
+`
+
+func TestExecuteRunsRegisteredAction(t *testing.T) {
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(actionsWorkflowYAML), &cfg); err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	card := &fakeCard{name: "Ticket"}
+	wm := workflow.NewWorkflowManager(&cfg)
+	wm.Ticket = card
+
+	var got workflow.ActionContext
+	calls := 0
+	workflow.RegisterAction("test_review", func(ctx workflow.ActionContext) error {
+		calls++
+		got = ctx
+		return nil
+	})
+
+	if err := wm.Execute(); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the action to run once, ran %d times", calls)
+	}
+	if got.Step.ID != "review" || got.Ticket != card || got.Manager != wm {
+		t.Errorf("unexpected action context: %+v", got)
+	}
+
+	if err := wm.NextStep("close"); err != nil {
+		t.Fatalf("NextStep failed: %v", err)
+	}
+	if err := wm.Execute(); !errors.Is(err, workflow.ErrUnknownAction) {
+		t.Fatalf("expected ErrUnknownAction, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("the review action ran for another step")
+	}
+}
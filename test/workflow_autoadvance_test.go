@@ -0,0 +1,69 @@
+package test
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/workflow"
+)
+
+const autoAdvanceWorkflowYAML = `
+workflow:
+  steps:
+    - id: intake
+      name: Intake
+      next: spec
+    - id: spec
+      name: Write spec
+      next: estimate
+    - id: estimate
+      name: Estimate
+      next:
+        - decision:
+            - option: Small
+              nextStep: build
+            - option: Large
+              nextStep: spec
+    - id: build
+      name: Build
+      next: intake
+workflowControl:
+  currentStep: intake
+`
+
+func TestAutoAdvanceStopsAtFirstDecision(t *testing.T) {
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(autoAdvanceWorkflowYAML), &cfg); err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	wm := workflow.NewWorkflowManager(&cfg)
+
+	var visited []string
+	for {
+		advanced, err := wm.AutoAdvance()
+		if err != nil {
+			t.Fatalf("AutoAdvance failed: %v", err)
+		}
+		if !advanced {
+			break
+		}
+		step, _ := wm.CurrentStep()
+		visited = append(visited, step.ID)
+		if len(visited) > 10 {
+			t.Fatal("AutoAdvance did not stop")
+		}
+	}
+
+	if len(visited) != 2 || visited[0] != "spec" || visited[1] != "estimate" {
+		t.Fatalf("expected to walk spec then estimate, got %v", visited)
+	}
+	choices, err := wm.NextChoices()
+	if err != nil {
+		t.Fatalf("NextChoices failed: %v", err)
+	}
+	if len(choices) != 2 {
+		t.Fatalf("expected the decision's 2 choices, got %+v", choices)
+	}
+}
@@ -0,0 +1,62 @@
+package test
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/workflow"
+)
+
+const advanceWorkflowYAML = `
+workflow:
+  steps:
+    - id: spec
+      name: Write spec
+      column: Backlog
+      next: build
+    - id: build
+      name: Build
+      column: In Progress
+      next: review
+    - id: review
+      name: Code Review
+      next: spec
+workflowControl:
+  currentStep: spec
+`
+
+func TestAdvanceCardFollowsWorkflow(t *testing.T) {
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(advanceWorkflowYAML), &cfg); err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	wm := workflow.NewWorkflowManager(&cfg)
+	card := &fakeCard{name: "Ticket", list: "Backlog"}
+	b := &fakeBoard{cards: []*fakeCard{card}, lists: []string{"Backlog", "In Progress"}}
+
+	if err := wm.NextStep("build"); err != nil {
+		t.Fatalf("NextStep failed: %v", err)
+	}
+	if err := workflow.AdvanceCard(b, card, wm); err != nil {
+		t.Fatalf("AdvanceCard failed: %v", err)
+	}
+	if card.list != "In Progress" {
+		t.Fatalf("expected card in In Progress, got %q", card.list)
+	}
+
+	// The review step has no column, so its name is used and the list is created.
+	if err := wm.NextStep("review"); err != nil {
+		t.Fatalf("NextStep failed: %v", err)
+	}
+	if err := workflow.AdvanceCard(b, card, wm); err != nil {
+		t.Fatalf("AdvanceCard failed: %v", err)
+	}
+	if card.list != "Code Review" {
+		t.Fatalf("expected card in Code Review, got %q", card.list)
+	}
+	if len(b.lists) != 3 || b.lists[2] != "Code Review" {
+		t.Fatalf("expected the missing list to be created, got %v", b.lists)
+	}
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/workflow"
+)
+
+func TestNextChoicesAreStableAcrossCallsAndReload(t *testing.T) {
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(defaultBranchWorkflowYAML), &cfg); err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	wm := workflow.NewWorkflowManager(&cfg)
+
+	want := map[string][]workflow.DecisionOption{
+		"triage": {
+			{Option: "Bug", NextStep: "fix", Name: "Fix"},
+			{Option: "Feature", NextStep: "spec", Name: "Spec"},
+			{Option: "Anything else", NextStep: "clarify", Name: "Clarify", Default: true},
+		},
+		"review": {
+			{Option: "Approve", NextStep: "fix", Name: "Fix"},
+			{Option: "Unclear", NextStep: "clarify", Name: "Clarify", Default: true},
+		},
+		"fix":     {{Option: "Continue", NextStep: "triage", Name: "Triage"}},
+		"spec":    {{Option: "Continue", NextStep: "triage", Name: "Triage"}},
+		"clarify": {{Option: "Continue", NextStep: "triage", Name: "Triage"}},
+	}
+	for _, step := range cfg.Workflow.Steps {
+		if err := wm.SetCurrentStep(step.ID); err != nil {
+			t.Fatalf("SetCurrentStep(%q) failed: %v", step.ID, err)
+		}
+		for i := 0; i < 2; i++ {
+			got, err := wm.NextChoices()
+			if err != nil {
+				t.Fatalf("NextChoices at %q failed: %v", step.ID, err)
+			}
+			if !reflect.DeepEqual(got, want[step.ID]) {
+				t.Fatalf("NextChoices at %q call %d:\n got %+v\nwant %+v", step.ID, i+1, got, want[step.ID])
+			}
+			// Callers may modify the result without affecting later calls.
+			got[0].Option = "changed"
+		}
+	}
+
+	// A reloaded configuration replaces the cached choices.
+	var reloaded config.Config
+	if err := yaml.Unmarshal([]byte(strings.Replace(defaultBranchWorkflowYAML, "next: triage", "next: review", 1)), &reloaded); err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	if err := wm.SetCurrentStep("fix"); err != nil {
+		t.Fatalf("SetCurrentStep failed: %v", err)
+	}
+	wm.Reload(&reloaded)
+	got, err := wm.NextChoices()
+	if err != nil {
+		t.Fatalf("NextChoices after reload failed: %v", err)
+	}
+	if len(got) != 1 || got[0].NextStep != "review" {
+		t.Fatalf("expected the reloaded next step, got %+v", got)
+	}
+
+	// Unknown steps are still rejected.
+	if err := wm.SetCurrentStep("missing"); err == nil {
+		t.Fatal("expected an error for an unknown step")
+	}
+}
@@ -0,0 +1,76 @@
+package test
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/workflow"
+)
+
+func TestWorkflowCloneAndReset(t *testing.T) {
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(defaultBranchWorkflowYAML), &cfg); err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	wm := workflow.NewWorkflowManager(&cfg)
+
+	// Advancing a clone leaves the original and its configuration where they were.
+	clone := wm.Clone()
+	if err := clone.NextStep("fix"); err != nil {
+		t.Fatalf("NextStep on clone failed: %v", err)
+	}
+	if err := clone.NextStep("triage"); err != nil {
+		t.Fatalf("NextStep on clone failed: %v", err)
+	}
+	if err := clone.NextStep("spec"); err != nil {
+		t.Fatalf("NextStep on clone failed: %v", err)
+	}
+	if step, _ := clone.CurrentStep(); step.ID != "spec" {
+		t.Fatalf("expected the clone at spec, got %q", step.ID)
+	}
+	if step, _ := wm.CurrentStep(); step.ID != "triage" {
+		t.Fatalf("expected the original still at triage, got %q", step.ID)
+	}
+	if cfg.WorkflowControl.CurrentStep != "triage" {
+		t.Fatalf("clone changed the original config: currentStep %q", cfg.WorkflowControl.CurrentStep)
+	}
+	if err := wm.Back(); err == nil {
+		t.Fatal("expected the original to have no history")
+	}
+
+	// Back undoes one step of the clone; Reset returns it to the start and clears the history.
+	if err := clone.Back(); err != nil {
+		t.Fatalf("Back failed: %v", err)
+	}
+	if step, _ := clone.CurrentStep(); step.ID != "triage" {
+		t.Fatalf("expected Back to return to triage, got %q", step.ID)
+	}
+	if err := clone.NextStep("clarify"); err != nil {
+		t.Fatalf("NextStep on clone failed: %v", err)
+	}
+	if err := clone.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if step, _ := clone.CurrentStep(); step.ID != "triage" {
+		t.Fatalf("expected Reset to return to triage, got %q", step.ID)
+	}
+	if err := clone.Back(); err == nil {
+		t.Fatal("expected Reset to clear the history")
+	}
+
+	// Reset on the original works after it moved too.
+	if err := wm.NextStep("spec"); err != nil {
+		t.Fatalf("NextStep failed: %v", err)
+	}
+	if cfg.WorkflowControl.CurrentStep != "triage" {
+		t.Fatalf("NextStep changed the shared config: currentStep %q", cfg.WorkflowControl.CurrentStep)
+	}
+	if err := wm.Reset(); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if step, _ := wm.CurrentStep(); step.ID != "triage" || cfg.WorkflowControl.CurrentStep != "triage" {
+		t.Fatalf("expected the original reset to triage, got %q (config %q)", step.ID, cfg.WorkflowControl.CurrentStep)
+	}
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/egobogo/aiagents/internal/agent"
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/promptbuilder/chatgptpromptbuilder"
+	"github.com/egobogo/aiagents/internal/workflow"
+)
+
+func newDecidingAgent(answer string) (*agent.BaseAgent, *fakePromptBuilder) {
+	pb := &fakePromptBuilder{}
+	return &agent.BaseAgent{
+		Name:          "Manager",
+		Role:          "Manager",
+		ModelClient:   &fakeModelClient{parsed: answer},
+		Context:       &fakeContextStorage{},
+		PromptBuilder: pb,
+	}, pb
+}
+
+func loadDefaultBranchWorkflow(t *testing.T) *workflow.WorkflowManager {
+	t.Helper()
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(defaultBranchWorkflowYAML), &cfg); err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	return workflow.NewWorkflowManager(&cfg)
+}
+
+func TestDecideNextFollowsModelChoice(t *testing.T) {
+	wm := loadDefaultBranchWorkflow(t)
+	a, pb := newDecidingAgent(`{"chosenOption":"feature"}`)
+
+	next, err := workflow.DecideNext(wm, a)
+	if err != nil {
+		t.Fatalf("DecideNext failed: %v", err)
+	}
+	if next != "spec" {
+		t.Fatalf("expected spec, got %q", next)
+	}
+	if step, _ := wm.CurrentStep(); step.ID != "spec" {
+		t.Fatalf("expected the workflow at spec, got %q", step.ID)
+	}
+	if calls := pb.callsWithMode("Decide"); len(calls) != 1 || len(pb.calls) != 1 {
+		t.Errorf("expected a single Decide prompt and nothing else, got %+v", pb.calls)
+	}
+	if mems := a.Context.GetMemories(); len(mems) != 0 {
+		t.Errorf("deciding must not change the agent's memory, got %+v", mems)
+	}
+}
+
+func TestDecideNextFallsBackToDefault(t *testing.T) {
+	wm := loadDefaultBranchWorkflow(t)
+	a, pb := newDecidingAgent(`{"chosenOption":"Ship it"}`)
+
+	next, err := workflow.DecideNext(wm, a)
+	if err != nil {
+		t.Fatalf("DecideNext failed: %v", err)
+	}
+	if next != "clarify" {
+		t.Fatalf("expected the default branch clarify, got %q", next)
+	}
+	if calls := pb.callsWithMode("Decide"); len(calls) != 2 {
+		t.Errorf("expected the model to be asked twice, got %d", len(calls))
+	}
+}
+
+func TestDecideNextWithoutDefaultFails(t *testing.T) {
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(autoAdvanceWorkflowYAML), &cfg); err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	wm := workflow.NewWorkflowManager(&cfg)
+	a, _ := newDecidingAgent(`{"chosenOption":"Medium"}`)
+
+	// intake has a single next step, so it advances without asking.
+	if next, err := workflow.DecideNext(wm, a); err != nil || next != "spec" {
+		t.Fatalf("expected to advance to spec, got %q (%v)", next, err)
+	}
+	if err := wm.SetCurrentStep("estimate"); err != nil {
+		t.Fatalf("SetCurrentStep failed: %v", err)
+	}
+	if _, err := workflow.DecideNext(wm, a); !errors.Is(err, workflow.ErrNoDecision) {
+		t.Fatalf("expected ErrNoDecision, got %v", err)
+	}
+	if step, _ := wm.CurrentStep(); step.ID != "estimate" {
+		t.Errorf("expected the workflow to stay at estimate, got %q", step.ID)
+	}
+}
+
+func TestDecideNextWithoutChoicesDoesNotAsk(t *testing.T) {
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(`
+workflow:
+  steps:
+    - id: done
+      name: Done
+workflowControl:
+  currentStep: done
+`), &cfg); err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	wm := workflow.NewWorkflowManager(&cfg)
+	a, pb := newDecidingAgent(`{"chosenOption":"Done"}`)
+
+	if _, err := workflow.DecideNext(wm, a); err == nil {
+		t.Fatal("expected an error for a step without next steps")
+	}
+	if len(pb.calls) != 0 {
+		t.Errorf("expected no prompt for a step without choices, got %d", len(pb.calls))
+	}
+}
+
+// The Decide mode has a built-in prompt, so a configuration without it still builds the request.
+func TestDecideNextWithoutConfiguredDecideMode(t *testing.T) {
+	loadTestConfig(t, temperatureConfigYAML)
+	wm := loadDefaultBranchWorkflow(t)
+	a, _ := newDecidingAgent(`{"chosenOption":"Bug"}`)
+	a.Role = "Developer"
+	a.PromptBuilder = chatgptpromptbuilder.New()
+
+	if next, err := workflow.DecideNext(wm, a); err != nil || next != "fix" {
+		t.Fatalf("expected to advance to fix, got %q (%v)", next, err)
+	}
+}
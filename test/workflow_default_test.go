@@ -0,0 +1,94 @@
+package test
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/egobogo/aiagents/internal/config"
+	"github.com/egobogo/aiagents/internal/workflow"
+)
+
+const defaultBranchWorkflowYAML = `
+workflow:
+  steps:
+    - id: triage
+      name: Triage
+      options:
+        - option: Bug
+          nextStep: fix
+        - option: Feature
+          nextStep: spec
+        - option: Anything else
+          nextStep: clarify
+          default: true
+    - id: review
+      name: Review
+      next:
+        - decision:
+            - option: Approve
+              nextStep: fix
+            - option: Unclear
+              nextStep: clarify
+              default: true
+    - id: fix
+      name: Fix
+      next: triage
+    - id: spec
+      name: Spec
+      next: triage
+    - id: clarify
+      name: Clarify
+      next: triage
+workflowControl:
+  currentStep: triage
+`
+
+func TestNextStepFallsBackToDefaultBranch(t *testing.T) {
+	var cfg config.Config
+	if err := yaml.Unmarshal([]byte(defaultBranchWorkflowYAML), &cfg); err != nil {
+		t.Fatalf("failed to parse workflow: %v", err)
+	}
+	wm := workflow.NewWorkflowManager(&cfg)
+
+	choices, err := wm.NextChoices()
+	if err != nil {
+		t.Fatalf("NextChoices failed: %v", err)
+	}
+	if len(choices) != 3 || !choices[2].Default || choices[0].Default {
+		t.Fatalf("expected only the last choice to be the default, got %+v", choices)
+	}
+
+	if err := wm.NextStep("spec"); err != nil {
+		t.Fatalf("NextStep to a listed choice failed: %v", err)
+	}
+	if step, _ := wm.CurrentStep(); step.ID != "spec" {
+		t.Fatalf("expected spec, got %q", step.ID)
+	}
+
+	if err := wm.SetCurrentStep("triage"); err != nil {
+		t.Fatalf("SetCurrentStep failed: %v", err)
+	}
+	if err := wm.NextStep("deploy"); err != nil {
+		t.Fatalf("NextStep with an unexpected choice failed: %v", err)
+	}
+	if step, _ := wm.CurrentStep(); step.ID != "clarify" {
+		t.Fatalf("expected the default branch clarify, got %q", step.ID)
+	}
+
+	// Decision branches under next support the same marker.
+	if err := wm.SetCurrentStep("review"); err != nil {
+		t.Fatalf("SetCurrentStep failed: %v", err)
+	}
+	if err := wm.NextStep("unknown"); err != nil {
+		t.Fatalf("NextStep with an unexpected decision failed: %v", err)
+	}
+	if step, _ := wm.CurrentStep(); step.ID != "clarify" {
+		t.Fatalf("expected the default branch clarify, got %q", step.ID)
+	}
+
+	// Without a default, an unexpected choice is still rejected.
+	if err := wm.NextStep("unknown"); err == nil {
+		t.Fatal("expected an error for a step without a default branch")
+	}
+}